@@ -4,31 +4,38 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"flag"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"strconv"
+	// Doctor time zones must load even where the host has no zoneinfo
+	_ "time/tzdata"
 
+	"github.com/shayesteh1hs/DrAppointment/internal/config"
 	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+	"github.com/shayesteh1hs/DrAppointment/internal/provider/sms"
+	"github.com/shayesteh1hs/DrAppointment/internal/random"
 	"github.com/shayesteh1hs/DrAppointment/internal/router"
-	"github.com/shayesteh1hs/DrAppointment/internal/utils"
+	"github.com/shayesteh1hs/DrAppointment/internal/validation"
 )
 
 func main() {
-	dbConfig := database.Config{
-		Host:     utils.GetEnv("DB_HOST", "localhost"),
-		Port:     utils.GetEnvInt("DB_PORT", 5432),
-		User:     utils.GetEnv("DB_USER", "postgres"),
-		Password: utils.GetEnv("DB_PASSWORD", "postgres"),
-		DBName:   utils.GetEnv("DB_NAME", "drgo"),
-		SSLMode:  utils.GetEnv("DB_SSL_MODE", "disable"),
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path to an optional YAML config file")
+	flag.Parse()
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	log.Printf("Effective configuration:\n%s", cfg)
 
-	databaseCtx, databaseCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	dbConfig := cfg.Database.Connection()
+	databaseCtx, databaseCancel := context.WithTimeout(context.Background(), cfg.Database.ConnectTimeout)
 	defer databaseCancel()
 	db, err := database.Connect(databaseCtx, &dbConfig)
 	if err != nil {
@@ -41,9 +48,35 @@ func main() {
 		}
 	}(db)
 
-	r := router.SetupRouter(db)
+	if cfg.Cursor.Secret != "" {
+		pagination.SetCursorSigner(pagination.NewCursorSigner([]byte(cfg.Cursor.Secret), cfg.Cursor.TTL))
+	} else {
+		log.Println("CURSOR_SECRET is not set; pagination cursors are signed with a random key and will not survive restarts")
+	}
+
+	if cfg.Auth.JWTSecret == "" {
+		log.Println("JWT_SECRET is not set; tokens are signed with a random key and every session ends on restart")
+		cfg.Auth.JWTSecret = config.Secret(random.Hex(32))
+	}
 
-	port := utils.GetEnvInt("PORT", 8000)
+	sender, closeSender, err := newSMSSender(cfg.SMS)
+	if err != nil {
+		log.Fatalf("Failed to set up SMS sender: %v", err)
+	}
+	defer func() {
+		if err := closeSender(); err != nil {
+			log.Printf("Failed to close SMS sender: %v", err)
+		}
+	}()
+
+	translator, err := validation.RegisterBinding()
+	if err != nil {
+		log.Fatalf("Failed to set up request validation: %v", err)
+	}
+
+	r := router.SetupRouter(db, cfg, sender, translator)
+
+	port := cfg.Server.Port
 	server := &http.Server{
 		Addr:    ":" + strconv.Itoa(port),
 		Handler: r,
@@ -62,7 +95,7 @@ func main() {
 	<-quit
 	log.Println("Shutting down server...")
 
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer shutdownCancel()
 	if err := server.Shutdown(shutdownCtx); err != nil {
 		log.Fatal("Server forced to shutdown:", err)
@@ -70,3 +103,12 @@ func main() {
 
 	log.Println("Server exiting")
 }
+
+func newSMSSender(cfg config.SMSConfig) (sms.Sender, func() error, error) {
+	switch cfg.Driver {
+	case "file":
+		return sms.NewFileSender(cfg.File)
+	default:
+		return sms.NewConsoleSender(os.Stdout), func() error { return nil }, nil
+	}
+}
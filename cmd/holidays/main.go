@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/config"
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	"github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/scheduling"
+)
+
+// Loads a list of public holidays from a CSV file with "date,name" rows.
+// Existing holidays on the same date are renamed, so the import can be re-run safely.
+func main() {
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path to an optional YAML config file")
+	file := flag.String("file", "", "path to a CSV file with date,name rows (YYYY-MM-DD dates)")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("Failed to open holidays file: %v", err)
+	}
+	defer func(f *os.File) {
+		err := f.Close()
+		if err != nil {
+			log.Printf("Failed to close holidays file: %v", err)
+		}
+	}(f)
+
+	holidays, err := scheduling.ParseHolidaysCSV(f)
+	if err != nil {
+		log.Fatalf("Failed to parse holidays file: %v", err)
+	}
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	dbConfig := cfg.Database.Connection()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	db, err := database.Connect(ctx, &dbConfig)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer func(db *sql.DB) {
+		err := db.Close()
+		if err != nil {
+			log.Printf("Failed to close database: %v", err)
+		}
+	}(db)
+
+	affected, err := medical.NewHolidayRepository(db).Upsert(ctx, holidays)
+	if err != nil {
+		log.Fatalf("Failed to import holidays: %v", err)
+	}
+
+	log.Printf("Imported %d holidays (%d rows written)", len(holidays), affected)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/config"
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	"github.com/shayesteh1hs/DrAppointment/internal/database/migrate"
+	"github.com/shayesteh1hs/DrAppointment/internal/database/migrations"
+)
+
+const usage = `Usage: migrate [flags] <command>
+
+Commands:
+  up      apply changed functions/ scripts and all pending migrations
+  down    roll back the latest applied migrations (see -steps)
+  status  list migrations and whether they are applied
+  redo    roll back the latest applied migration and apply it again
+
+Flags:
+`
+
+// Applies the SQL migrations embedded from internal/database/migrations.
+// Concurrent runs are serialized with a PostgreSQL advisory lock.
+func main() {
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path to an optional YAML config file")
+	steps := flag.Int("steps", 1, "number of migrations to roll back with down")
+	timeout := flag.Duration("timeout", 5*time.Minute, "maximum time the command may run")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	source, err := migrate.Load(migrations.FS)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	dbConfig := cfg.Database.Connection()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	db, err := database.Connect(ctx, &dbConfig)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer func(db *sql.DB) {
+		err := db.Close()
+		if err != nil {
+			log.Printf("Failed to close database: %v", err)
+		}
+	}(db)
+
+	migrator := migrate.NewMigrator(db, source)
+
+	switch command := flag.Arg(0); command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			log.Printf("Applied %s", m)
+		}
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		if len(applied) == 0 {
+			log.Println("Database is up to date")
+		}
+	case "down":
+		rolledBack, err := migrator.Down(ctx, *steps)
+		for _, m := range rolledBack {
+			log.Printf("Rolled back %s", m)
+		}
+		if err != nil {
+			log.Fatalf("Rollback failed: %v", err)
+		}
+		if len(rolledBack) == 0 {
+			log.Println("No migrations to roll back")
+		}
+	case "redo":
+		redone, err := migrator.Redo(ctx)
+		if err != nil {
+			log.Fatalf("Redo failed: %v", err)
+		}
+		if redone == nil {
+			log.Println("No migrations to redo")
+		} else {
+			log.Printf("Redid %s", redone)
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		printStatus(statuses)
+	default:
+		log.Printf("Unknown command %q", command)
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func printStatus(statuses []migrate.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "-"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+		_, _ = fmt.Fprintf(w, "%03d\t%s\t%s\t%s\n", s.Version, s.Name, s.State, appliedAt)
+	}
+	if err := w.Flush(); err != nil {
+		log.Printf("Failed to print status: %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/config"
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	"github.com/shayesteh1hs/DrAppointment/internal/seed"
+)
+
+// defaultFrom is a fixed Saturday, the first day of the clinic week, so a --seed alone always
+// produces the same dataset
+const defaultFrom = "2025-01-04"
+
+// Seeds the database with specialties, doctors, weekly schedules and appointments for development.
+// The same --seed, --from and --now always produce the same rows, ids included, and
+// --reset empties the seeded tables first so every run ends with an identical dataset.
+func main() {
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path to an optional YAML config file")
+	seedValue := flag.Uint64("seed", 1, "random seed; equal seeds produce equal datasets")
+	specialties := flag.Int("specialties", 8, "number of specialties")
+	doctors := flag.Int("doctors", 50, "number of doctors")
+	patients := flag.Int("patients", 30, "number of patients")
+	appointments := flag.Int("appointments", 5, "appointments per doctor")
+	from := flag.String("from", defaultFrom, "date (YYYY-MM-DD) appointments are spread around")
+	days := flag.Int("days", 14, "days before and after --from that appointments may fall on")
+	now := flag.String("now", "", "time (RFC 3339) before which appointments are past; defaults to the start of --from")
+	reset := flag.Bool("reset", false, "truncate specialties, doctors, schedules, time off, patients and appointments before seeding")
+	flag.Parse()
+
+	anchor, err := time.Parse(time.DateOnly, *from)
+	if err != nil {
+		log.Fatalf("Invalid --from date: %v", err)
+	}
+	current := anchor
+	if *now != "" {
+		if current, err = time.Parse(time.RFC3339, *now); err != nil {
+			log.Fatalf("Invalid --now time: %v", err)
+		}
+	}
+
+	data, err := seed.Generate(seed.Config{
+		Seed:                  *seedValue,
+		Specialties:           *specialties,
+		Doctors:               *doctors,
+		Patients:              *patients,
+		AppointmentsPerDoctor: *appointments,
+		From:                  anchor,
+		Days:                  *days,
+		Now:                   current,
+	})
+	if err != nil {
+		log.Fatalf("Invalid seed configuration: %v", err)
+	}
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	dbConfig := cfg.Database.Connection()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	db, err := database.Connect(ctx, &dbConfig)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer func(db *sql.DB) {
+		err := db.Close()
+		if err != nil {
+			log.Printf("Failed to close database: %v", err)
+		}
+	}(db)
+
+	result, err := seed.Write(ctx, db, data, *reset)
+	if err != nil {
+		log.Fatalf("Failed to seed database: %v", err)
+	}
+
+	log.Printf("Seeded %d specialties, %d doctors, %d schedules, %d patients and %d appointments (seed %d, from %s)",
+		result.Specialties, result.Doctors, result.Schedules, result.Patients, result.Appointments, *seedValue, anchor.Format(time.DateOnly))
+}
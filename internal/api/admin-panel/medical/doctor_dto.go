@@ -0,0 +1,43 @@
+package medical
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+
+	authService "github.com/shayesteh1hs/DrAppointment/internal/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+// DoctorRequest creates a doctor or replaces all editable fields of one
+type DoctorRequest struct {
+	Name        string    `json:"name"`
+	SpecialtyID uuid.UUID `json:"specialty_id"`
+	PhoneNumber string    `json:"phone_number"`
+	AvatarURL   string    `json:"avatar_url"`
+	Description string    `json:"description"`
+	// TimeZone is an IANA name such as Asia/Tehran; medical.DefaultTimeZone when empty
+	TimeZone string `json:"time_zone"`
+}
+
+// ToDoctor builds the doctor to save, normalizing the phone number the doctor will sign in with.
+// The result is validated by Doctor.Validate.
+func (r DoctorRequest) ToDoctor(id uuid.UUID) (medical.Doctor, error) {
+	phone, err := authService.NormalizePhoneNumber(r.PhoneNumber)
+	if err != nil {
+		return medical.Doctor{}, err
+	}
+	timeZone := strings.TrimSpace(r.TimeZone)
+	if timeZone == "" {
+		timeZone = medical.DefaultTimeZone
+	}
+	return medical.Doctor{
+		ID:          id,
+		Name:        strings.TrimSpace(r.Name),
+		SpecialtyID: r.SpecialtyID,
+		PhoneNumber: phone,
+		AvatarURL:   strings.TrimSpace(r.AvatarURL),
+		Description: strings.TrimSpace(r.Description),
+		TimeZone:    timeZone,
+	}, nil
+}
@@ -0,0 +1,138 @@
+package medical
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/api/response"
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+)
+
+// DoctorCatalog changes doctors on behalf of an admin and records each change in the audit log
+type DoctorCatalog interface {
+	CreateDoctor(ctx context.Context, actor auth.Principal, doctor *medical.Doctor) error
+	UpdateDoctor(ctx context.Context, actor auth.Principal, doctor *medical.Doctor) error
+	DeleteDoctor(ctx context.Context, actor auth.Principal, id uuid.UUID) error
+}
+
+// DoctorHandler lets admins register, edit and remove doctors.
+// Its routes must be mounted behind middleware.Authenticate.
+type DoctorHandler struct {
+	service DoctorCatalog
+}
+
+func NewDoctorHandler(service DoctorCatalog) *DoctorHandler {
+	return &DoctorHandler{
+		service: service,
+	}
+}
+
+func (h *DoctorHandler) Create(c *gin.Context) {
+	actor, ok := currentAdmin(c)
+	if !ok {
+		return
+	}
+
+	doctor, ok := bindDoctor(c, uuid.Nil)
+	if !ok {
+		return
+	}
+
+	if err := h.service.CreateDoctor(c.Request.Context(), actor, &doctor); err != nil {
+		switch {
+		case errors.Is(err, medical.ErrSpecialtyNotFound):
+			_ = c.Error(apperror.Invalid("Specialty does not exist", err))
+		default:
+			_ = c.Error(fmt.Errorf("create doctor: %w", err))
+		}
+		return
+	}
+
+	middleware.JSON(c, http.StatusCreated, response.NewDoctor(doctor))
+}
+
+func (h *DoctorHandler) Update(c *gin.Context) {
+	actor, ok := currentAdmin(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.Invalid("Invalid doctor id", err))
+		return
+	}
+	doctor, ok := bindDoctor(c, id)
+	if !ok {
+		return
+	}
+
+	if err := h.service.UpdateDoctor(c.Request.Context(), actor, &doctor); err != nil {
+		switch {
+		// Checked first: the specialty error is a not-found error too, but it is about the request body
+		case errors.Is(err, medical.ErrSpecialtyNotFound):
+			_ = c.Error(apperror.Invalid("Specialty does not exist", err))
+		default:
+			_ = c.Error(fmt.Errorf("update doctor: %w", err))
+		}
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, response.NewDoctor(doctor))
+}
+
+// Delete removes a doctor; doctors with appointments on record are kept and 409 is returned
+func (h *DoctorHandler) Delete(c *gin.Context) {
+	actor, ok := currentAdmin(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.Invalid("Invalid doctor id", err))
+		return
+	}
+
+	if err := h.service.DeleteDoctor(c.Request.Context(), actor, id); err != nil {
+		_ = c.Error(fmt.Errorf("delete doctor: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// bindDoctor reads and validates the request body, writing the error response when it is invalid
+func bindDoctor(c *gin.Context, id uuid.UUID) (medical.Doctor, bool) {
+	var req DoctorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid doctor request", err))
+		return medical.Doctor{}, false
+	}
+	doctor, err := req.ToDoctor(id)
+	if err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return medical.Doctor{}, false
+	}
+	if err := doctor.Validate(); err != nil {
+		_ = c.Error(err)
+		return medical.Doctor{}, false
+	}
+	return doctor, true
+}
+
+func (h *DoctorHandler) RegisterRoutes(router *gin.RouterGroup) {
+	doctorRoutes := router.Group("/doctors")
+
+	doctorRoutes.POST("", h.Create)
+	doctorRoutes.PUT("/:id", h.Update)
+	doctorRoutes.DELETE("/:id", h.Delete)
+}
@@ -0,0 +1,111 @@
+package medical
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	domainMedical "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+func TestDoctorHandler_Create(t *testing.T) {
+	adminID := uuid.New()
+	specialtyID := uuid.New()
+
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(*MockCatalogService)
+		expectedStatusCode int
+	}{
+		{
+			name: "Success - Phone number is normalized",
+			body: `{"name": "دکتر رضایی", "specialty_id": "` + specialtyID.String() + `", "phone_number": "۰۹۱۲۱۲۳۴۵۶۷"}`,
+			mockSetup: func(svc *MockCatalogService) {
+				svc.On("CreateDoctor", mock.Anything, mock.Anything, mock.MatchedBy(func(d *domainMedical.Doctor) bool {
+					return d.Name == "دکتر رضایی" && d.SpecialtyID == specialtyID && d.PhoneNumber == "+989121234567" &&
+						d.TimeZone == domainMedical.DefaultTimeZone
+				})).Return(nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Error - Missing specialty",
+			body:               `{"name": "دکتر رضایی", "phone_number": "09121234567"}`,
+			mockSetup:          func(svc *MockCatalogService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Error - Unknown time zone",
+			body:               `{"name": "دکتر رضایی", "specialty_id": "` + specialtyID.String() + `", "phone_number": "09121234567", "time_zone": "Mars/Olympus"}`,
+			mockSetup:          func(svc *MockCatalogService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Error - Invalid avatar url",
+			body:               `{"name": "دکتر رضایی", "specialty_id": "` + specialtyID.String() + `", "phone_number": "09121234567", "avatar_url": "not a url"}`,
+			mockSetup:          func(svc *MockCatalogService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error - Unknown specialty",
+			body: `{"name": "دکتر رضایی", "specialty_id": "` + specialtyID.String() + `", "phone_number": "09121234567"}`,
+			mockSetup: func(svc *MockCatalogService) {
+				svc.On("CreateDoctor", mock.Anything, mock.Anything, mock.Anything).Return(domainMedical.ErrSpecialtyNotFound)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error - Phone number of another doctor",
+			body: `{"name": "دکتر رضایی", "specialty_id": "` + specialtyID.String() + `", "phone_number": "09121234567"}`,
+			mockSetup: func(svc *MockCatalogService) {
+				svc.On("CreateDoctor", mock.Anything, mock.Anything, mock.Anything).Return(domainMedical.ErrPhoneNumberTaken)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCatalogService)
+			tt.mockSetup(mockService)
+			router := newAdminTestRouter(NewDoctorHandler(mockService), adminID)
+
+			w := serveAuthenticated(t, router, http.MethodPost, "/doctors", tt.body)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDoctorHandler_Delete(t *testing.T) {
+	adminID := uuid.New()
+	doctorID := uuid.New()
+
+	tests := []struct {
+		name               string
+		mockErr            error
+		expectedStatusCode int
+	}{
+		{name: "Success", expectedStatusCode: http.StatusNoContent},
+		{name: "Error - Doctor has appointments", mockErr: domainMedical.ErrDoctorHasAppointments, expectedStatusCode: http.StatusConflict},
+		{name: "Error - Doctor not found", mockErr: domainMedical.ErrDoctorNotFound, expectedStatusCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCatalogService)
+			mockService.On("DeleteDoctor", mock.Anything, mock.Anything, doctorID).Return(tt.mockErr)
+			router := newAdminTestRouter(NewDoctorHandler(mockService), adminID)
+
+			w := serveAuthenticated(t, router, http.MethodDelete, "/doctors/"+doctorID.String(), "")
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package medical
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+// SpecialtyRequest creates or renames a specialty
+type SpecialtyRequest struct {
+	Name string `json:"name"`
+}
+
+// ToSpecialty builds the specialty to save; it is validated by Specialty.Validate
+func (r SpecialtyRequest) ToSpecialty(id uuid.UUID) medical.Specialty {
+	return medical.Specialty{
+		ID:   id,
+		Name: strings.TrimSpace(r.Name),
+	}
+}
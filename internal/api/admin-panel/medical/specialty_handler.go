@@ -0,0 +1,130 @@
+package medical
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/api/response"
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+)
+
+// SpecialtyCatalog changes specialties on behalf of an admin and records each change in the audit log
+type SpecialtyCatalog interface {
+	CreateSpecialty(ctx context.Context, actor auth.Principal, specialty *medical.Specialty) error
+	UpdateSpecialty(ctx context.Context, actor auth.Principal, specialty *medical.Specialty) error
+	DeleteSpecialty(ctx context.Context, actor auth.Principal, id uuid.UUID) error
+}
+
+// SpecialtyHandler lets admins manage the specialty catalog.
+// Its routes must be mounted behind middleware.Authenticate.
+type SpecialtyHandler struct {
+	service SpecialtyCatalog
+}
+
+func NewSpecialtyHandler(service SpecialtyCatalog) *SpecialtyHandler {
+	return &SpecialtyHandler{
+		service: service,
+	}
+}
+
+func (h *SpecialtyHandler) Create(c *gin.Context) {
+	actor, ok := currentAdmin(c)
+	if !ok {
+		return
+	}
+
+	var req SpecialtyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid specialty request", err))
+		return
+	}
+	specialty := req.ToSpecialty(uuid.Nil)
+	if err := specialty.Validate(); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.service.CreateSpecialty(c.Request.Context(), actor, &specialty); err != nil {
+		_ = c.Error(fmt.Errorf("create specialty: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusCreated, response.NewSpecialty(specialty))
+}
+
+func (h *SpecialtyHandler) Update(c *gin.Context) {
+	actor, ok := currentAdmin(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.Invalid("Invalid specialty id", err))
+		return
+	}
+
+	var req SpecialtyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid specialty request", err))
+		return
+	}
+	specialty := req.ToSpecialty(id)
+	if err := specialty.Validate(); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.service.UpdateSpecialty(c.Request.Context(), actor, &specialty); err != nil {
+		_ = c.Error(fmt.Errorf("update specialty: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, response.NewSpecialty(specialty))
+}
+
+// Delete removes a specialty; while doctors still belong to it the database refuses and 409 is returned
+func (h *SpecialtyHandler) Delete(c *gin.Context) {
+	actor, ok := currentAdmin(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.Invalid("Invalid specialty id", err))
+		return
+	}
+
+	if err := h.service.DeleteSpecialty(c.Request.Context(), actor, id); err != nil {
+		_ = c.Error(fmt.Errorf("delete specialty: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// currentAdmin returns the authenticated admin, who is recorded as the actor of every change
+func currentAdmin(c *gin.Context) (auth.Principal, bool) {
+	principal, ok := middleware.PrincipalFrom(c)
+	if !ok {
+		_ = c.Error(auth.ErrUnauthenticated)
+		return auth.Principal{}, false
+	}
+	return principal, true
+}
+
+func (h *SpecialtyHandler) RegisterRoutes(router *gin.RouterGroup) {
+	specialtyRoutes := router.Group("/specialties")
+
+	specialtyRoutes.POST("", h.Create)
+	specialtyRoutes.PUT("/:id", h.Update)
+	specialtyRoutes.DELETE("/:id", h.Delete)
+}
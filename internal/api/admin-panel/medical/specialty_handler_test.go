@@ -0,0 +1,209 @@
+package medical
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	domainMedical "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+	"github.com/shayesteh1hs/DrAppointment/internal/validation"
+)
+
+// Mock service
+type MockCatalogService struct {
+	mock.Mock
+}
+
+func (m *MockCatalogService) CreateSpecialty(ctx context.Context, actor auth.Principal, specialty *domainMedical.Specialty) error {
+	args := m.Called(ctx, actor, specialty)
+	return args.Error(0)
+}
+
+func (m *MockCatalogService) UpdateSpecialty(ctx context.Context, actor auth.Principal, specialty *domainMedical.Specialty) error {
+	args := m.Called(ctx, actor, specialty)
+	return args.Error(0)
+}
+
+func (m *MockCatalogService) DeleteSpecialty(ctx context.Context, actor auth.Principal, id uuid.UUID) error {
+	args := m.Called(ctx, actor, id)
+	return args.Error(0)
+}
+
+func (m *MockCatalogService) CreateDoctor(ctx context.Context, actor auth.Principal, doctor *domainMedical.Doctor) error {
+	args := m.Called(ctx, actor, doctor)
+	return args.Error(0)
+}
+
+func (m *MockCatalogService) UpdateDoctor(ctx context.Context, actor auth.Principal, doctor *domainMedical.Doctor) error {
+	args := m.Called(ctx, actor, doctor)
+	return args.Error(0)
+}
+
+func (m *MockCatalogService) DeleteDoctor(ctx context.Context, actor auth.Principal, id uuid.UUID) error {
+	args := m.Called(ctx, actor, id)
+	return args.Error(0)
+}
+
+// Helper functions
+
+// stubTokens accepts any bearer token as an access token of the admin
+type stubTokens struct {
+	adminID uuid.UUID
+}
+
+func (s stubTokens) Parse(string, auth.TokenType) (*auth.Claims, error) {
+	return &auth.Claims{Subject: s.adminID.String(), Role: auth.RoleAdmin, Type: auth.TokenTypeAccess}, nil
+}
+
+type routeRegistrar interface {
+	RegisterRoutes(router *gin.RouterGroup)
+}
+
+func newAdminTestRouter(handler routeRegistrar, adminID uuid.UUID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler(validation.NewTranslator()), middleware.Authenticate(stubTokens{adminID: adminID}))
+	handler.RegisterRoutes(router.Group(""))
+	return router
+}
+
+func serveAuthenticated(t *testing.T, router *gin.Engine, method, url, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	req.RequestURI = url
+	req.Header.Set("Authorization", "Bearer test")
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// Table-driven tests
+
+func TestSpecialtyHandler_Create(t *testing.T) {
+	adminID := uuid.New()
+	actor := auth.Principal{ID: adminID, Role: auth.RoleAdmin}
+
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(*MockCatalogService)
+		expectedStatusCode int
+	}{
+		{
+			name: "Success - Name is trimmed and admin is the actor",
+			body: `{"name": "  قلب و عروق "}`,
+			mockSetup: func(svc *MockCatalogService) {
+				svc.On("CreateSpecialty", mock.Anything, actor, mock.MatchedBy(func(s *domainMedical.Specialty) bool {
+					return s.Name == "قلب و عروق"
+				})).Return(nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Error - Empty name",
+			body:               `{"name": "   "}`,
+			mockSetup:          func(svc *MockCatalogService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error - Duplicate name",
+			body: `{"name": "قلب و عروق"}`,
+			mockSetup: func(svc *MockCatalogService) {
+				svc.On("CreateSpecialty", mock.Anything, actor, mock.Anything).Return(domainMedical.ErrSpecialtyNameTaken)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCatalogService)
+			tt.mockSetup(mockService)
+			router := newAdminTestRouter(NewSpecialtyHandler(mockService), adminID)
+
+			w := serveAuthenticated(t, router, http.MethodPost, "/specialties", tt.body)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSpecialtyHandler_Delete(t *testing.T) {
+	adminID := uuid.New()
+	specialtyID := uuid.New()
+
+	tests := []struct {
+		name               string
+		path               string
+		mockSetup          func(*MockCatalogService)
+		expectedStatusCode int
+		expectedCode       string
+	}{
+		{
+			name: "Success",
+			path: "/specialties/" + specialtyID.String(),
+			mockSetup: func(svc *MockCatalogService) {
+				svc.On("DeleteSpecialty", mock.Anything, mock.Anything, specialtyID).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name: "Error - Doctors still belong to the specialty",
+			path: "/specialties/" + specialtyID.String(),
+			mockSetup: func(svc *MockCatalogService) {
+				svc.On("DeleteSpecialty", mock.Anything, mock.Anything, specialtyID).Return(domainMedical.ErrSpecialtyInUse)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedCode:       "specialty_in_use",
+		},
+		{
+			name: "Error - Specialty not found",
+			path: "/specialties/" + specialtyID.String(),
+			mockSetup: func(svc *MockCatalogService) {
+				svc.On("DeleteSpecialty", mock.Anything, mock.Anything, specialtyID).Return(domainMedical.ErrSpecialtyNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "Error - Invalid id",
+			path:               "/specialties/not-a-uuid",
+			mockSetup:          func(svc *MockCatalogService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCatalogService)
+			tt.mockSetup(mockService)
+			router := newAdminTestRouter(NewSpecialtyHandler(mockService), adminID)
+
+			w := serveAuthenticated(t, router, http.MethodDelete, tt.path, "")
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			if tt.expectedCode != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedCode, response["code"])
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"github.com/shayesteh1hs/DrAppointment/internal/api/response"
+	authService "github.com/shayesteh1hs/DrAppointment/internal/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/calendar"
+	domainAuth "github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+)
+
+type RequestOTPRequest struct {
+	PhoneNumber string `json:"phone_number" binding:"required"`
+}
+
+type VerifyOTPRequest struct {
+	PhoneNumber string `json:"phone_number" binding:"required"`
+	Code        string `json:"code" binding:"required,len=6,numeric"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// OTPChallengeResponse describes a login code that was just sent
+type OTPChallengeResponse struct {
+	authService.OTPChallenge
+	ExpiresAtJalali string `json:"expires_at_jalali,omitempty"`
+	ResendAtJalali  string `json:"resend_at_jalali,omitempty"`
+}
+
+func (r OTPChallengeResponse) Present(system calendar.System) any {
+	if system == calendar.Jalali {
+		r.ExpiresAtJalali = system.FormatTime(r.ExpiresAt)
+		r.ResendAtJalali = system.FormatTime(r.ResendAt)
+	}
+	return r
+}
+
+// LoginResponse is the tokens and profile of a patient who logged in
+type LoginResponse struct {
+	domainAuth.TokenPair
+	Patient response.Patient `json:"patient"`
+}
+
+func NewLoginResponse(login *authService.Login) LoginResponse {
+	return LoginResponse{TokenPair: login.TokenPair, Patient: response.NewPatient(*login.Patient)}
+}
+
+func (r LoginResponse) Present(system calendar.System) any {
+	r.Patient = r.Patient.In(system)
+	return r
+}
+
+// DoctorLoginResponse is the tokens and profile of a doctor who logged in
+type DoctorLoginResponse struct {
+	domainAuth.TokenPair
+	Doctor response.Doctor `json:"doctor"`
+}
+
+func NewDoctorLoginResponse(login *authService.DoctorLogin) DoctorLoginResponse {
+	return DoctorLoginResponse{TokenPair: login.TokenPair, Doctor: response.NewDoctor(*login.Doctor)}
+}
+
+func (r DoctorLoginResponse) Present(system calendar.System) any {
+	r.Doctor = r.Doctor.In(system)
+	return r
+}
+
+// AdminLoginResponse is the tokens and profile of an admin who logged in
+type AdminLoginResponse struct {
+	domainAuth.TokenPair
+	Admin response.Admin `json:"admin"`
+}
+
+func NewAdminLoginResponse(login *authService.AdminLogin) AdminLoginResponse {
+	return AdminLoginResponse{TokenPair: login.TokenPair, Admin: response.NewAdmin(*login.Admin)}
+}
+
+func (r AdminLoginResponse) Present(system calendar.System) any {
+	r.Admin = r.Admin.In(system)
+	return r
+}
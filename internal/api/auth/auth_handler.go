@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
+	authService "github.com/shayesteh1hs/DrAppointment/internal/auth"
+	domainAuth "github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+)
+
+// Service is the part of auth.Service the handler depends on
+type Service interface {
+	RequestOTP(ctx context.Context, phoneNumber string) (*authService.OTPChallenge, error)
+	VerifyOTP(ctx context.Context, phoneNumber, code string) (*authService.Login, error)
+	VerifyDoctorOTP(ctx context.Context, phoneNumber, code string) (*authService.DoctorLogin, error)
+	VerifyAdminOTP(ctx context.Context, phoneNumber, code string) (*authService.AdminLogin, error)
+	Refresh(ctx context.Context, refreshToken string) (*domainAuth.TokenPair, error)
+}
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RequestOTP(c *gin.Context) {
+	var req RequestOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid login code request", err))
+		return
+	}
+
+	challenge, err := h.service.RequestOTP(c.Request.Context(), req.PhoneNumber)
+	if err != nil {
+		var cooldown *authService.CooldownError
+		switch {
+		case errors.Is(err, authService.ErrInvalidPhoneNumber):
+			_ = c.Error(apperror.Invalid(err.Error(), err))
+		case errors.As(err, &cooldown):
+			c.Header("Retry-After", strconv.Itoa(int(cooldown.RetryAfter.Seconds())))
+			_ = c.Error(err)
+		default:
+			_ = c.Error(fmt.Errorf("send login code: %w", err))
+		}
+		return
+	}
+
+	middleware.JSON(c, http.StatusAccepted, OTPChallengeResponse{OTPChallenge: *challenge})
+}
+
+func (h *Handler) VerifyOTP(c *gin.Context) {
+	var req VerifyOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid login code verification request", err))
+		return
+	}
+
+	login, err := h.service.VerifyOTP(c.Request.Context(), req.PhoneNumber, req.Code)
+	if err != nil {
+		if errors.Is(err, authService.ErrInvalidPhoneNumber) {
+			_ = c.Error(apperror.Invalid(err.Error(), err))
+			return
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, NewLoginResponse(login))
+}
+
+// VerifyDoctorOTP logs in a doctor with a code requested through RequestOTP
+func (h *Handler) VerifyDoctorOTP(c *gin.Context) {
+	var req VerifyOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid login code verification request", err))
+		return
+	}
+
+	login, err := h.service.VerifyDoctorOTP(c.Request.Context(), req.PhoneNumber, req.Code)
+	if err != nil {
+		if errors.Is(err, authService.ErrInvalidPhoneNumber) {
+			_ = c.Error(apperror.Invalid(err.Error(), err))
+			return
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, NewDoctorLoginResponse(login))
+}
+
+// VerifyAdminOTP logs in an admin with a code requested through RequestOTP
+func (h *Handler) VerifyAdminOTP(c *gin.Context) {
+	var req VerifyOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid login code verification request", err))
+		return
+	}
+
+	login, err := h.service.VerifyAdminOTP(c.Request.Context(), req.PhoneNumber, req.Code)
+	if err != nil {
+		if errors.Is(err, authService.ErrInvalidPhoneNumber) {
+			_ = c.Error(apperror.Invalid(err.Error(), err))
+			return
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, NewAdminLoginResponse(login))
+}
+
+func (h *Handler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid refresh request", err))
+		return
+	}
+
+	tokens, err := h.service.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	authRoutes := router.Group("/auth")
+
+	authRoutes.POST("/otp/request", h.RequestOTP)
+	authRoutes.POST("/otp/verify", h.VerifyOTP)
+	authRoutes.POST("/doctor/otp/verify", h.VerifyDoctorOTP)
+	authRoutes.POST("/admin/otp/verify", h.VerifyAdminOTP)
+	authRoutes.POST("/refresh", h.Refresh)
+}
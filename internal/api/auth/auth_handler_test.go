@@ -0,0 +1,307 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	authService "github.com/shayesteh1hs/DrAppointment/internal/auth"
+	domainAuth "github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+	"github.com/shayesteh1hs/DrAppointment/internal/validation"
+)
+
+// Mock service
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) RequestOTP(ctx context.Context, phoneNumber string) (*authService.OTPChallenge, error) {
+	args := m.Called(ctx, phoneNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authService.OTPChallenge), args.Error(1)
+}
+
+func (m *MockService) VerifyOTP(ctx context.Context, phoneNumber, code string) (*authService.Login, error) {
+	args := m.Called(ctx, phoneNumber, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authService.Login), args.Error(1)
+}
+
+func (m *MockService) VerifyDoctorOTP(ctx context.Context, phoneNumber, code string) (*authService.DoctorLogin, error) {
+	args := m.Called(ctx, phoneNumber, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authService.DoctorLogin), args.Error(1)
+}
+
+func (m *MockService) VerifyAdminOTP(ctx context.Context, phoneNumber, code string) (*authService.AdminLogin, error) {
+	args := m.Called(ctx, phoneNumber, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authService.AdminLogin), args.Error(1)
+}
+
+func (m *MockService) Refresh(ctx context.Context, refreshToken string) (*domainAuth.TokenPair, error) {
+	args := m.Called(ctx, refreshToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainAuth.TokenPair), args.Error(1)
+}
+
+func newTestRouter(service *MockService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler(validation.NewTranslator()))
+	NewHandler(service).RegisterRoutes(router.Group(""))
+	return router
+}
+
+func postJSON(router *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_RequestOTP(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(*MockService)
+		expectedStatusCode int
+		expectedCode       string
+		expectedRetry      string
+	}{
+		{
+			name: "code sent",
+			body: `{"phone_number": "09121234567"}`,
+			mockSetup: func(m *MockService) {
+				m.On("RequestOTP", mock.Anything, "09121234567").Return(&authService.OTPChallenge{
+					PhoneNumber: "+989121234567",
+					ExpiresAt:   time.Now().Add(2 * time.Minute),
+					ResendAt:    time.Now().Add(time.Minute),
+				}, nil)
+			},
+			expectedStatusCode: http.StatusAccepted,
+		},
+		{
+			name:               "missing phone number",
+			body:               `{}`,
+			mockSetup:          func(m *MockService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid phone number",
+			body: `{"phone_number": "021-12345678"}`,
+			mockSetup: func(m *MockService) {
+				m.On("RequestOTP", mock.Anything, "021-12345678").Return(nil, authService.ErrInvalidPhoneNumber)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "cooldown sets Retry-After",
+			body: `{"phone_number": "09121234567"}`,
+			mockSetup: func(m *MockService) {
+				m.On("RequestOTP", mock.Anything, "09121234567").Return(nil, &authService.CooldownError{RetryAfter: 40 * time.Second})
+			},
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedCode:       "otp_cooldown",
+			expectedRetry:      "40",
+		},
+		{
+			name: "sms gateway failure",
+			body: `{"phone_number": "09121234567"}`,
+			mockSetup: func(m *MockService) {
+				m.On("RequestOTP", mock.Anything, "09121234567").Return(nil, errors.New("gateway down"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockService)
+			tt.mockSetup(service)
+
+			w := postJSON(newTestRouter(service), "/auth/otp/request", tt.body)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRetry, w.Header().Get("Retry-After"))
+			if tt.expectedCode != "" {
+				var response middleware.Problem
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedCode, response.Code)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_VerifyOTP(t *testing.T) {
+	patient := &medical.Patient{ID: uuid.New(), PhoneNumber: "+989121234567"}
+
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(*MockService)
+		expectedStatusCode int
+		expectedCode       string
+	}{
+		{
+			name: "correct code returns tokens",
+			body: `{"phone_number": "09121234567", "code": "123456"}`,
+			mockSetup: func(m *MockService) {
+				m.On("VerifyOTP", mock.Anything, "09121234567", "123456").Return(&authService.Login{
+					TokenPair: domainAuth.TokenPair{AccessToken: "access", RefreshToken: "refresh", TokenType: "Bearer", ExpiresIn: 900},
+					Patient:   patient,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "malformed code",
+			body:               `{"phone_number": "09121234567", "code": "12ab"}`,
+			mockSetup:          func(m *MockService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "wrong code",
+			body: `{"phone_number": "09121234567", "code": "654321"}`,
+			mockSetup: func(m *MockService) {
+				m.On("VerifyOTP", mock.Anything, "09121234567", "654321").Return(nil, domainAuth.ErrOTPInvalid)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedCode:       "otp_invalid",
+		},
+		{
+			name: "too many attempts",
+			body: `{"phone_number": "09121234567", "code": "654321"}`,
+			mockSetup: func(m *MockService) {
+				m.On("VerifyOTP", mock.Anything, "09121234567", "654321").Return(nil, domainAuth.ErrOTPAttemptsExceeded)
+			},
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedCode:       "otp_attempts_exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockService)
+			tt.mockSetup(service)
+
+			w := postJSON(newTestRouter(service), "/auth/otp/verify", tt.body)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.expectedStatusCode == http.StatusOK {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "access", response["access_token"])
+				assert.Equal(t, "refresh", response["refresh_token"])
+				assert.Equal(t, patient.ID.String(), response["patient"].(map[string]any)["id"])
+			}
+			if tt.expectedCode != "" {
+				var response middleware.Problem
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedCode, response.Code)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_VerifyDoctorOTP(t *testing.T) {
+	t.Run("doctor account", func(t *testing.T) {
+		doctor := &medical.Doctor{ID: uuid.New(), PhoneNumber: "+989121234567"}
+		service := new(MockService)
+		service.On("VerifyDoctorOTP", mock.Anything, "09121234567", "123456").Return(&authService.DoctorLogin{
+			TokenPair: domainAuth.TokenPair{AccessToken: "access", RefreshToken: "refresh", TokenType: "Bearer", ExpiresIn: 900},
+			Doctor:    doctor,
+		}, nil)
+
+		w := postJSON(newTestRouter(service), "/auth/doctor/otp/verify", `{"phone_number": "09121234567", "code": "123456"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "access", response["access_token"])
+		assert.Equal(t, doctor.ID.String(), response["doctor"].(map[string]any)["id"])
+	})
+
+	t.Run("no doctor with this phone number", func(t *testing.T) {
+		service := new(MockService)
+		service.On("VerifyDoctorOTP", mock.Anything, "09121234567", "123456").Return(nil, domainAuth.ErrForbidden)
+
+		w := postJSON(newTestRouter(service), "/auth/doctor/otp/verify", `{"phone_number": "09121234567", "code": "123456"}`)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestHandler_VerifyAdminOTP(t *testing.T) {
+	t.Run("admin account", func(t *testing.T) {
+		admin := &domainAuth.Admin{ID: uuid.New(), PhoneNumber: "+989121234567"}
+		service := new(MockService)
+		service.On("VerifyAdminOTP", mock.Anything, "09121234567", "123456").Return(&authService.AdminLogin{
+			TokenPair: domainAuth.TokenPair{AccessToken: "access", RefreshToken: "refresh", TokenType: "Bearer", ExpiresIn: 900},
+			Admin:     admin,
+		}, nil)
+
+		w := postJSON(newTestRouter(service), "/auth/admin/otp/verify", `{"phone_number": "09121234567", "code": "123456"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, admin.ID.String(), response["admin"].(map[string]any)["id"])
+	})
+
+	t.Run("no admin with this phone number", func(t *testing.T) {
+		service := new(MockService)
+		service.On("VerifyAdminOTP", mock.Anything, "09121234567", "123456").Return(nil, domainAuth.ErrForbidden)
+
+		w := postJSON(newTestRouter(service), "/auth/admin/otp/verify", `{"phone_number": "09121234567", "code": "123456"}`)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestHandler_Refresh(t *testing.T) {
+	t.Run("valid token", func(t *testing.T) {
+		service := new(MockService)
+		service.On("Refresh", mock.Anything, "refresh").Return(&domainAuth.TokenPair{AccessToken: "new-access"}, nil)
+
+		w := postJSON(newTestRouter(service), "/auth/refresh", `{"refresh_token": "refresh"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "new-access")
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		service := new(MockService)
+		service.On("Refresh", mock.Anything, "stale").Return(nil, domainAuth.ErrInvalidToken)
+
+		w := postJSON(newTestRouter(service), "/auth/refresh", `{"refresh_token": "stale"}`)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
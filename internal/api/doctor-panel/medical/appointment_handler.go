@@ -0,0 +1,165 @@
+package medical
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/api/response"
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+)
+
+// AppointmentService applies the lifecycle rules of appointments for the doctor
+type AppointmentService interface {
+	List(ctx context.Context, actor auth.Principal, filters medicalFilter.AppointmentQueryParam, paginator *pagination.LimitOffsetPaginator[medical.Appointment]) (*pagination.Result[medical.Appointment], error)
+	Confirm(ctx context.Context, actor auth.Principal, id uuid.UUID) (*medical.Appointment, error)
+	Cancel(ctx context.Context, actor auth.Principal, id uuid.UUID) (*medical.Appointment, error)
+	Complete(ctx context.Context, actor auth.Principal, id uuid.UUID) (*medical.Appointment, error)
+	MarkNoShow(ctx context.Context, actor auth.Principal, id uuid.UUID) (*medical.Appointment, error)
+	History(ctx context.Context, actor auth.Principal, id uuid.UUID) ([]medical.AppointmentTransition, error)
+}
+
+// AppointmentHandler serves the appointments booked with the authenticated doctor.
+// Its routes must be mounted behind middleware.Authenticate.
+type AppointmentHandler struct {
+	service AppointmentService
+}
+
+func NewAppointmentHandler(service AppointmentService) *AppointmentHandler {
+	return &AppointmentHandler{
+		service: service,
+	}
+}
+
+// GetAllPaginated lists the doctor's appointments; the service shows upcoming ones when no dates are given
+func (h *AppointmentHandler) GetAllPaginated(c *gin.Context) {
+	actor, ok := currentDoctor(c)
+	if !ok {
+		return
+	}
+
+	var paginationParams pagination.LimitOffsetParams
+	if err := c.ShouldBindQuery(&paginationParams); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid pagination parameters", err))
+		return
+	}
+	paginationParams.BaseURL = c.Request.RequestURI
+	if err := paginationParams.Validate(); err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+
+	var filterParams medicalFilter.AppointmentQueryParam
+	if err := c.ShouldBindQuery(&filterParams); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid filter parameters", err))
+		return
+	}
+	if err := filterParams.ToGregorian(middleware.CalendarFrom(c)); err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+	if err := filterParams.Validate(); err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+
+	result, err := h.service.List(c.Request.Context(), actor, filterParams, pagination.NewLimitOffsetPaginator[medical.Appointment](paginationParams))
+	if err != nil {
+		_ = c.Error(fmt.Errorf("fetch appointments: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, response.NewPage(result, response.NewAppointment))
+}
+
+func (h *AppointmentHandler) Confirm(c *gin.Context) {
+	h.change(c, "confirm", h.service.Confirm)
+}
+
+// Cancel cancels the appointment unless it starts within the doctor cancellation cut-off
+func (h *AppointmentHandler) Cancel(c *gin.Context) {
+	h.change(c, "cancel", h.service.Cancel)
+}
+
+func (h *AppointmentHandler) Complete(c *gin.Context) {
+	h.change(c, "complete", h.service.Complete)
+}
+
+func (h *AppointmentHandler) NoShow(c *gin.Context) {
+	h.change(c, "mark as no-show", h.service.MarkNoShow)
+}
+
+// History lists the booking, status changes and reschedules of the appointment, oldest first
+func (h *AppointmentHandler) History(c *gin.Context) {
+	actor, id, ok := appointmentParams(c)
+	if !ok {
+		return
+	}
+
+	transitions, err := h.service.History(c.Request.Context(), actor, id)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("fetch the history of appointment: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, response.NewHistory(transitions))
+}
+
+// change applies a status change of the appointment service; action names it in the logged error
+func (h *AppointmentHandler) change(c *gin.Context, action string, apply func(context.Context, auth.Principal, uuid.UUID) (*medical.Appointment, error)) {
+	actor, id, ok := appointmentParams(c)
+	if !ok {
+		return
+	}
+
+	appointment, err := apply(c.Request.Context(), actor, id)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("%s appointment: %w", action, err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, response.NewAppointment(*appointment))
+}
+
+// appointmentParams returns the authenticated doctor and the appointment id in the path
+func appointmentParams(c *gin.Context) (auth.Principal, uuid.UUID, bool) {
+	actor, ok := currentDoctor(c)
+	if !ok {
+		return auth.Principal{}, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.Invalid("Invalid appointment id", err))
+		return auth.Principal{}, uuid.Nil, false
+	}
+	return actor, id, true
+}
+
+func currentDoctor(c *gin.Context) (auth.Principal, bool) {
+	principal, ok := middleware.PrincipalFrom(c)
+	if !ok {
+		_ = c.Error(auth.ErrUnauthenticated)
+		return auth.Principal{}, false
+	}
+	return principal, true
+}
+
+func (h *AppointmentHandler) RegisterRoutes(router *gin.RouterGroup) {
+	appointmentRoutes := router.Group("/appointments")
+
+	appointmentRoutes.GET("", h.GetAllPaginated)
+	appointmentRoutes.GET("/:id/history", h.History)
+	appointmentRoutes.POST("/:id/confirm", h.Confirm)
+	appointmentRoutes.POST("/:id/cancel", h.Cancel)
+	appointmentRoutes.POST("/:id/complete", h.Complete)
+	appointmentRoutes.POST("/:id/no-show", h.NoShow)
+}
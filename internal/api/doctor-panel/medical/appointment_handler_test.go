@@ -0,0 +1,273 @@
+package medical
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	domainMedical "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+	"github.com/shayesteh1hs/DrAppointment/internal/validation"
+)
+
+// Mock service
+type MockAppointmentService struct {
+	mock.Mock
+}
+
+func (m *MockAppointmentService) List(ctx context.Context, actor auth.Principal, filters medicalFilter.AppointmentQueryParam, paginator *pagination.LimitOffsetPaginator[domainMedical.Appointment]) (*pagination.Result[domainMedical.Appointment], error) {
+	args := m.Called(ctx, actor, filters, paginator)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Result[domainMedical.Appointment]), args.Error(1)
+}
+
+func (m *MockAppointmentService) Confirm(ctx context.Context, actor auth.Principal, id uuid.UUID) (*domainMedical.Appointment, error) {
+	return m.change("Confirm", ctx, actor, id)
+}
+
+func (m *MockAppointmentService) Cancel(ctx context.Context, actor auth.Principal, id uuid.UUID) (*domainMedical.Appointment, error) {
+	return m.change("Cancel", ctx, actor, id)
+}
+
+func (m *MockAppointmentService) Complete(ctx context.Context, actor auth.Principal, id uuid.UUID) (*domainMedical.Appointment, error) {
+	return m.change("Complete", ctx, actor, id)
+}
+
+func (m *MockAppointmentService) MarkNoShow(ctx context.Context, actor auth.Principal, id uuid.UUID) (*domainMedical.Appointment, error) {
+	return m.change("MarkNoShow", ctx, actor, id)
+}
+
+func (m *MockAppointmentService) History(ctx context.Context, actor auth.Principal, id uuid.UUID) ([]domainMedical.AppointmentTransition, error) {
+	args := m.Called(ctx, actor, id)
+	var out []domainMedical.AppointmentTransition
+	if v := args.Get(0); v != nil {
+		out = v.([]domainMedical.AppointmentTransition)
+	}
+	return out, args.Error(1)
+}
+
+func (m *MockAppointmentService) change(method string, ctx context.Context, actor auth.Principal, id uuid.UUID) (*domainMedical.Appointment, error) {
+	args := m.MethodCalled(method, ctx, actor, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainMedical.Appointment), args.Error(1)
+}
+
+// Helper functions
+
+// stubTokens accepts any bearer token as an access token of the doctor
+type stubTokens struct {
+	doctorID uuid.UUID
+}
+
+func (s stubTokens) Parse(string, auth.TokenType) (*auth.Claims, error) {
+	return &auth.Claims{Subject: s.doctorID.String(), Role: auth.RoleDoctor, Type: auth.TokenTypeAccess}, nil
+}
+
+type routeRegistrar interface {
+	RegisterRoutes(router *gin.RouterGroup)
+}
+
+func newDoctorTestRouter(handler routeRegistrar, doctorID uuid.UUID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler(validation.NewTranslator()), middleware.Authenticate(stubTokens{doctorID: doctorID}))
+	handler.RegisterRoutes(router.Group(""))
+	return router
+}
+
+func serveAuthenticated(t *testing.T, router *gin.Engine, method, url, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	req.RequestURI = url
+	req.Header.Set("Authorization", "Bearer test")
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// Table-driven tests
+
+func TestAppointmentHandler_GetAllPaginated(t *testing.T) {
+	doctorID := uuid.New()
+	actor := auth.Principal{ID: doctorID, Role: auth.RoleDoctor}
+	appointments := []domainMedical.Appointment{{ID: uuid.New(), DoctorID: doctorID}}
+
+	tests := []struct {
+		name               string
+		queryParams        string
+		expectedFilters    medicalFilter.AppointmentQueryParam
+		expectedStatusCode int
+	}{
+		{
+			name:               "Success - No filters",
+			queryParams:        "",
+			expectedFilters:    medicalFilter.AppointmentQueryParam{},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Success - Date range and status",
+			queryParams:        "?from=2025-03-01&to=2025-03-07&status=completed",
+			expectedFilters:    medicalFilter.AppointmentQueryParam{From: "2025-03-01", To: "2025-03-07", Status: "completed"},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Error - Invalid date",
+			queryParams:        "?from=yesterday",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAppointmentService)
+			if tt.expectedStatusCode == http.StatusOK {
+				mockService.On("List", mock.Anything, actor, tt.expectedFilters, mock.Anything).
+					Return(&pagination.Result[domainMedical.Appointment]{Items: appointments, TotalCount: 1}, nil)
+			}
+			router := newDoctorTestRouter(NewAppointmentHandler(mockService), doctorID)
+
+			w := serveAuthenticated(t, router, http.MethodGet, "/appointments"+tt.queryParams, "")
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			if tt.expectedStatusCode == http.StatusOK {
+				var response pagination.Result[domainMedical.Appointment]
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Len(t, response.Items, len(appointments))
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAppointmentHandler_StatusChanges(t *testing.T) {
+	doctorID := uuid.New()
+	actor := auth.Principal{ID: doctorID, Role: auth.RoleDoctor}
+	appointmentID := uuid.New()
+	path := func(action string) string {
+		return "/appointments/" + appointmentID.String() + "/" + action
+	}
+	changed := func(status domainMedical.AppointmentStatus) *domainMedical.Appointment {
+		return &domainMedical.Appointment{ID: appointmentID, DoctorID: doctorID, Status: status}
+	}
+
+	tests := []struct {
+		name               string
+		path               string
+		mockSetup          func(*MockAppointmentService)
+		expectedStatusCode int
+		expectedErrorCode  string
+	}{
+		{
+			name: "Success - Confirmed",
+			path: path("confirm"),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Confirm", mock.Anything, actor, appointmentID).Return(changed(domainMedical.AppointmentStatusConfirmed), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Success - Cancelled by the doctor",
+			path: path("cancel"),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Cancel", mock.Anything, actor, appointmentID).Return(changed(domainMedical.AppointmentStatusCancelledByDoctor), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Success - Completed",
+			path: path("complete"),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Complete", mock.Anything, actor, appointmentID).Return(changed(domainMedical.AppointmentStatusCompleted), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Success - No-show",
+			path: path("no-show"),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("MarkNoShow", mock.Anything, actor, appointmentID).Return(changed(domainMedical.AppointmentStatusNoShow), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Error - Another doctor's appointment",
+			path: path("complete"),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Complete", mock.Anything, actor, appointmentID).Return(nil, domainMedical.ErrAppointmentNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Error - Not started yet",
+			path: path("no-show"),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("MarkNoShow", mock.Anything, actor, appointmentID).Return(nil, domainMedical.ErrAppointmentNotFinishable)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorCode:  "appointment_not_finishable",
+		},
+		{
+			name: "Error - Confirming an already confirmed appointment",
+			path: path("confirm"),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Confirm", mock.Anything, actor, appointmentID).Return(nil, domainMedical.ErrAppointmentNotConfirmable)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorCode:  "appointment_not_confirmable",
+		},
+		{
+			name: "Error - Status changed by a concurrent request",
+			path: path("cancel"),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Cancel", mock.Anything, actor, appointmentID).Return(nil, domainMedical.ErrInvalidTransition)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorCode:  "invalid_transition",
+		},
+		{
+			name:               "Error - Invalid id",
+			path:               "/appointments/not-a-uuid/complete",
+			mockSetup:          func(svc *MockAppointmentService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAppointmentService)
+			tt.mockSetup(mockService)
+			router := newDoctorTestRouter(NewAppointmentHandler(mockService), doctorID)
+
+			w := serveAuthenticated(t, router, http.MethodPost, tt.path, "")
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			if tt.expectedErrorCode != "" {
+				var response middleware.Problem
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedErrorCode, response.Code)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package medical
+
+import (
+	authService "github.com/shayesteh1hs/DrAppointment/internal/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+// UpdateProfileRequest changes only the fields that are present; an empty string clears description or avatar_url
+type UpdateProfileRequest struct {
+	Description *string `json:"description" binding:"omitempty,max=2000"`
+	AvatarURL   *string `json:"avatar_url" binding:"omitempty,url,max=2048"`
+	PhoneNumber *string `json:"phone_number"`
+	// TimeZone is the IANA time zone the weekly schedule is kept in
+	TimeZone *string `json:"time_zone" binding:"omitempty,timezone"`
+}
+
+// ToUpdate normalizes the phone number the doctor will sign in with
+func (r UpdateProfileRequest) ToUpdate() (medical.DoctorProfileUpdate, error) {
+	update := medical.DoctorProfileUpdate{
+		Description: r.Description,
+		AvatarURL:   r.AvatarURL,
+		TimeZone:    r.TimeZone,
+	}
+	if r.PhoneNumber != nil {
+		phone, err := authService.NormalizePhoneNumber(*r.PhoneNumber)
+		if err != nil {
+			return medical.DoctorProfileUpdate{}, err
+		}
+		update.PhoneNumber = &phone
+	}
+	return update, nil
+}
@@ -0,0 +1,80 @@
+package medical
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/api/response"
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+)
+
+// ProfileService reads and changes the profile of the signed-in doctor
+type ProfileService interface {
+	Profile(ctx context.Context, actor auth.Principal) (*medical.DoctorDetail, error)
+	UpdateProfile(ctx context.Context, actor auth.Principal, update medical.DoctorProfileUpdate) (*medical.Doctor, error)
+}
+
+// ProfileHandler serves the profile of the authenticated doctor
+type ProfileHandler struct {
+	service ProfileService
+}
+
+func NewProfileHandler(service ProfileService) *ProfileHandler {
+	return &ProfileHandler{
+		service: service,
+	}
+}
+
+func (h *ProfileHandler) Get(c *gin.Context) {
+	actor, ok := currentDoctor(c)
+	if !ok {
+		return
+	}
+
+	doctor, err := h.service.Profile(c.Request.Context(), actor)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("fetch doctor profile: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, response.NewDoctorDetail(*doctor))
+}
+
+func (h *ProfileHandler) Update(c *gin.Context) {
+	actor, ok := currentDoctor(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid profile update request", err))
+		return
+	}
+	update, err := req.ToUpdate()
+	if err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+
+	doctor, err := h.service.UpdateProfile(c.Request.Context(), actor, update)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("update doctor profile: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, response.NewDoctor(*doctor))
+}
+
+func (h *ProfileHandler) RegisterRoutes(router *gin.RouterGroup) {
+	profileRoutes := router.Group("/profile")
+
+	profileRoutes.GET("", h.Get)
+	profileRoutes.PATCH("", h.Update)
+}
@@ -0,0 +1,93 @@
+package medical
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	domainMedical "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+// Mock service
+type MockProfileService struct {
+	mock.Mock
+}
+
+func (m *MockProfileService) Profile(ctx context.Context, actor auth.Principal) (*domainMedical.DoctorDetail, error) {
+	args := m.Called(ctx, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainMedical.DoctorDetail), args.Error(1)
+}
+
+func (m *MockProfileService) UpdateProfile(ctx context.Context, actor auth.Principal, update domainMedical.DoctorProfileUpdate) (*domainMedical.Doctor, error) {
+	args := m.Called(ctx, actor, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainMedical.Doctor), args.Error(1)
+}
+
+func TestProfileHandler_Update(t *testing.T) {
+	doctorID := uuid.New()
+	actor := auth.Principal{ID: doctorID, Role: auth.RoleDoctor}
+	doctor := &domainMedical.Doctor{ID: doctorID, PhoneNumber: "+989121234567"}
+
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(*MockProfileService)
+		expectedStatusCode int
+	}{
+		{
+			name: "Success - Phone number is normalized",
+			body: `{"phone_number": "۰۹۱۲۱۲۳۴۵۶۷", "description": "متخصص اطفال"}`,
+			mockSetup: func(svc *MockProfileService) {
+				svc.On("UpdateProfile", mock.Anything, actor, mock.MatchedBy(func(u domainMedical.DoctorProfileUpdate) bool {
+					return u.PhoneNumber != nil && *u.PhoneNumber == "+989121234567" &&
+						u.Description != nil && *u.Description == "متخصص اطفال" && u.AvatarURL == nil
+				})).Return(doctor, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Error - Invalid avatar url",
+			body:               `{"avatar_url": "not a url"}`,
+			mockSetup:          func(svc *MockProfileService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Error - Invalid phone number",
+			body:               `{"phone_number": "021-12345678"}`,
+			mockSetup:          func(svc *MockProfileService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error - Phone number of another doctor",
+			body: `{"phone_number": "09121234567"}`,
+			mockSetup: func(svc *MockProfileService) {
+				svc.On("UpdateProfile", mock.Anything, actor, mock.Anything).Return(nil, domainMedical.ErrPhoneNumberTaken)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockProfileService)
+			tt.mockSetup(mockService)
+			router := newDoctorTestRouter(NewProfileHandler(mockService), doctorID)
+
+			w := serveAuthenticated(t, router, http.MethodPatch, "/profile", tt.body)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package medical
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/api/response"
+	"github.com/shayesteh1hs/DrAppointment/internal/calendar"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+// Schedule errors; their messages are keys of the problem message translations
+var (
+	errSlotLongerThanHours = errors.New("slot_duration_minutes is longer than the working hours")
+	errHoursOverlap        = errors.New("working hours of a day overlap")
+)
+
+// ScheduleEntry is one block of working hours; DayOfWeek is a pointer so an omitted day is
+// rejected instead of read as Sunday
+type ScheduleEntry struct {
+	DayOfWeek    *int              `json:"day_of_week" binding:"required,min=0,max=6"`
+	StartTime    medical.ClockTime `json:"start_time"`
+	EndTime      medical.ClockTime `json:"end_time"`
+	SlotDuration int               `json:"slot_duration_minutes" binding:"required,min=5,max=240"`
+}
+
+// ReplaceScheduleRequest is the doctor's whole weekly schedule; an empty list removes it
+type ReplaceScheduleRequest struct {
+	Schedules []ScheduleEntry `json:"schedules" binding:"required,max=50,dive"`
+}
+
+// Validate checks every entry holds at least one slot and that entries of a day do not overlap
+func (r ReplaceScheduleRequest) Validate() error {
+	for _, entry := range r.Schedules {
+		if entry.EndTime <= entry.StartTime {
+			return errEndNotAfterStart
+		}
+		if int(entry.EndTime-entry.StartTime) < entry.SlotDuration {
+			return errSlotLongerThanHours
+		}
+	}
+
+	entries := slices.Clone(r.Schedules)
+	slices.SortFunc(entries, func(a, b ScheduleEntry) int {
+		return cmp.Or(cmp.Compare(*a.DayOfWeek, *b.DayOfWeek), cmp.Compare(a.StartTime, b.StartTime))
+	})
+	for i := 1; i < len(entries); i++ {
+		previous, entry := entries[i-1], entries[i]
+		if *entry.DayOfWeek == *previous.DayOfWeek && entry.StartTime < previous.EndTime {
+			return errHoursOverlap
+		}
+	}
+	return nil
+}
+
+func (r ReplaceScheduleRequest) ToSchedules() []medical.Schedule {
+	schedules := make([]medical.Schedule, len(r.Schedules))
+	for i, entry := range r.Schedules {
+		schedules[i] = medical.Schedule{
+			DayOfWeek:    time.Weekday(*entry.DayOfWeek),
+			StartTime:    entry.StartTime,
+			EndTime:      entry.EndTime,
+			SlotDuration: entry.SlotDuration,
+		}
+	}
+	return schedules
+}
+
+// SchedulesResponse is the weekly working hours of the doctor
+type SchedulesResponse struct {
+	Schedules response.List[response.Schedule] `json:"schedules"`
+}
+
+func NewSchedulesResponse(schedules []medical.Schedule) SchedulesResponse {
+	return SchedulesResponse{Schedules: response.NewList(schedules, response.NewSchedule)}
+}
+
+func (r SchedulesResponse) Present(system calendar.System) any {
+	r.Schedules = r.Schedules.In(system)
+	return r
+}
@@ -0,0 +1,79 @@
+package medical
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+)
+
+// ScheduleService manages the weekly working hours of the signed-in doctor
+type ScheduleService interface {
+	Get(ctx context.Context, actor auth.Principal) ([]medical.Schedule, error)
+	Replace(ctx context.Context, actor auth.Principal, schedules []medical.Schedule) ([]medical.Schedule, error)
+}
+
+// ScheduleHandler serves the weekly working hours of the authenticated doctor
+type ScheduleHandler struct {
+	service ScheduleService
+}
+
+func NewScheduleHandler(service ScheduleService) *ScheduleHandler {
+	return &ScheduleHandler{
+		service: service,
+	}
+}
+
+func (h *ScheduleHandler) Get(c *gin.Context) {
+	doctor, ok := currentDoctor(c)
+	if !ok {
+		return
+	}
+
+	schedules, err := h.service.Get(c.Request.Context(), doctor)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("fetch schedules: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, NewSchedulesResponse(schedules))
+}
+
+// Replace swaps the whole weekly schedule; booked appointments are kept even when they fall outside it
+func (h *ScheduleHandler) Replace(c *gin.Context) {
+	doctor, ok := currentDoctor(c)
+	if !ok {
+		return
+	}
+
+	var req ReplaceScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid schedule request", err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+
+	schedules, err := h.service.Replace(c.Request.Context(), doctor, req.ToSchedules())
+	if err != nil {
+		_ = c.Error(fmt.Errorf("replace schedules: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, NewSchedulesResponse(schedules))
+}
+
+func (h *ScheduleHandler) RegisterRoutes(router *gin.RouterGroup) {
+	scheduleRoutes := router.Group("/schedule")
+
+	scheduleRoutes.GET("", h.Get)
+	scheduleRoutes.PUT("", h.Replace)
+}
@@ -0,0 +1,156 @@
+package medical
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	domainMedical "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+)
+
+// Mock service
+type MockScheduleService struct {
+	mock.Mock
+}
+
+func (m *MockScheduleService) Get(ctx context.Context, actor auth.Principal) ([]domainMedical.Schedule, error) {
+	args := m.Called(ctx, actor)
+	var out []domainMedical.Schedule
+	if v := args.Get(0); v != nil {
+		out = v.([]domainMedical.Schedule)
+	}
+	return out, args.Error(1)
+}
+
+func (m *MockScheduleService) Replace(ctx context.Context, actor auth.Principal, schedules []domainMedical.Schedule) ([]domainMedical.Schedule, error) {
+	args := m.Called(ctx, actor, schedules)
+	var out []domainMedical.Schedule
+	if v := args.Get(0); v != nil {
+		out = v.([]domainMedical.Schedule)
+	}
+	return out, args.Error(1)
+}
+
+func TestScheduleHandler_Replace(t *testing.T) {
+	doctorID := uuid.New()
+	actor := auth.Principal{ID: doctorID, Role: auth.RoleDoctor}
+
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(*MockScheduleService)
+		expectedStatusCode int
+	}{
+		{
+			name: "Success - Weekly schedule replaced",
+			body: `{"schedules": [
+				{"day_of_week": 6, "start_time": "09:00", "end_time": "13:00", "slot_duration_minutes": 20},
+				{"day_of_week": 6, "start_time": "16:00", "end_time": "19:00", "slot_duration_minutes": 20}
+			]}`,
+			mockSetup: func(svc *MockScheduleService) {
+				svc.On("Replace", mock.Anything, actor, []domainMedical.Schedule{
+					{DayOfWeek: time.Saturday, StartTime: 9 * 60, EndTime: 13 * 60, SlotDuration: 20},
+					{DayOfWeek: time.Saturday, StartTime: 16 * 60, EndTime: 19 * 60, SlotDuration: 20},
+				}).Return([]domainMedical.Schedule{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Success - Sunday is day zero",
+			body: `{"schedules": [{"day_of_week": 0, "start_time": "09:00", "end_time": "13:00", "slot_duration_minutes": 20}]}`,
+			mockSetup: func(svc *MockScheduleService) {
+				svc.On("Replace", mock.Anything, actor, []domainMedical.Schedule{
+					{DayOfWeek: time.Sunday, StartTime: 9 * 60, EndTime: 13 * 60, SlotDuration: 20},
+				}).Return([]domainMedical.Schedule{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Success - Empty schedule clears working hours",
+			body: `{"schedules": []}`,
+			mockSetup: func(svc *MockScheduleService) {
+				svc.On("Replace", mock.Anything, actor, []domainMedical.Schedule{}).Return([]domainMedical.Schedule{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Error - Missing schedules",
+			body:               `{}`,
+			mockSetup:          func(svc *MockScheduleService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error - Overlapping hours",
+			body: `{"schedules": [
+				{"day_of_week": 1, "start_time": "09:00", "end_time": "13:00", "slot_duration_minutes": 20},
+				{"day_of_week": 1, "start_time": "12:00", "end_time": "15:00", "slot_duration_minutes": 20}
+			]}`,
+			mockSetup:          func(svc *MockScheduleService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Error - End before start",
+			body:               `{"schedules": [{"day_of_week": 1, "start_time": "13:00", "end_time": "09:00", "slot_duration_minutes": 20}]}`,
+			mockSetup:          func(svc *MockScheduleService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Error - Missing day",
+			body:               `{"schedules": [{"start_time": "09:00", "end_time": "13:00", "slot_duration_minutes": 20}]}`,
+			mockSetup:          func(svc *MockScheduleService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Error - Invalid day",
+			body:               `{"schedules": [{"day_of_week": 7, "start_time": "09:00", "end_time": "13:00", "slot_duration_minutes": 20}]}`,
+			mockSetup:          func(svc *MockScheduleService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockScheduleService)
+			tt.mockSetup(mockService)
+			router := newDoctorTestRouter(NewScheduleHandler(mockService), doctorID)
+
+			w := serveAuthenticated(t, router, http.MethodPut, "/schedule", tt.body)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScheduleHandler_Replace_PersianProblem(t *testing.T) {
+	mockService := new(MockScheduleService)
+	router := newDoctorTestRouter(NewScheduleHandler(mockService), uuid.New())
+
+	req, err := http.NewRequest(http.MethodPut, "/schedule", bytes.NewBufferString(`{"schedules": [
+		{"day_of_week": 1, "start_time": "09:00", "end_time": "13:00", "slot_duration_minutes": 20},
+		{"day_of_week": 1, "start_time": "12:00", "end_time": "15:00", "slot_duration_minutes": 20}
+	]}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "fa-IR")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var problem middleware.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "ساعات کاری یک روز با هم همپوشانی دارند", problem.Detail)
+	mockService.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything, mock.Anything)
+}
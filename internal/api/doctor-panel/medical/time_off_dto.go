@@ -0,0 +1,56 @@
+package medical
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/api/response"
+	"github.com/shayesteh1hs/DrAppointment/internal/calendar"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+// Time range errors; their messages are keys of the problem message translations
+var (
+	errEndNotAfterStart = errors.New("end_time must be after start_time")
+	errEndNotInFuture   = errors.New("end_time must be in the future")
+)
+
+// CreateTimeOffRequest is a period in which the doctor does not accept appointments, e.g. a vacation
+type CreateTimeOffRequest struct {
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required"`
+	Reason    string    `json:"reason" binding:"max=500"`
+}
+
+// Validate checks the period has not ended yet
+func (r CreateTimeOffRequest) Validate(now time.Time) error {
+	if !r.EndTime.After(r.StartTime) {
+		return errEndNotAfterStart
+	}
+	if !r.EndTime.After(now) {
+		return errEndNotInFuture
+	}
+	return nil
+}
+
+func (r CreateTimeOffRequest) ToTimeOff() medical.TimeOff {
+	return medical.TimeOff{
+		StartTime: r.StartTime,
+		EndTime:   r.EndTime,
+		Reason:    r.Reason,
+	}
+}
+
+// TimeOffResponse is the time off of the doctor that has not ended yet
+type TimeOffResponse struct {
+	TimeOff response.List[response.TimeOff] `json:"time_off"`
+}
+
+func NewTimeOffResponse(timeOffs []medical.TimeOff) TimeOffResponse {
+	return TimeOffResponse{TimeOff: response.NewList(timeOffs, response.NewTimeOff)}
+}
+
+func (r TimeOffResponse) Present(system calendar.System) any {
+	r.TimeOff = r.TimeOff.In(system)
+	return r
+}
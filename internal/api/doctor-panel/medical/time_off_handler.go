@@ -0,0 +1,105 @@
+package medical
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/api/response"
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+)
+
+// TimeOffService manages the periods in which the signed-in doctor does not accept appointments
+type TimeOffService interface {
+	List(ctx context.Context, actor auth.Principal) ([]medical.TimeOff, error)
+	Create(ctx context.Context, actor auth.Principal, timeOff *medical.TimeOff) error
+	Delete(ctx context.Context, actor auth.Principal, id uuid.UUID) error
+}
+
+// TimeOffHandler serves the time off of the authenticated doctor. Patients cannot book slots
+// inside a time off period; appointments already booked in it are kept.
+type TimeOffHandler struct {
+	service TimeOffService
+}
+
+func NewTimeOffHandler(service TimeOffService) *TimeOffHandler {
+	return &TimeOffHandler{
+		service: service,
+	}
+}
+
+// GetAll lists the time off that has not ended yet, earliest first
+func (h *TimeOffHandler) GetAll(c *gin.Context) {
+	doctor, ok := currentDoctor(c)
+	if !ok {
+		return
+	}
+
+	timeOffs, err := h.service.List(c.Request.Context(), doctor)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("fetch time off: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, NewTimeOffResponse(timeOffs))
+}
+
+func (h *TimeOffHandler) Create(c *gin.Context) {
+	doctor, ok := currentDoctor(c)
+	if !ok {
+		return
+	}
+
+	var req CreateTimeOffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid time off request", err))
+		return
+	}
+	if err := req.Validate(time.Now()); err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+
+	timeOff := req.ToTimeOff()
+	if err := h.service.Create(c.Request.Context(), doctor, &timeOff); err != nil {
+		_ = c.Error(fmt.Errorf("create time off: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusCreated, response.NewTimeOff(timeOff))
+}
+
+func (h *TimeOffHandler) Delete(c *gin.Context) {
+	doctor, ok := currentDoctor(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.Invalid("Invalid time off id", err))
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), doctor, id); err != nil {
+		_ = c.Error(fmt.Errorf("delete time off: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TimeOffHandler) RegisterRoutes(router *gin.RouterGroup) {
+	timeOffRoutes := router.Group("/time-off")
+
+	timeOffRoutes.GET("", h.GetAll)
+	timeOffRoutes.POST("", h.Create)
+	timeOffRoutes.DELETE("/:id", h.Delete)
+}
@@ -0,0 +1,180 @@
+package medical
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	domainMedical "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+// Mock service
+type MockTimeOffService struct {
+	mock.Mock
+}
+
+func (m *MockTimeOffService) List(ctx context.Context, actor auth.Principal) ([]domainMedical.TimeOff, error) {
+	args := m.Called(ctx, actor)
+	var out []domainMedical.TimeOff
+	if v := args.Get(0); v != nil {
+		out = v.([]domainMedical.TimeOff)
+	}
+	return out, args.Error(1)
+}
+
+func (m *MockTimeOffService) Create(ctx context.Context, actor auth.Principal, timeOff *domainMedical.TimeOff) error {
+	args := m.Called(ctx, actor, timeOff)
+	return args.Error(0)
+}
+
+func (m *MockTimeOffService) Delete(ctx context.Context, actor auth.Principal, id uuid.UUID) error {
+	args := m.Called(ctx, actor, id)
+	return args.Error(0)
+}
+
+func TestTimeOffHandler_GetAll(t *testing.T) {
+	doctorID := uuid.New()
+	actor := auth.Principal{ID: doctorID, Role: auth.RoleDoctor}
+	timeOffs := []domainMedical.TimeOff{{ID: uuid.New(), DoctorID: doctorID, Reason: "Conference"}}
+
+	mockService := new(MockTimeOffService)
+	mockService.On("List", mock.Anything, actor).Return(timeOffs, nil)
+	router := newDoctorTestRouter(NewTimeOffHandler(mockService), doctorID)
+
+	w := serveAuthenticated(t, router, http.MethodGet, "/time-off", "")
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response struct {
+		TimeOff []domainMedical.TimeOff `json:"time_off"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.TimeOff, 1)
+	assert.Equal(t, "Conference", response.TimeOff[0].Reason)
+	mockService.AssertExpectations(t)
+}
+
+func TestTimeOffHandler_Create(t *testing.T) {
+	doctorID := uuid.New()
+	actor := auth.Principal{ID: doctorID, Role: auth.RoleDoctor}
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Second).UTC()
+	end := start.Add(72 * time.Hour)
+
+	body := func(start, end time.Time) string {
+		return fmt.Sprintf(`{"start_time":%q,"end_time":%q,"reason":"Vacation"}`, start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(*MockTimeOffService)
+		expectedStatusCode int
+	}{
+		{
+			name: "Success - Time off created",
+			body: body(start, end),
+			mockSetup: func(svc *MockTimeOffService) {
+				svc.On("Create", mock.Anything, actor, mock.MatchedBy(func(t *domainMedical.TimeOff) bool {
+					return t.StartTime.Equal(start) && t.EndTime.Equal(end) && t.Reason == "Vacation"
+				})).Return(nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name: "Success - Period that already started",
+			body: body(start.Add(-72*time.Hour), end),
+			mockSetup: func(svc *MockTimeOffService) {
+				svc.On("Create", mock.Anything, actor, mock.Anything).Return(nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Error - End before start",
+			body:               body(end, start),
+			mockSetup:          func(svc *MockTimeOffService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Error - Period already ended",
+			body:               body(start.Add(-96*time.Hour), start.Add(-72*time.Hour)),
+			mockSetup:          func(svc *MockTimeOffService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Error - Missing start",
+			body:               fmt.Sprintf(`{"end_time":%q}`, end.Format(time.RFC3339)),
+			mockSetup:          func(svc *MockTimeOffService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTimeOffService)
+			tt.mockSetup(mockService)
+			router := newDoctorTestRouter(NewTimeOffHandler(mockService), doctorID)
+
+			w := serveAuthenticated(t, router, http.MethodPost, "/time-off", tt.body)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTimeOffHandler_Delete(t *testing.T) {
+	doctorID := uuid.New()
+	actor := auth.Principal{ID: doctorID, Role: auth.RoleDoctor}
+	timeOffID := uuid.New()
+
+	tests := []struct {
+		name               string
+		id                 string
+		mockSetup          func(*MockTimeOffService)
+		expectedStatusCode int
+	}{
+		{
+			name: "Success - Time off removed",
+			id:   timeOffID.String(),
+			mockSetup: func(svc *MockTimeOffService) {
+				svc.On("Delete", mock.Anything, actor, timeOffID).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name: "Error - Not found",
+			id:   timeOffID.String(),
+			mockSetup: func(svc *MockTimeOffService) {
+				svc.On("Delete", mock.Anything, actor, timeOffID).Return(domainMedical.ErrTimeOffNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "Error - Invalid id",
+			id:                 "not-a-uuid",
+			mockSetup:          func(svc *MockTimeOffService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTimeOffService)
+			tt.mockSetup(mockService)
+			router := newDoctorTestRouter(NewTimeOffHandler(mockService), doctorID)
+
+			w := serveAuthenticated(t, router, http.MethodDelete, "/time-off/"+tt.id, "")
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package medical
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Time range errors; their messages are keys of the problem message translations
+var (
+	errEndNotAfterStart = errors.New("end_time must be after start_time")
+	errStartNotInFuture = errors.New("start_time must be in the future")
+)
+
+type CreateAppointmentRequest struct {
+	DoctorID  uuid.UUID `json:"doctor_id" binding:"required"`
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required"`
+}
+
+// Validate checks the booking time range
+func (r *CreateAppointmentRequest) Validate(now time.Time) error {
+	if !r.EndTime.After(r.StartTime) {
+		return errEndNotAfterStart
+	}
+	if !r.StartTime.After(now) {
+		return errStartNotInFuture
+	}
+	return nil
+}
+
+type RescheduleAppointmentRequest struct {
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required"`
+}
+
+// Validate checks the new time range
+func (r *RescheduleAppointmentRequest) Validate(now time.Time) error {
+	if !r.EndTime.After(r.StartTime) {
+		return errEndNotAfterStart
+	}
+	if !r.StartTime.After(now) {
+		return errStartNotInFuture
+	}
+	return nil
+}
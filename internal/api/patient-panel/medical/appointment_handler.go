@@ -0,0 +1,218 @@
+package medical
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/api/response"
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+)
+
+// AppointmentService applies the booking, cancellation and rescheduling rules for the patient
+type AppointmentService interface {
+	List(ctx context.Context, actor auth.Principal, filters medicalFilter.AppointmentQueryParam, paginator *pagination.LimitOffsetPaginator[medical.Appointment]) (*pagination.Result[medical.Appointment], error)
+	Book(ctx context.Context, actor auth.Principal, appointment *medical.Appointment) error
+	Get(ctx context.Context, actor auth.Principal, id uuid.UUID) (*medical.Appointment, error)
+	Cancel(ctx context.Context, actor auth.Principal, id uuid.UUID) (*medical.Appointment, error)
+	Reschedule(ctx context.Context, actor auth.Principal, id uuid.UUID, startTime, endTime time.Time) (*medical.Appointment, error)
+	History(ctx context.Context, actor auth.Principal, id uuid.UUID) ([]medical.AppointmentTransition, error)
+}
+
+// AppointmentHandler serves the appointments of the authenticated patient.
+// Its routes must be mounted behind middleware.Authenticate.
+type AppointmentHandler struct {
+	service AppointmentService
+}
+
+func NewAppointmentHandler(service AppointmentService) *AppointmentHandler {
+	return &AppointmentHandler{
+		service: service,
+	}
+}
+
+func (h *AppointmentHandler) Create(c *gin.Context) {
+	actor, ok := currentPatient(c)
+	if !ok {
+		return
+	}
+
+	var req CreateAppointmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid appointment request", err))
+		return
+	}
+	if err := req.Validate(time.Now()); err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+
+	appointment := medical.Appointment{
+		DoctorID:  req.DoctorID,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	}
+	if err := h.service.Book(c.Request.Context(), actor, &appointment); err != nil {
+		_ = c.Error(fmt.Errorf("create appointment: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusCreated, response.NewAppointment(appointment))
+}
+
+func (h *AppointmentHandler) GetAllPaginated(c *gin.Context) {
+	actor, ok := currentPatient(c)
+	if !ok {
+		return
+	}
+
+	var paginationParams pagination.LimitOffsetParams
+	if err := c.ShouldBindQuery(&paginationParams); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid pagination parameters", err))
+		return
+	}
+	paginationParams.BaseURL = c.Request.RequestURI
+	if err := paginationParams.Validate(); err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+
+	var filterParams medicalFilter.AppointmentQueryParam
+	if err := c.ShouldBindQuery(&filterParams); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid filter parameters", err))
+		return
+	}
+	if err := filterParams.ToGregorian(middleware.CalendarFrom(c)); err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+	if err := filterParams.Validate(); err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+
+	result, err := h.service.List(c.Request.Context(), actor, filterParams, pagination.NewLimitOffsetPaginator[medical.Appointment](paginationParams))
+	if err != nil {
+		_ = c.Error(fmt.Errorf("fetch appointments: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, response.NewPage(result, response.NewAppointment))
+}
+
+func (h *AppointmentHandler) GetByID(c *gin.Context) {
+	actor, id, ok := appointmentParams(c)
+	if !ok {
+		return
+	}
+
+	appointment, err := h.service.Get(c.Request.Context(), actor, id)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("fetch appointment: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, response.NewAppointment(*appointment))
+}
+
+// Cancel cancels the appointment unless it starts within the patient cancellation cut-off
+func (h *AppointmentHandler) Cancel(c *gin.Context) {
+	actor, id, ok := appointmentParams(c)
+	if !ok {
+		return
+	}
+
+	appointment, err := h.service.Cancel(c.Request.Context(), actor, id)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("cancel appointment: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, response.NewAppointment(*appointment))
+}
+
+// Reschedule moves the appointment to another time with the same doctor
+func (h *AppointmentHandler) Reschedule(c *gin.Context) {
+	actor, id, ok := appointmentParams(c)
+	if !ok {
+		return
+	}
+
+	var req RescheduleAppointmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid reschedule request", err))
+		return
+	}
+	if err := req.Validate(time.Now()); err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+
+	appointment, err := h.service.Reschedule(c.Request.Context(), actor, id, req.StartTime, req.EndTime)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("reschedule appointment: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, response.NewAppointment(*appointment))
+}
+
+// History lists the booking, status changes and reschedules of the appointment, oldest first
+func (h *AppointmentHandler) History(c *gin.Context) {
+	actor, id, ok := appointmentParams(c)
+	if !ok {
+		return
+	}
+
+	transitions, err := h.service.History(c.Request.Context(), actor, id)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("fetch the history of appointment: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, response.NewHistory(transitions))
+}
+
+// appointmentParams returns the authenticated patient and the appointment id in the path
+func appointmentParams(c *gin.Context) (auth.Principal, uuid.UUID, bool) {
+	actor, ok := currentPatient(c)
+	if !ok {
+		return auth.Principal{}, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.Invalid("Invalid appointment id", err))
+		return auth.Principal{}, uuid.Nil, false
+	}
+	return actor, id, true
+}
+
+func currentPatient(c *gin.Context) (auth.Principal, bool) {
+	principal, ok := middleware.PrincipalFrom(c)
+	if !ok {
+		_ = c.Error(auth.ErrUnauthenticated)
+		return auth.Principal{}, false
+	}
+	return principal, true
+}
+
+func (h *AppointmentHandler) RegisterRoutes(router *gin.RouterGroup) {
+	appointmentRoutes := router.Group("/appointments")
+
+	appointmentRoutes.POST("", h.Create)
+	appointmentRoutes.GET("", h.GetAllPaginated)
+	appointmentRoutes.GET("/:id", h.GetByID)
+	appointmentRoutes.DELETE("/:id", h.Cancel)
+	appointmentRoutes.POST("/:id/reschedule", h.Reschedule)
+	appointmentRoutes.GET("/:id/history", h.History)
+}
@@ -0,0 +1,444 @@
+package medical
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	domainMedical "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+	"github.com/shayesteh1hs/DrAppointment/internal/validation"
+)
+
+// Mock service
+type MockAppointmentService struct {
+	mock.Mock
+}
+
+func (m *MockAppointmentService) List(ctx context.Context, actor auth.Principal, filters medicalFilter.AppointmentQueryParam, paginator *pagination.LimitOffsetPaginator[domainMedical.Appointment]) (*pagination.Result[domainMedical.Appointment], error) {
+	args := m.Called(ctx, actor, filters, paginator)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Result[domainMedical.Appointment]), args.Error(1)
+}
+
+func (m *MockAppointmentService) Book(ctx context.Context, actor auth.Principal, appointment *domainMedical.Appointment) error {
+	args := m.Called(ctx, actor, appointment)
+	return args.Error(0)
+}
+
+func (m *MockAppointmentService) Get(ctx context.Context, actor auth.Principal, id uuid.UUID) (*domainMedical.Appointment, error) {
+	args := m.Called(ctx, actor, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainMedical.Appointment), args.Error(1)
+}
+
+func (m *MockAppointmentService) Cancel(ctx context.Context, actor auth.Principal, id uuid.UUID) (*domainMedical.Appointment, error) {
+	args := m.Called(ctx, actor, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainMedical.Appointment), args.Error(1)
+}
+
+func (m *MockAppointmentService) Reschedule(ctx context.Context, actor auth.Principal, id uuid.UUID, startTime, endTime time.Time) (*domainMedical.Appointment, error) {
+	args := m.Called(ctx, actor, id, startTime, endTime)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainMedical.Appointment), args.Error(1)
+}
+
+func (m *MockAppointmentService) History(ctx context.Context, actor auth.Principal, id uuid.UUID) ([]domainMedical.AppointmentTransition, error) {
+	args := m.Called(ctx, actor, id)
+	var out []domainMedical.AppointmentTransition
+	if v := args.Get(0); v != nil {
+		out = v.([]domainMedical.AppointmentTransition)
+	}
+	return out, args.Error(1)
+}
+
+// stubTokens accepts any bearer token as an access token of the patient
+type stubTokens struct {
+	patientID uuid.UUID
+}
+
+func (s stubTokens) Parse(string, auth.TokenType) (*auth.Claims, error) {
+	return &auth.Claims{Subject: s.patientID.String(), Role: auth.RolePatient, Type: auth.TokenTypeAccess}, nil
+}
+
+func newAppointmentTestRouter(appointments *MockAppointmentService, patientID uuid.UUID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler(validation.NewTranslator()), middleware.Authenticate(stubTokens{patientID: patientID}))
+	NewAppointmentHandler(appointments).RegisterRoutes(router.Group(""))
+	return router
+}
+
+func newAuthenticatedRequest(t *testing.T, method, url, body string) *http.Request {
+	req, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	req.RequestURI = url
+	req.Header.Set("Authorization", "Bearer test")
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req
+}
+
+func TestAppointmentHandler_Create(t *testing.T) {
+	doctorID := uuid.New()
+	patientID := uuid.New()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Second).UTC()
+
+	body := func(start, end time.Time) string {
+		return fmt.Sprintf(`{"doctor_id":%q,"start_time":%q,"end_time":%q}`,
+			doctorID, start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(*MockAppointmentService)
+		expectedStatusCode int
+		expectedErrorCode  string
+	}{
+		{
+			name: "Success - Appointment booked",
+			body: body(start, start.Add(30*time.Minute)),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Book", mock.Anything, auth.Principal{ID: patientID, Role: auth.RolePatient}, mock.MatchedBy(func(a *domainMedical.Appointment) bool {
+					return a.DoctorID == doctorID && a.StartTime.Equal(start) && a.EndTime.Equal(start.Add(30*time.Minute))
+				})).Return(nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Error - Missing doctor",
+			body:               fmt.Sprintf(`{"start_time":%q}`, start.Format(time.RFC3339)),
+			mockSetup:          func(svc *MockAppointmentService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Error - End before start",
+			body:               body(start, start.Add(-30*time.Minute)),
+			mockSetup:          func(svc *MockAppointmentService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Error - Start in the past",
+			body:               body(start.Add(-96*time.Hour), start.Add(-95*time.Hour)),
+			mockSetup:          func(svc *MockAppointmentService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error - Unknown doctor",
+			body: body(start, start.Add(30*time.Minute)),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Book", mock.Anything, mock.Anything, mock.Anything).Return(domainMedical.ErrDoctorNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Error - Slot already taken",
+			body: body(start, start.Add(30*time.Minute)),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Book", mock.Anything, mock.Anything, mock.Anything).Return(domainMedical.ErrSlotTaken)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorCode:  "slot_taken",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAppointmentService)
+			tt.mockSetup(mockService)
+			router := newAppointmentTestRouter(mockService, patientID)
+
+			req := newAuthenticatedRequest(t, http.MethodPost, "/appointments", tt.body)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			if tt.expectedErrorCode != "" {
+				var response middleware.Problem
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedErrorCode, response.Code)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAppointmentHandler_GetAllPaginated(t *testing.T) {
+	patientID := uuid.New()
+	actor := auth.Principal{ID: patientID, Role: auth.RolePatient}
+	appointments := []domainMedical.Appointment{{ID: uuid.New(), PatientID: patientID}}
+	page := &pagination.Result[domainMedical.Appointment]{Items: appointments, TotalCount: 1}
+
+	tests := []struct {
+		name               string
+		queryParams        string
+		mockSetup          func(*MockAppointmentService)
+		expectedStatusCode int
+	}{
+		{
+			name:        "Success - Patient appointments",
+			queryParams: "",
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("List", mock.Anything, actor, medicalFilter.AppointmentQueryParam{}, mock.Anything).Return(page, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:        "Success - Status filter",
+			queryParams: "?status=confirmed",
+			mockSetup: func(svc *MockAppointmentService) {
+				filters := medicalFilter.AppointmentQueryParam{Status: "confirmed"}
+				svc.On("List", mock.Anything, actor, filters, mock.Anything).Return(page, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Error - Invalid status",
+			queryParams:        "?status=unknown",
+			mockSetup:          func(svc *MockAppointmentService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAppointmentService)
+			tt.mockSetup(mockService)
+			router := newAppointmentTestRouter(mockService, patientID)
+
+			req := newAuthenticatedRequest(t, http.MethodGet, "/appointments"+tt.queryParams, "")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			if tt.expectedStatusCode == http.StatusOK {
+				var response pagination.Result[domainMedical.Appointment]
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Len(t, response.Items, len(appointments))
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAppointmentHandler_Cancel(t *testing.T) {
+	appointmentID := uuid.New()
+	patientID := uuid.New()
+	actor := auth.Principal{ID: patientID, Role: auth.RolePatient}
+	cancelled := &domainMedical.Appointment{ID: appointmentID, PatientID: patientID, Status: domainMedical.AppointmentStatusCancelledByPatient}
+
+	tests := []struct {
+		name               string
+		id                 string
+		mockSetup          func(*MockAppointmentService)
+		expectedStatusCode int
+		expectedErrorCode  string
+	}{
+		{
+			name: "Success - Appointment cancelled by the patient",
+			id:   appointmentID.String(),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Cancel", mock.Anything, actor, appointmentID).Return(cancelled, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Error - Invalid id",
+			id:                 "not-a-uuid",
+			mockSetup:          func(svc *MockAppointmentService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error - Not found",
+			id:   appointmentID.String(),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Cancel", mock.Anything, actor, appointmentID).Return(nil, domainMedical.ErrAppointmentNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Error - Already completed",
+			id:   appointmentID.String(),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Cancel", mock.Anything, actor, appointmentID).Return(nil, domainMedical.ErrAppointmentNotCancellable)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorCode:  "appointment_not_cancellable",
+		},
+		{
+			name: "Error - Within the cancellation cut-off",
+			id:   appointmentID.String(),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Cancel", mock.Anything, actor, appointmentID).Return(nil, domainMedical.ErrCancellationCutoffPassed)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorCode:  "cancellation_cutoff_passed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAppointmentService)
+			tt.mockSetup(mockService)
+			router := newAppointmentTestRouter(mockService, patientID)
+
+			req := newAuthenticatedRequest(t, http.MethodDelete, "/appointments/"+tt.id, "")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			if tt.expectedErrorCode != "" {
+				var response middleware.Problem
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedErrorCode, response.Code)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAppointmentHandler_Reschedule(t *testing.T) {
+	appointmentID := uuid.New()
+	patientID := uuid.New()
+	actor := auth.Principal{ID: patientID, Role: auth.RolePatient}
+	newStart := time.Now().Add(72 * time.Hour).Truncate(time.Second).UTC()
+	newEnd := newStart.Add(30 * time.Minute)
+	moved := &domainMedical.Appointment{ID: appointmentID, PatientID: patientID, StartTime: newStart, EndTime: newEnd, Status: domainMedical.AppointmentStatusConfirmed}
+
+	body := func(start, end time.Time) string {
+		return fmt.Sprintf(`{"start_time":%q,"end_time":%q}`, start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+
+	tests := []struct {
+		name               string
+		body               string
+		mockSetup          func(*MockAppointmentService)
+		expectedStatusCode int
+		expectedErrorCode  string
+	}{
+		{
+			name: "Success - Appointment moved",
+			body: body(newStart, newEnd),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Reschedule", mock.Anything, actor, appointmentID, mock.MatchedBy(newStart.Equal), mock.MatchedBy(newEnd.Equal)).
+					Return(moved, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Error - End before start",
+			body:               body(newStart, newStart.Add(-time.Minute)),
+			mockSetup:          func(svc *MockAppointmentService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error - Cancelled appointment",
+			body: body(newStart, newEnd),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Reschedule", mock.Anything, actor, appointmentID, mock.Anything, mock.Anything).
+					Return(nil, domainMedical.ErrAppointmentNotReschedulable)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorCode:  "appointment_not_reschedulable",
+		},
+		{
+			name: "Error - New slot already taken",
+			body: body(newStart, newEnd),
+			mockSetup: func(svc *MockAppointmentService) {
+				svc.On("Reschedule", mock.Anything, actor, appointmentID, mock.Anything, mock.Anything).
+					Return(nil, domainMedical.ErrSlotTaken)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorCode:  "slot_taken",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAppointmentService)
+			tt.mockSetup(mockService)
+			router := newAppointmentTestRouter(mockService, patientID)
+
+			req := newAuthenticatedRequest(t, http.MethodPost, "/appointments/"+appointmentID.String()+"/reschedule", tt.body)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			if tt.expectedErrorCode != "" {
+				var response middleware.Problem
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedErrorCode, response.Code)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAppointmentHandler_History(t *testing.T) {
+	appointmentID := uuid.New()
+	patientID := uuid.New()
+	actor := auth.Principal{ID: patientID, Role: auth.RolePatient}
+	transitions := []domainMedical.AppointmentTransition{
+		{AppointmentID: appointmentID, ToStatus: domainMedical.AppointmentStatusPending, ActorID: patientID, ActorRole: auth.RolePatient},
+		{AppointmentID: appointmentID, FromStatus: domainMedical.AppointmentStatusPending, ToStatus: domainMedical.AppointmentStatusConfirmed, ActorRole: auth.RoleDoctor},
+	}
+
+	mockService := new(MockAppointmentService)
+	mockService.On("History", mock.Anything, actor, appointmentID).Return(transitions, nil)
+	router := newAppointmentTestRouter(mockService, patientID)
+
+	req := newAuthenticatedRequest(t, http.MethodGet, "/appointments/"+appointmentID.String()+"/history", "")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response struct {
+		Transitions []domainMedical.AppointmentTransition `json:"transitions"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, transitions, response.Transitions)
+	mockService.AssertExpectations(t)
+}
+
+func TestAppointmentHandler_RequiresToken(t *testing.T) {
+	mockService := new(MockAppointmentService)
+	router := newAppointmentTestRouter(mockService, uuid.New())
+
+	req, err := http.NewRequest(http.MethodGet, "/appointments", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockService.AssertExpectations(t)
+}
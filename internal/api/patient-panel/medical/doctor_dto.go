@@ -1,10 +1,14 @@
 package medical
 
 import (
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/validation"
 )
 
+// validate checks the `validate` tags of requests that are not bound by gin
+var validate = validation.New()
+
 type SearchDoctorsRequest struct {
 	SpecialtyID uuid.UUID `form:"specialty_id" validate:"omitempty"`
 	Name        string    `form:"name" validate:"omitempty,max=100"`
@@ -12,6 +16,5 @@ type SearchDoctorsRequest struct {
 
 // Validate validates the search doctors request
 func (r *SearchDoctorsRequest) Validate() error {
-	validate := validator.New()
 	return validate.Struct(r)
 }
@@ -1,76 +1,128 @@
 package medical
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 
+	"github.com/shayesteh1hs/DrAppointment/internal/api/response"
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
 	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
 	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
 	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
-	medicalRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/scheduling"
 )
 
+var (
+	errInvalidPaginationParams = errors.New("invalid pagination parameters")
+	errSearchWithCursor        = errors.New("search results do not support cursor pagination")
+)
+
+// DoctorService serves the doctor catalog and the doctors' free slots
+type DoctorService interface {
+	List(ctx context.Context, filters medicalFilter.DoctorQueryParam, paginator pagination.Paginator[medical.Doctor]) (*pagination.Result[medical.Doctor], error)
+	Get(ctx context.Context, id uuid.UUID) (*medical.DoctorDetail, error)
+	FreeSlots(ctx context.Context, doctorID uuid.UUID, days scheduling.DateRange) (*medical.Availability, error)
+}
+
 type Handler struct {
-	repo medicalRepo.DoctorRepository
+	service DoctorService
 }
 
-func NewHandler(repo medicalRepo.DoctorRepository) *Handler {
+func NewHandler(service DoctorService) *Handler {
 	return &Handler{
-		repo: repo,
+		service: service,
 	}
 }
 
 func (h *Handler) GetAllPaginated(c *gin.Context) {
-	var paginationParams pagination.LimitOffsetParams
-	if err := c.ShouldBindQuery(&paginationParams); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pagination parameters"})
-		return
-	}
-	paginationParams.BaseURL = c.Request.RequestURI
-	if err := paginationParams.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
 	var filterParams medicalFilter.DoctorQueryParam
 	if err := c.ShouldBindQuery(&filterParams); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filter parameters"})
+		_ = c.Error(apperror.Invalid("Invalid filter parameters", err))
 		return
 	}
 	if err := filterParams.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+
+	paginator, err := bindDoctorPaginator(c, filterParams)
+	if err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
 		return
 	}
 
-	totalCount, err := h.repo.Count(c.Request.Context(), filterParams)
+	result, err := h.service.List(c.Request.Context(), filterParams, paginator)
 	if err != nil {
-		log.Printf("failed to fetch doctors count: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch doctors count"})
+		_ = c.Error(fmt.Errorf("fetch doctors: %w", err))
 		return
 	}
 
-	paginator := pagination.NewLimitOffsetPaginator[medical.Doctor](paginationParams)
-	doctors, err := h.repo.GetAllPaginated(c.Request.Context(), filterParams, paginator)
+	middleware.JSON(c, http.StatusOK, response.NewPage(result, response.NewDoctor))
+}
+
+func (h *Handler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		log.Printf("failed to fetch doctors: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch doctors"})
+		_ = c.Error(apperror.Invalid("Invalid doctor id", err))
 		return
 	}
 
-	result, err := paginator.CreatePaginationResult(doctors, totalCount)
+	doctor, err := h.service.Get(c.Request.Context(), id)
 	if err != nil {
-		log.Printf("failed to paginate doctors: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch doctor list."})
+		_ = c.Error(fmt.Errorf("fetch doctor: %w", err))
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	middleware.JSON(c, http.StatusOK, response.NewDoctorDetail(*doctor))
+}
+
+// bindDoctorPaginator builds an offset paginator by default, or a cursor paginator
+// when the client asks for ?pagination=cursor (used by infinite-scroll clients)
+func bindDoctorPaginator(c *gin.Context, filters medicalFilter.DoctorQueryParam) (pagination.Paginator[medical.Doctor], error) {
+	var modeParams pagination.ModeParams
+	if err := c.ShouldBindQuery(&modeParams); err != nil {
+		return nil, errInvalidPaginationParams
+	}
+
+	if modeParams.IsCursor() {
+		// Relevance scores are not a stable keyset, so ranked results are page-numbered only
+		if filters.IsSearch() {
+			return nil, errSearchWithCursor
+		}
+
+		var cursorParams pagination.CursorParams
+		if err := c.ShouldBindQuery(&cursorParams); err != nil {
+			return nil, errInvalidPaginationParams
+		}
+		cursorParams.BaseURL = c.Request.RequestURI
+		cursorParams.SortFields = medical.DoctorSortFields
+		if err := cursorParams.Validate(); err != nil {
+			return nil, err
+		}
+		return pagination.NewCursorPaginator[medical.Doctor](cursorParams), nil
+	}
+
+	var offsetParams pagination.LimitOffsetParams
+	if err := c.ShouldBindQuery(&offsetParams); err != nil {
+		return nil, errInvalidPaginationParams
+	}
+	offsetParams.BaseURL = c.Request.RequestURI
+	offsetParams.SortFields = medical.DoctorSortFields
+	if err := offsetParams.Validate(); err != nil {
+		return nil, err
+	}
+	return pagination.NewLimitOffsetPaginator[medical.Doctor](offsetParams), nil
 }
 
 func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 	doctorRoutes := router.Group("/doctors")
 
 	doctorRoutes.GET("", h.GetAllPaginated)
+	doctorRoutes.GET("/:id", h.GetByID)
 }
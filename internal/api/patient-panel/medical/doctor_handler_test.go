@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -17,36 +18,45 @@ import (
 
 	domainMedical "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
 	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
 	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+	"github.com/shayesteh1hs/DrAppointment/internal/scheduling"
+	"github.com/shayesteh1hs/DrAppointment/internal/validation"
 )
 
-// Mock repository
-type MockDoctorRepository struct {
+// Mock service
+type MockDoctorService struct {
 	mock.Mock
 }
 
-func (m *MockDoctorRepository) GetAllPaginated(ctx context.Context, filters medicalFilter.DoctorQueryParam, paginator *pagination.LimitOffsetPaginator[domainMedical.Doctor]) ([]domainMedical.Doctor, error) {
+// List pages the doctors returned by the mock with the paginator it was given,
+// so the handler's pagination mode shows up in the response
+func (m *MockDoctorService) List(ctx context.Context, filters medicalFilter.DoctorQueryParam, paginator pagination.Paginator[domainMedical.Doctor]) (*pagination.Result[domainMedical.Doctor], error) {
 	args := m.Called(ctx, filters, paginator)
-	var out []domainMedical.Doctor
+	if err := args.Error(2); err != nil {
+		return nil, err
+	}
+	var doctors []domainMedical.Doctor
 	if v := args.Get(0); v != nil {
-		if cast, ok := v.([]domainMedical.Doctor); ok {
-			out = cast
-		}
+		doctors = v.([]domainMedical.Doctor)
 	}
-	return out, args.Error(1)
+	return paginator.CreatePaginationResult(doctors, args.Int(1))
 }
 
-func (m *MockDoctorRepository) Count(ctx context.Context, filters medicalFilter.DoctorQueryParam) (int, error) {
-	args := m.Called(ctx, filters)
-	return args.Int(0), args.Error(1)
+func (m *MockDoctorService) Get(ctx context.Context, id uuid.UUID) (*domainMedical.DoctorDetail, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainMedical.DoctorDetail), args.Error(1)
 }
 
-func (m *MockDoctorRepository) GetByID(ctx context.Context, id uuid.UUID) (*domainMedical.Doctor, error) {
-	args := m.Called(ctx, id)
+func (m *MockDoctorService) FreeSlots(ctx context.Context, doctorID uuid.UUID, days scheduling.DateRange) (*domainMedical.Availability, error) {
+	args := m.Called(ctx, doctorID, days)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*domainMedical.Doctor), args.Error(1)
+	return args.Get(0).(*domainMedical.Availability), args.Error(1)
 }
 
 func TestHandler_GetAllPaginated(t *testing.T) {
@@ -86,16 +96,17 @@ func TestHandler_GetAllPaginated(t *testing.T) {
 	tests := []struct {
 		name               string
 		queryParams        string
-		mockSetup          func(*MockDoctorRepository)
+		mockSetup          func(*MockDoctorService)
 		expectedStatusCode int
 		expectedItemCount  int
+		expectedTotal      int
+		expectNext         bool
 	}{
 		{
 			name:        "Success - Get all doctors",
 			queryParams: "?page=1&limit=10",
-			mockSetup: func(repo *MockDoctorRepository) {
-				repo.On("Count", mock.Anything, mock.Anything).Return(2, nil)
-				repo.On("GetAllPaginated", mock.Anything, mock.Anything, mock.Anything).Return(doctors, nil)
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("List", mock.Anything, mock.Anything, mock.Anything).Return(doctors, 2, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedItemCount:  2,
@@ -103,28 +114,17 @@ func TestHandler_GetAllPaginated(t *testing.T) {
 		{
 			name:        "Success - Filter by name",
 			queryParams: "?page=1&limit=10&name=Smith",
-			mockSetup: func(repo *MockDoctorRepository) {
-				repo.On("Count", mock.Anything, mock.Anything).Return(1, nil)
-				repo.On("GetAllPaginated", mock.Anything, mock.Anything, mock.Anything).Return(doctors[:1], nil)
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("List", mock.Anything, mock.Anything, mock.Anything).Return(doctors[:1], 1, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedItemCount:  1,
 		},
 		{
-			name:        "Error - Count fails",
+			name:        "Error - Service error",
 			queryParams: "?page=1&limit=10",
-			mockSetup: func(repo *MockDoctorRepository) {
-				repo.On("Count", mock.Anything, mock.Anything).Return(0, errors.New("database error"))
-			},
-			expectedStatusCode: http.StatusInternalServerError,
-			expectedItemCount:  0,
-		},
-		{
-			name:        "Error - GetAllPaginated fails",
-			queryParams: "?page=1&limit=10",
-			mockSetup: func(repo *MockDoctorRepository) {
-				repo.On("Count", mock.Anything, mock.Anything).Return(2, nil)
-				repo.On("GetAllPaginated", mock.Anything, mock.Anything, mock.Anything).Return([]domainMedical.Doctor{}, errors.New("database error"))
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("List", mock.Anything, mock.Anything, mock.Anything).Return(nil, 0, errors.New("database error"))
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedItemCount:  0,
@@ -132,18 +132,108 @@ func TestHandler_GetAllPaginated(t *testing.T) {
 		{
 			name:               "Error - Invalid pagination params",
 			queryParams:        "?page=0&limit=10",
-			mockSetup:          func(repo *MockDoctorRepository) {},
+			mockSetup:          func(svc *MockDoctorService) {},
 			expectedStatusCode: http.StatusBadRequest,
 			expectedItemCount:  0,
 		},
+		{
+			name:        "Success - Ordered by name and created_at",
+			queryParams: "?ordering=name,-created_at",
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("List", mock.Anything, mock.Anything, mock.AnythingOfType("*pagination.LimitOffsetPaginator[github.com/shayesteh1hs/DrAppointment/internal/domain/medical.Doctor]")).Return(doctors, 2, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedItemCount:  2,
+			expectedTotal:      2,
+		},
+		{
+			name:               "Error - Ordering not whitelisted",
+			queryParams:        "?ordering=phone_number",
+			mockSetup:          func(svc *MockDoctorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:        "Success - Search",
+			queryParams: "?search=smith",
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("List", mock.Anything, medicalFilter.DoctorQueryParam{Search: "smith"}, mock.Anything).Return(doctors, 2, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedItemCount:  2,
+			expectedTotal:      2,
+		},
+		{
+			name:               "Error - Search in cursor mode",
+			queryParams:        "?search=smith&pagination=cursor",
+			mockSetup:          func(svc *MockDoctorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:        "Success - Specialty filter",
+			queryParams: "?specialty_id=f47ac10b-58cc-4372-8567-0e02b2c3d479",
+			mockSetup: func(svc *MockDoctorService) {
+				filters := medicalFilter.DoctorQueryParam{SpecialtyID: "f47ac10b-58cc-4372-8567-0e02b2c3d479"}
+				svc.On("List", mock.Anything, filters, mock.Anything).Return(doctors, 2, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedItemCount:  2,
+			expectedTotal:      2,
+		},
+		{
+			name:               "Error - Invalid specialty id",
+			queryParams:        "?specialty_id=cardiology",
+			mockSetup:          func(svc *MockDoctorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:        "Success - Cursor mode first page",
+			queryParams: "?pagination=cursor&limit=1",
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("List", mock.Anything, mock.Anything, mock.AnythingOfType("*pagination.CursorPaginator[github.com/shayesteh1hs/DrAppointment/internal/domain/medical.Doctor]")).Return(doctors, 2, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedItemCount:  1,
+			expectedTotal:      2,
+			expectNext:         true,
+		},
+		{
+			name:               "Error - Unknown pagination mode",
+			queryParams:        "?pagination=keyset",
+			mockSetup:          func(svc *MockDoctorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:        "Success - Cursor mode ordered by name",
+			queryParams: "?pagination=cursor&ordering=-name&limit=1",
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("List", mock.Anything, mock.Anything, mock.AnythingOfType("*pagination.CursorPaginator[github.com/shayesteh1hs/DrAppointment/internal/domain/medical.Doctor]")).Return(doctors, 2, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedItemCount:  1,
+			expectedTotal:      2,
+			expectNext:         true,
+		},
+		{
+			name:               "Error - Cursor mode ordering not whitelisted",
+			queryParams:        "?pagination=cursor&ordering=phone_number",
+			mockSetup:          func(svc *MockDoctorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Error - Invalid cursor",
+			queryParams:        "?pagination=cursor&cursor=!!!",
+			mockSetup:          func(svc *MockDoctorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockRepo := new(MockDoctorRepository)
-			tt.mockSetup(mockRepo)
-			handler := NewHandler(mockRepo)
+			mockService := new(MockDoctorService)
+			tt.mockSetup(mockService)
+			handler := NewHandler(mockService)
 			router := gin.New()
+			router.Use(middleware.ErrorHandler(validation.NewTranslator()))
 
 			router.GET("/doctors", handler.GetAllPaginated)
 			req, err := http.NewRequest(http.MethodGet, "/doctors"+tt.queryParams, nil)
@@ -162,11 +252,106 @@ func TestHandler_GetAllPaginated(t *testing.T) {
 				err = json.Unmarshal(w.Body.Bytes(), &response)
 				require.NoError(t, err)
 				assert.Len(t, response.Items, tt.expectedItemCount)
-				assert.Equal(t, tt.expectedItemCount, response.TotalCount)
+				expectedTotal := tt.expectedTotal
+				if expectedTotal == 0 {
+					expectedTotal = tt.expectedItemCount
+				}
+				assert.Equal(t, expectedTotal, response.TotalCount)
+				assert.Equal(t, tt.expectNext, response.Next != nil)
 			}
 
 			// Verify all expectations were met
-			mockRepo.AssertExpectations(t)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GetByID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Now()
+	specialty := domainMedical.Specialty{ID: uuid.New(), Name: "Cardiology", CreatedAt: now, UpdatedAt: now}
+	detail := &domainMedical.DoctorDetail{
+		Doctor: domainMedical.Doctor{
+			ID:          uuid.New(),
+			Name:        "Dr. John Smith",
+			SpecialtyID: specialty.ID,
+			PhoneNumber: "+1234567890",
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		},
+		Specialty: specialty,
+	}
+
+	tests := []struct {
+		name               string
+		id                 string
+		mockSetup          func(svc *MockDoctorService)
+		expectedStatusCode int
+		expectedCode       string
+	}{
+		{
+			name: "Success",
+			id:   detail.ID.String(),
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("Get", mock.Anything, detail.ID).Return(detail, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Error - Invalid id",
+			id:                 "not-a-uuid",
+			mockSetup:          func(svc *MockDoctorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error - Not found",
+			id:   detail.ID.String(),
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("Get", mock.Anything, detail.ID).Return(nil, fmt.Errorf("%w: %s", domainMedical.ErrDoctorNotFound, detail.ID))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedCode:       "not_found",
+		},
+		{
+			name: "Error - Service error",
+			id:   detail.ID.String(),
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("Get", mock.Anything, detail.ID).Return(nil, errors.New("database error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockDoctorService)
+			tt.mockSetup(mockService)
+			router := gin.New()
+			router.Use(middleware.ErrorHandler(validation.NewTranslator()))
+			NewHandler(mockService).RegisterRoutes(router.Group(""))
+
+			req, err := http.NewRequest(http.MethodGet, "/doctors/"+tt.id, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+
+			if tt.expectedStatusCode == http.StatusOK {
+				var response domainMedical.DoctorDetail
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, detail.ID, response.ID)
+				assert.Equal(t, "Cardiology", response.Specialty.Name)
+			}
+			if tt.expectedCode != "" {
+				var response middleware.Problem
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedCode, response.Code)
+			}
+
+			mockService.AssertExpectations(t)
 		})
 	}
 }
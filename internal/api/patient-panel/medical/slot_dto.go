@@ -0,0 +1,66 @@
+package medical
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/api/response"
+	"github.com/shayesteh1hs/DrAppointment/internal/calendar"
+	"github.com/shayesteh1hs/DrAppointment/internal/scheduling"
+)
+
+const maxSlotRangeDays = 31
+
+type SlotQueryParams struct {
+	From string `form:"from" binding:"required"`
+	To   string `form:"to" binding:"required"`
+}
+
+// Days reads the inclusive from/to dates; they are placed in the doctor's time zone by the service
+func (p SlotQueryParams) Days() (scheduling.DateRange, error) {
+	from, err := scheduling.ParseDate(p.From)
+	if err != nil {
+		return scheduling.DateRange{}, fmt.Errorf("from must be a date in %s format", time.DateOnly)
+	}
+	to, err := scheduling.ParseDate(p.To)
+	if err != nil {
+		return scheduling.DateRange{}, fmt.Errorf("to must be a date in %s format", time.DateOnly)
+	}
+	if to.Before(from) {
+		return scheduling.DateRange{}, errors.New("to must not be before from")
+	}
+	if from.DaysUntil(to) >= maxSlotRangeDays {
+		return scheduling.DateRange{}, fmt.Errorf("date range must not exceed %d days", maxSlotRangeDays)
+	}
+
+	return scheduling.DateRange{From: from, To: to}, nil
+}
+
+// ToGregorian rewrites From and To, written in system, as Gregorian dates
+func (p *SlotQueryParams) ToGregorian(system calendar.System) error {
+	from, err := system.GregorianDate(p.From)
+	if err != nil {
+		return fmt.Errorf("from: %w", err)
+	}
+	to, err := system.GregorianDate(p.To)
+	if err != nil {
+		return fmt.Errorf("to: %w", err)
+	}
+	p.From, p.To = from, to
+	return nil
+}
+
+// SlotsResponse lists free slots in UTC and in TimeZone, the doctor's time zone
+type SlotsResponse struct {
+	DoctorID uuid.UUID                    `json:"doctor_id"`
+	TimeZone string                       `json:"time_zone"`
+	Slots    response.List[response.Slot] `json:"slots"`
+}
+
+func (r SlotsResponse) Present(system calendar.System) any {
+	r.Slots = r.Slots.In(system)
+	return r
+}
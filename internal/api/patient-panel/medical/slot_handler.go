@@ -0,0 +1,62 @@
+package medical
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/api/response"
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+)
+
+type SlotHandler struct {
+	service DoctorService
+}
+
+func NewSlotHandler(service DoctorService) *SlotHandler {
+	return &SlotHandler{
+		service: service,
+	}
+}
+
+func (h *SlotHandler) GetFreeSlots(c *gin.Context) {
+	doctorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.Invalid("Invalid doctor id", err))
+		return
+	}
+
+	var params SlotQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid slot parameters", err))
+		return
+	}
+	if err := params.ToGregorian(middleware.CalendarFrom(c)); err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+	days, err := params.Days()
+	if err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+
+	availability, err := h.service.FreeSlots(c.Request.Context(), doctorID, days)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("fetch free slots: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, SlotsResponse{
+		DoctorID: doctorID,
+		TimeZone: availability.TimeZone,
+		Slots:    response.NewList(availability.Slots, response.NewSlot),
+	})
+}
+
+func (h *SlotHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/doctors/:id/slots", h.GetFreeSlots)
+}
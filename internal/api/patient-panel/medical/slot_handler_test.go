@@ -0,0 +1,221 @@
+package medical
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/calendar"
+	domainMedical "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+	"github.com/shayesteh1hs/DrAppointment/internal/scheduling"
+	"github.com/shayesteh1hs/DrAppointment/internal/validation"
+)
+
+func TestSlotHandler_GetFreeSlots(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	doctorID := uuid.New()
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	date := tomorrow.Format(time.DateOnly)
+	midnight := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 0, 0, 0, 0, time.UTC)
+	days := scheduling.DateRange{From: scheduling.DateOf(tomorrow), To: scheduling.DateOf(tomorrow)}
+	nineOClock := midnight.Add(9 * time.Hour)
+	availability := &domainMedical.Availability{
+		TimeZone: "UTC",
+		Slots: []domainMedical.Slot{
+			{StartTime: nineOClock, EndTime: nineOClock.Add(30 * time.Minute)},
+			{StartTime: nineOClock.Add(30 * time.Minute), EndTime: nineOClock.Add(time.Hour)},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		doctorID           string
+		queryParams        string
+		mockSetup          func(*MockDoctorService)
+		expectedStatusCode int
+		expectedSlotCount  int
+	}{
+		{
+			name:        "Success - Free slots of the day",
+			doctorID:    doctorID.String(),
+			queryParams: "?from=" + date + "&to=" + date,
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("FreeSlots", mock.Anything, doctorID, days).Return(availability, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedSlotCount:  2,
+		},
+		{
+			name:        "Error - Doctor not found",
+			doctorID:    doctorID.String(),
+			queryParams: "?from=" + date + "&to=" + date,
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("FreeSlots", mock.Anything, doctorID, days).Return(nil, domainMedical.ErrDoctorNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:        "Error - Service error",
+			doctorID:    doctorID.String(),
+			queryParams: "?from=" + date + "&to=" + date,
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("FreeSlots", mock.Anything, doctorID, days).Return(nil, errors.New("database error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:               "Error - Invalid doctor id",
+			doctorID:           "not-a-uuid",
+			queryParams:        "?from=" + date + "&to=" + date,
+			mockSetup:          func(*MockDoctorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Error - Missing range",
+			doctorID:           doctorID.String(),
+			queryParams:        "",
+			mockSetup:          func(*MockDoctorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Error - Range too long",
+			doctorID:           doctorID.String(),
+			queryParams:        "?from=2025-01-01&to=2025-03-01",
+			mockSetup:          func(*MockDoctorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockDoctorService)
+			tt.mockSetup(mockService)
+
+			router := gin.New()
+			router.Use(middleware.ErrorHandler(validation.NewTranslator()))
+			NewSlotHandler(mockService).RegisterRoutes(router.Group(""))
+
+			req, err := http.NewRequest(http.MethodGet, "/doctors/"+tt.doctorID+"/slots"+tt.queryParams, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			if tt.expectedStatusCode == http.StatusOK {
+				var response SlotsResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, doctorID, response.DoctorID)
+				assert.Equal(t, "UTC", response.TimeZone)
+				assert.Len(t, response.Slots, tt.expectedSlotCount)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSlotHandler_GetFreeSlots_Jalali(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	doctorID := uuid.New()
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	midnight := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 0, 0, 0, 0, time.UTC)
+	days := scheduling.DateRange{From: scheduling.DateOf(tomorrow), To: scheduling.DateOf(tomorrow)}
+	nineOClock := midnight.Add(9 * time.Hour)
+	jalaliDate := calendar.ToJalali(tomorrow).String()
+	availability := &domainMedical.Availability{
+		TimeZone: "UTC",
+		Slots: []domainMedical.Slot{
+			{StartTime: nineOClock, EndTime: nineOClock.Add(30 * time.Minute)},
+			{StartTime: nineOClock.Add(30 * time.Minute), EndTime: nineOClock.Add(time.Hour)},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		queryParams        string
+		header             string
+		mockSetup          func(*MockDoctorService)
+		expectedStatusCode int
+	}{
+		{
+			name:        "Success - Jalali dates with query parameter",
+			queryParams: "?calendar=jalali&from=" + jalaliDate + "&to=" + jalaliDate,
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("FreeSlots", mock.Anything, doctorID, days).Return(availability, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:        "Success - Jalali dates with header",
+			queryParams: "?from=" + jalaliDate + "&to=" + jalaliDate,
+			header:      "jalali",
+			mockSetup: func(svc *MockDoctorService) {
+				svc.On("FreeSlots", mock.Anything, doctorID, days).Return(availability, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Error - Date that does not exist in the Jalali calendar",
+			queryParams:        "?calendar=jalali&from=1402-12-30&to=1402-12-30",
+			mockSetup:          func(*MockDoctorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Error - Unknown calendar",
+			queryParams:        "?calendar=lunar&from=" + jalaliDate + "&to=" + jalaliDate,
+			mockSetup:          func(*MockDoctorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockDoctorService)
+			tt.mockSetup(mockService)
+
+			router := gin.New()
+			router.Use(middleware.ErrorHandler(validation.NewTranslator()), middleware.Calendar())
+			NewSlotHandler(mockService).RegisterRoutes(router.Group(""))
+
+			req, err := http.NewRequest(http.MethodGet, "/doctors/"+doctorID.String()+"/slots"+tt.queryParams, nil)
+			require.NoError(t, err)
+			if tt.header != "" {
+				req.Header.Set(middleware.CalendarHeader, tt.header)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			if tt.expectedStatusCode == http.StatusOK {
+				var response struct {
+					Slots []struct {
+						StartTime       time.Time `json:"start_time"`
+						StartTimeJalali string    `json:"start_time_jalali"`
+						EndTimeJalali   string    `json:"end_time_jalali"`
+					} `json:"slots"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Slots, 2)
+				assert.True(t, nineOClock.Equal(response.Slots[0].StartTime))
+				assert.Equal(t, jalaliDate+"T09:00:00Z", response.Slots[0].StartTimeJalali)
+				assert.Equal(t, jalaliDate+"T09:30:00Z", response.Slots[0].EndTimeJalali)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package medical
+
+import (
+	"github.com/shayesteh1hs/DrAppointment/internal/api/response"
+	"github.com/shayesteh1hs/DrAppointment/internal/calendar"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+// SpecialtiesResponse is the specialty catalog; it is small enough to be returned unpaginated
+type SpecialtiesResponse struct {
+	Items response.List[response.SpecialtySummary] `json:"items"`
+}
+
+func NewSpecialtiesResponse(specialties []medical.SpecialtySummary) SpecialtiesResponse {
+	return SpecialtiesResponse{Items: response.NewList(specialties, response.NewSpecialtySummary)}
+}
+
+func (r SpecialtiesResponse) Present(system calendar.System) any {
+	r.Items = r.Items.In(system)
+	return r
+}
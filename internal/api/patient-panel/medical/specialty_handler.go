@@ -0,0 +1,55 @@
+package medical
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+)
+
+// SpecialtyService serves the specialty catalog with the number of doctors in each specialty
+type SpecialtyService interface {
+	List(ctx context.Context, filters medicalFilter.SpecialtyQueryParam) ([]medical.SpecialtySummary, error)
+}
+
+type SpecialtyHandler struct {
+	service SpecialtyService
+}
+
+func NewSpecialtyHandler(service SpecialtyService) *SpecialtyHandler {
+	return &SpecialtyHandler{
+		service: service,
+	}
+}
+
+func (h *SpecialtyHandler) GetAll(c *gin.Context) {
+	var filterParams medicalFilter.SpecialtyQueryParam
+	if err := c.ShouldBindQuery(&filterParams); err != nil {
+		_ = c.Error(apperror.Invalid("Invalid filter parameters", err))
+		return
+	}
+	if err := filterParams.Validate(); err != nil {
+		_ = c.Error(apperror.Invalid(err.Error(), err))
+		return
+	}
+
+	specialties, err := h.service.List(c.Request.Context(), filterParams)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("fetch specialties: %w", err))
+		return
+	}
+
+	middleware.JSON(c, http.StatusOK, NewSpecialtiesResponse(specialties))
+}
+
+func (h *SpecialtyHandler) RegisterRoutes(router *gin.RouterGroup) {
+	specialtyRoutes := router.Group("/specialties")
+
+	specialtyRoutes.GET("", h.GetAll)
+}
@@ -0,0 +1,129 @@
+package medical
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainMedical "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+	"github.com/shayesteh1hs/DrAppointment/internal/validation"
+)
+
+// Mock service
+type MockSpecialtyService struct {
+	mock.Mock
+}
+
+func (m *MockSpecialtyService) List(ctx context.Context, filters medicalFilter.SpecialtyQueryParam) ([]domainMedical.SpecialtySummary, error) {
+	args := m.Called(ctx, filters)
+	var out []domainMedical.SpecialtySummary
+	if v := args.Get(0); v != nil {
+		out = v.([]domainMedical.SpecialtySummary)
+	}
+	return out, args.Error(1)
+}
+
+func TestSpecialtyHandler_GetAll(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Now()
+	specialties := []domainMedical.SpecialtySummary{
+		{Specialty: domainMedical.Specialty{ID: uuid.New(), Name: "Cardiology", CreatedAt: now, UpdatedAt: now}, DoctorCount: 4},
+		{Specialty: domainMedical.Specialty{ID: uuid.New(), Name: "Dermatology", CreatedAt: now, UpdatedAt: now}, DoctorCount: 0},
+	}
+
+	tests := []struct {
+		name               string
+		queryParams        string
+		mockSetup          func(svc *MockSpecialtyService)
+		expectedStatusCode int
+		expectedItemCount  int
+	}{
+		{
+			name:        "Success - All specialties",
+			queryParams: "",
+			mockSetup: func(svc *MockSpecialtyService) {
+				svc.On("List", mock.Anything, medicalFilter.SpecialtyQueryParam{}).Return(specialties, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedItemCount:  2,
+		},
+		{
+			name:        "Success - Filtered",
+			queryParams: "?name=card&has_doctors=true",
+			mockSetup: func(svc *MockSpecialtyService) {
+				svc.On("List", mock.Anything, medicalFilter.SpecialtyQueryParam{Name: "card", HasDoctors: true}).Return(specialties[:1], nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedItemCount:  1,
+		},
+		{
+			name:        "Success - Empty catalog",
+			queryParams: "",
+			mockSetup: func(svc *MockSpecialtyService) {
+				svc.On("List", mock.Anything, mock.Anything).Return([]domainMedical.SpecialtySummary{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedItemCount:  0,
+		},
+		{
+			name:               "Error - Invalid has_doctors",
+			queryParams:        "?has_doctors=maybe",
+			mockSetup:          func(svc *MockSpecialtyService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:        "Error - Service error",
+			queryParams: "",
+			mockSetup: func(svc *MockSpecialtyService) {
+				svc.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSpecialtyService)
+			tt.mockSetup(mockService)
+			router := gin.New()
+			router.Use(middleware.ErrorHandler(validation.NewTranslator()))
+			NewSpecialtyHandler(mockService).RegisterRoutes(router.Group(""))
+
+			req, err := http.NewRequest(http.MethodGet, "/specialties"+tt.queryParams, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+
+			if tt.expectedStatusCode == http.StatusOK {
+				var response struct {
+					Items []map[string]any `json:"items"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.NotNil(t, response.Items)
+				assert.Len(t, response.Items, tt.expectedItemCount)
+				if tt.expectedItemCount > 0 {
+					assert.Contains(t, response.Items[0], "doctor_count")
+					assert.Contains(t, response.Items[0], "name")
+				}
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package response
+
+import (
+	"github.com/shayesteh1hs/DrAppointment/internal/calendar"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+)
+
+type Admin struct {
+	auth.Admin
+	CreatedAtJalali string `json:"created_at_jalali,omitempty"`
+	UpdatedAtJalali string `json:"updated_at_jalali,omitempty"`
+}
+
+func NewAdmin(admin auth.Admin) Admin {
+	return Admin{Admin: admin}
+}
+
+func (a Admin) In(system calendar.System) Admin {
+	a.CreatedAtJalali = jalali(system, a.CreatedAt)
+	a.UpdatedAtJalali = jalali(system, a.UpdatedAt)
+	return a
+}
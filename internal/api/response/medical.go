@@ -0,0 +1,221 @@
+package response
+
+import (
+	"github.com/shayesteh1hs/DrAppointment/internal/calendar"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+type Appointment struct {
+	medical.Appointment
+	StartTimeJalali      string `json:"start_time_jalali,omitempty"`
+	EndTimeJalali        string `json:"end_time_jalali,omitempty"`
+	LocalStartTimeJalali string `json:"local_start_time_jalali,omitempty"`
+	LocalEndTimeJalali   string `json:"local_end_time_jalali,omitempty"`
+	CreatedAtJalali      string `json:"created_at_jalali,omitempty"`
+	UpdatedAtJalali      string `json:"updated_at_jalali,omitempty"`
+}
+
+func NewAppointment(appointment medical.Appointment) Appointment {
+	return Appointment{Appointment: appointment}
+}
+
+func (a Appointment) In(system calendar.System) Appointment {
+	a.StartTimeJalali = jalali(system, a.StartTime)
+	a.EndTimeJalali = jalali(system, a.EndTime)
+	a.LocalStartTimeJalali = jalali(system, a.LocalStartTime)
+	a.LocalEndTimeJalali = jalali(system, a.LocalEndTime)
+	a.CreatedAtJalali = jalali(system, a.CreatedAt)
+	a.UpdatedAtJalali = jalali(system, a.UpdatedAt)
+	return a
+}
+
+func (a Appointment) Present(system calendar.System) any {
+	return a.In(system)
+}
+
+type AppointmentTransition struct {
+	medical.AppointmentTransition
+	StartTimeJalali      string `json:"start_time_jalali,omitempty"`
+	EndTimeJalali        string `json:"end_time_jalali,omitempty"`
+	LocalStartTimeJalali string `json:"local_start_time_jalali,omitempty"`
+	LocalEndTimeJalali   string `json:"local_end_time_jalali,omitempty"`
+	CreatedAtJalali      string `json:"created_at_jalali,omitempty"`
+}
+
+func NewAppointmentTransition(transition medical.AppointmentTransition) AppointmentTransition {
+	return AppointmentTransition{AppointmentTransition: transition}
+}
+
+func (t AppointmentTransition) In(system calendar.System) AppointmentTransition {
+	t.StartTimeJalali = jalali(system, t.StartTime)
+	t.EndTimeJalali = jalali(system, t.EndTime)
+	t.LocalStartTimeJalali = jalali(system, t.LocalStartTime)
+	t.LocalEndTimeJalali = jalali(system, t.LocalEndTime)
+	t.CreatedAtJalali = jalali(system, t.CreatedAt)
+	return t
+}
+
+type Doctor struct {
+	medical.Doctor
+	CreatedAtJalali string `json:"created_at_jalali,omitempty"`
+	UpdatedAtJalali string `json:"updated_at_jalali,omitempty"`
+}
+
+func NewDoctor(doctor medical.Doctor) Doctor {
+	return Doctor{Doctor: doctor}
+}
+
+func (d Doctor) In(system calendar.System) Doctor {
+	d.CreatedAtJalali = jalali(system, d.CreatedAt)
+	d.UpdatedAtJalali = jalali(system, d.UpdatedAt)
+	return d
+}
+
+func (d Doctor) Present(system calendar.System) any {
+	return d.In(system)
+}
+
+// DoctorDetail is a doctor together with their specialty
+type DoctorDetail struct {
+	Doctor
+	Specialty Specialty `json:"specialty"`
+}
+
+func NewDoctorDetail(detail medical.DoctorDetail) DoctorDetail {
+	return DoctorDetail{Doctor: NewDoctor(detail.Doctor), Specialty: NewSpecialty(detail.Specialty)}
+}
+
+func (d DoctorDetail) In(system calendar.System) DoctorDetail {
+	d.Doctor = d.Doctor.In(system)
+	d.Specialty = d.Specialty.In(system)
+	return d
+}
+
+func (d DoctorDetail) Present(system calendar.System) any {
+	return d.In(system)
+}
+
+type Specialty struct {
+	medical.Specialty
+	CreatedAtJalali string `json:"created_at_jalali,omitempty"`
+	UpdatedAtJalali string `json:"updated_at_jalali,omitempty"`
+}
+
+func NewSpecialty(specialty medical.Specialty) Specialty {
+	return Specialty{Specialty: specialty}
+}
+
+func (s Specialty) In(system calendar.System) Specialty {
+	s.CreatedAtJalali = jalali(system, s.CreatedAt)
+	s.UpdatedAtJalali = jalali(system, s.UpdatedAt)
+	return s
+}
+
+func (s Specialty) Present(system calendar.System) any {
+	return s.In(system)
+}
+
+// SpecialtySummary is a specialty of the catalog with how many doctors practice it
+type SpecialtySummary struct {
+	Specialty
+	DoctorCount int `json:"doctor_count"`
+}
+
+func NewSpecialtySummary(summary medical.SpecialtySummary) SpecialtySummary {
+	return SpecialtySummary{Specialty: NewSpecialty(summary.Specialty), DoctorCount: summary.DoctorCount}
+}
+
+func (s SpecialtySummary) In(system calendar.System) SpecialtySummary {
+	s.Specialty = s.Specialty.In(system)
+	return s
+}
+
+type Schedule struct {
+	medical.Schedule
+	CreatedAtJalali string `json:"created_at_jalali,omitempty"`
+	UpdatedAtJalali string `json:"updated_at_jalali,omitempty"`
+}
+
+func NewSchedule(schedule medical.Schedule) Schedule {
+	return Schedule{Schedule: schedule}
+}
+
+func (s Schedule) In(system calendar.System) Schedule {
+	s.CreatedAtJalali = jalali(system, s.CreatedAt)
+	s.UpdatedAtJalali = jalali(system, s.UpdatedAt)
+	return s
+}
+
+type TimeOff struct {
+	medical.TimeOff
+	StartTimeJalali string `json:"start_time_jalali,omitempty"`
+	EndTimeJalali   string `json:"end_time_jalali,omitempty"`
+	CreatedAtJalali string `json:"created_at_jalali,omitempty"`
+	UpdatedAtJalali string `json:"updated_at_jalali,omitempty"`
+}
+
+func NewTimeOff(timeOff medical.TimeOff) TimeOff {
+	return TimeOff{TimeOff: timeOff}
+}
+
+func (t TimeOff) In(system calendar.System) TimeOff {
+	t.StartTimeJalali = jalali(system, t.StartTime)
+	t.EndTimeJalali = jalali(system, t.EndTime)
+	t.CreatedAtJalali = jalali(system, t.CreatedAt)
+	t.UpdatedAtJalali = jalali(system, t.UpdatedAt)
+	return t
+}
+
+func (t TimeOff) Present(system calendar.System) any {
+	return t.In(system)
+}
+
+type Slot struct {
+	medical.Slot
+	StartTimeJalali      string `json:"start_time_jalali,omitempty"`
+	EndTimeJalali        string `json:"end_time_jalali,omitempty"`
+	LocalStartTimeJalali string `json:"local_start_time_jalali,omitempty"`
+	LocalEndTimeJalali   string `json:"local_end_time_jalali,omitempty"`
+}
+
+func NewSlot(slot medical.Slot) Slot {
+	return Slot{Slot: slot}
+}
+
+func (s Slot) In(system calendar.System) Slot {
+	s.StartTimeJalali = jalali(system, s.StartTime)
+	s.EndTimeJalali = jalali(system, s.EndTime)
+	s.LocalStartTimeJalali = jalali(system, s.LocalStartTime)
+	s.LocalEndTimeJalali = jalali(system, s.LocalEndTime)
+	return s
+}
+
+type Patient struct {
+	medical.Patient
+	CreatedAtJalali string `json:"created_at_jalali,omitempty"`
+	UpdatedAtJalali string `json:"updated_at_jalali,omitempty"`
+}
+
+func NewPatient(patient medical.Patient) Patient {
+	return Patient{Patient: patient}
+}
+
+func (p Patient) In(system calendar.System) Patient {
+	p.CreatedAtJalali = jalali(system, p.CreatedAt)
+	p.UpdatedAtJalali = jalali(system, p.UpdatedAt)
+	return p
+}
+
+// History is the booking, status changes and reschedules of an appointment, oldest first
+type History struct {
+	Transitions List[AppointmentTransition] `json:"transitions"`
+}
+
+func NewHistory(transitions []medical.AppointmentTransition) History {
+	return History{Transitions: NewList(transitions, NewAppointmentTransition)}
+}
+
+func (h History) Present(system calendar.System) any {
+	h.Transitions = h.Transitions.In(system)
+	return h
+}
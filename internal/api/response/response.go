@@ -0,0 +1,72 @@
+// Package response holds the response bodies shared by the panels. Their timestamps stay
+// RFC 3339 in the Gregorian calendar; Jalali clients also get each one in a _jalali field.
+package response
+
+import (
+	"time"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/calendar"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain"
+	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+)
+
+// body is a response body that returns itself with its _jalali fields filled by In
+type body[T any] interface {
+	In(system calendar.System) T
+}
+
+// List is a list of response bodies; it is never rendered as null
+type List[T body[T]] []T
+
+// NewList wraps each of items into its response body
+func NewList[S any, T body[T]](items []S, wrap func(S) T) List[T] {
+	list := make(List[T], 0, len(items))
+	for _, item := range items {
+		list = append(list, wrap(item))
+	}
+	return list
+}
+
+// In returns the list with each item in the calendar
+func (l List[T]) In(system calendar.System) List[T] {
+	out := make(List[T], len(l))
+	for i, item := range l {
+		out[i] = item.In(system)
+	}
+	return out
+}
+
+func (l List[T]) Present(system calendar.System) any {
+	return l.In(system)
+}
+
+// Page is a pagination.Result of response bodies
+type Page[T body[T]] struct {
+	Items      List[T] `json:"items"`
+	TotalCount int     `json:"total_count"`
+	Previous   *string `json:"previous,omitempty"`
+	Next       *string `json:"next,omitempty"`
+}
+
+// NewPage wraps each item of result into its response body
+func NewPage[S domain.ModelEntity, T body[T]](result *pagination.Result[S], wrap func(S) T) Page[T] {
+	return Page[T]{
+		Items:      NewList(result.Items, wrap),
+		TotalCount: result.TotalCount,
+		Previous:   result.Previous,
+		Next:       result.Next,
+	}
+}
+
+func (p Page[T]) Present(system calendar.System) any {
+	p.Items = p.Items.In(system)
+	return p
+}
+
+// jalali writes t for a _jalali field; it is empty, and the field left out, for other calendars
+func jalali(system calendar.System, t time.Time) string {
+	if system != calendar.Jalali {
+		return ""
+	}
+	return system.FormatTime(t)
+}
@@ -0,0 +1,72 @@
+package response
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/calendar"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+)
+
+func TestPage_Present(t *testing.T) {
+	start := time.Date(2024, time.March, 20, 6, 30, 0, 0, time.UTC)
+	result := &pagination.Result[medical.Appointment]{
+		Items:      []medical.Appointment{{ID: uuid.New(), StartTime: start, EndTime: start.Add(30 * time.Minute)}},
+		TotalCount: 1,
+	}
+	page := NewPage(result, NewAppointment)
+
+	tests := []struct {
+		name           string
+		system         calendar.System
+		expectedJalali string
+	}{
+		{name: "gregorian leaves the jalali fields out", system: calendar.Gregorian},
+		{name: "jalali fills them next to the gregorian ones", system: calendar.Jalali, expectedJalali: "1403-01-01T06:30:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(page.Present(tt.system))
+			require.NoError(t, err)
+
+			var decoded struct {
+				Items []map[string]any `json:"items"`
+			}
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			require.Len(t, decoded.Items, 1)
+			item := decoded.Items[0]
+			assert.Equal(t, "2024-03-20T06:30:00Z", item["start_time"])
+			if tt.expectedJalali == "" {
+				assert.NotContains(t, item, "start_time_jalali")
+				return
+			}
+			assert.Equal(t, tt.expectedJalali, item["start_time_jalali"])
+			assert.Equal(t, "1403-01-01T07:00:00Z", item["end_time_jalali"])
+		})
+	}
+}
+
+func TestDoctorDetail_In(t *testing.T) {
+	createdAt := time.Date(2025, time.March, 20, 8, 0, 0, 0, time.UTC)
+	detail := NewDoctorDetail(medical.DoctorDetail{
+		Doctor:    medical.Doctor{Name: "Dr. Karimi", CreatedAt: createdAt},
+		Specialty: medical.Specialty{Name: "پوست", CreatedAt: createdAt.AddDate(-1, 0, 0)},
+	}).In(calendar.Jalali)
+
+	assert.Equal(t, "1403-12-30T08:00:00Z", detail.CreatedAtJalali)
+	assert.Equal(t, "1403-01-01T08:00:00Z", detail.Specialty.CreatedAtJalali)
+	assert.Equal(t, createdAt, detail.CreatedAt)
+}
+
+func TestNewList_NeverNull(t *testing.T) {
+	data, err := json.Marshal(NewHistory(nil).Present(calendar.Gregorian))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"transitions": []}`, string(data))
+}
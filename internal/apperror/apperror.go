@@ -0,0 +1,55 @@
+// Package apperror defines the errors handlers report to API clients
+package apperror
+
+import (
+	"net/http"
+)
+
+// CodeInvalidRequest is the code of every request the client has to fix before retrying
+const CodeInvalidRequest = "invalid_request"
+
+// FieldError describes what is wrong with one field of the request
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is an error with its HTTP representation. Code is stable and meant for programs, Message
+// for people. The wrapped error is never shown to the client.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+	Details []FieldError
+	Err     error
+}
+
+func New(status int, code, message string) *Error {
+	return &Error{
+		Status:  status,
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Invalid reports a malformed request. When cause holds validator errors, each failing field
+// is listed in the response.
+func Invalid(message string, cause error) *Error {
+	return &Error{
+		Status:  http.StatusBadRequest,
+		Code:    CodeInvalidRequest,
+		Message: message,
+		Err:     cause,
+	}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
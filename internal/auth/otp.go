@@ -0,0 +1,326 @@
+// Package auth implements phone number login with one-time codes and JWT session tokens
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/provider/sms"
+	authRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/auth"
+	medicalRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+)
+
+const codeMessage = "کد ورود شما به دکترگو: %s"
+
+// OTPConfig controls how login codes are issued and checked
+type OTPConfig struct {
+	TTL time.Duration
+	// MaxAttempts is how many codes may be tried before the code is locked until a new one is requested
+	MaxAttempts    int
+	ResendCooldown time.Duration
+}
+
+// CooldownError is returned by RequestOTP while the previous code may not be replaced yet
+type CooldownError struct {
+	RetryAfter time.Duration
+}
+
+func (e *CooldownError) Error() string {
+	return fmt.Sprintf("%v, retry after %s", domain.ErrOTPCooldown, e.RetryAfter)
+}
+
+func (e *CooldownError) Unwrap() error {
+	return domain.ErrOTPCooldown
+}
+
+// OTPChallenge describes a login code that was just sent
+type OTPChallenge struct {
+	PhoneNumber string    `json:"phone_number"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	ResendAt    time.Time `json:"resend_at"`
+}
+
+// Login is the result of a successful code verification
+type Login struct {
+	domain.TokenPair
+	Patient *medical.Patient `json:"patient"`
+}
+
+// DoctorLogin is the result of a successful code verification by a doctor
+type DoctorLogin struct {
+	domain.TokenPair
+	Doctor *medical.Doctor `json:"doctor"`
+}
+
+// AdminLogin is the result of a successful code verification by an admin
+type AdminLogin struct {
+	domain.TokenPair
+	Admin *domain.Admin `json:"admin"`
+}
+
+// DoctorLookup finds the doctors signing in; doctors are created by admins, never by a login
+type DoctorLookup interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*medical.Doctor, error)
+	GetByPhoneNumber(ctx context.Context, phoneNumber string) (*medical.Doctor, error)
+}
+
+type Service struct {
+	otps     authRepo.OTPRepository
+	patients medicalRepo.PatientRepository
+	doctors  DoctorLookup
+	admins   authRepo.AdminRepository
+	sender   sms.Sender
+	tokens   *TokenIssuer
+	// key turns codes into HMACs; a plain hash of a 6 digit code could be reversed by brute force
+	key     []byte
+	config  OTPConfig
+	now     func() time.Time
+	newCode func() (string, error)
+}
+
+// OTPKey derives the key of login code hashes from the token signing secret, so that secret itself
+// keys nothing but tokens
+func OTPKey(secret []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte("otp-code"))
+	return h.Sum(nil)
+}
+
+func NewService(otps authRepo.OTPRepository, patients medicalRepo.PatientRepository, doctors DoctorLookup, admins authRepo.AdminRepository, sender sms.Sender, tokens *TokenIssuer, key []byte, config OTPConfig) *Service {
+	return &Service{
+		otps:     otps,
+		patients: patients,
+		doctors:  doctors,
+		admins:   admins,
+		sender:   sender,
+		tokens:   tokens,
+		key:      key,
+		config:   config,
+		now:      time.Now,
+		newCode:  randomCode,
+	}
+}
+
+// RequestOTP sends a new login code to the phone number, replacing any previous code
+func (s *Service) RequestOTP(ctx context.Context, phoneNumber string) (*OTPChallenge, error) {
+	phone, err := NormalizePhoneNumber(phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := s.newCode()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	otp := domain.OTP{
+		PhoneNumber: phone,
+		CodeHash:    s.hash(phone, code),
+		ExpiresAt:   now.Add(s.config.TTL),
+		SentAt:      now,
+	}
+	saved, err := s.otps.Save(ctx, &otp, now.Add(-s.config.ResendCooldown))
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, s.cooldown(ctx, phone, now)
+	}
+
+	if err := s.sender.Send(ctx, phone, fmt.Sprintf(codeMessage, code)); err != nil {
+		// Drop the code so the user is not locked out by the cooldown for a message that never arrived
+		if deleteErr := s.otps.Delete(ctx, phone, otp.CodeHash); deleteErr != nil && !errors.Is(deleteErr, domain.ErrOTPNotFound) {
+			log.Printf("failed to delete unsent login code: %v", deleteErr)
+		}
+		return nil, fmt.Errorf("failed to send login code: %w", err)
+	}
+
+	return &OTPChallenge{
+		PhoneNumber: phone,
+		ExpiresAt:   otp.ExpiresAt,
+		ResendAt:    now.Add(s.config.ResendCooldown),
+	}, nil
+}
+
+// VerifyOTP checks the code sent to the phone number and logs the patient in,
+// registering a new patient on their first login
+func (s *Service) VerifyOTP(ctx context.Context, phoneNumber, code string) (*Login, error) {
+	phone, err := s.consumeCode(ctx, phoneNumber, code)
+	if err != nil {
+		return nil, err
+	}
+
+	patient, err := s.patients.GetOrCreateByPhoneNumber(ctx, phone)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := s.tokens.Issue(patient.ID, domain.RolePatient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Login{TokenPair: *tokens, Patient: patient}, nil
+}
+
+// VerifyDoctorOTP checks the code sent to the phone number and logs in the doctor
+// registered with it. Doctor accounts are never created here.
+func (s *Service) VerifyDoctorOTP(ctx context.Context, phoneNumber, code string) (*DoctorLogin, error) {
+	phone, err := s.consumeCode(ctx, phoneNumber, code)
+	if err != nil {
+		return nil, err
+	}
+
+	doctor, err := s.doctors.GetByPhoneNumber(ctx, phone)
+	if err != nil {
+		if errors.Is(err, medical.ErrDoctorNotFound) {
+			return nil, domain.ErrForbidden
+		}
+		return nil, err
+	}
+	tokens, err := s.tokens.Issue(doctor.ID, domain.RoleDoctor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DoctorLogin{TokenPair: *tokens, Doctor: doctor}, nil
+}
+
+// VerifyAdminOTP checks the code sent to the phone number and logs in the admin
+// registered with it. Admin accounts are never created here.
+func (s *Service) VerifyAdminOTP(ctx context.Context, phoneNumber, code string) (*AdminLogin, error) {
+	phone, err := s.consumeCode(ctx, phoneNumber, code)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := s.admins.GetByPhoneNumber(ctx, phone)
+	if err != nil {
+		if errors.Is(err, domain.ErrAdminNotFound) {
+			return nil, domain.ErrForbidden
+		}
+		return nil, err
+	}
+	tokens, err := s.tokens.Issue(admin.ID, domain.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdminLogin{TokenPair: *tokens, Admin: admin}, nil
+}
+
+// consumeCode checks the code sent to the phone number and deletes it once it matched,
+// returning the normalized phone number the caller has proven to own
+func (s *Service) consumeCode(ctx context.Context, phoneNumber, code string) (string, error) {
+	phone, err := NormalizePhoneNumber(phoneNumber)
+	if err != nil {
+		return "", err
+	}
+
+	// The attempt is counted before the code is compared, so parallel guesses are limited too
+	otp, err := s.otps.RecordAttempt(ctx, phone)
+	if err != nil {
+		if errors.Is(err, domain.ErrOTPNotFound) {
+			return "", domain.ErrOTPInvalid
+		}
+		return "", err
+	}
+
+	switch {
+	case otp.IsExpired(s.now()):
+		return "", domain.ErrOTPExpired
+	case otp.Attempts > s.config.MaxAttempts:
+		return "", domain.ErrOTPAttemptsExceeded
+	case !hmac.Equal([]byte(s.hash(phone, code)), []byte(otp.CodeHash)):
+		return "", domain.ErrOTPInvalid
+	}
+
+	// Codes are single use: of two verifications racing with the right code, only the one
+	// that deletes it logs in
+	if err := s.otps.Delete(ctx, phone, otp.CodeHash); err != nil {
+		if errors.Is(err, domain.ErrOTPNotFound) {
+			return "", domain.ErrOTPInvalid
+		}
+		return "", err
+	}
+	return phone, nil
+}
+
+// Refresh exchanges a valid refresh token for a new token pair
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (*domain.TokenPair, error) {
+	claims, err := s.tokens.Parse(refreshToken, domain.TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := s.accountForClaims(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	return s.tokens.Issue(id, claims.Role)
+}
+
+// accountForClaims makes sure the account a token was issued to still exists
+func (s *Service) accountForClaims(ctx context.Context, claims *domain.Claims) (uuid.UUID, error) {
+	id, err := parseSubject(claims)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	switch claims.Role {
+	case domain.RolePatient:
+		_, err = s.patients.GetByID(ctx, id)
+	case domain.RoleDoctor:
+		_, err = s.doctors.GetByID(ctx, id)
+	case domain.RoleAdmin:
+		_, err = s.admins.GetByID(ctx, id)
+	default:
+		return uuid.Nil, domain.ErrInvalidToken
+	}
+	if err != nil {
+		if errors.Is(err, medical.ErrPatientNotFound) || errors.Is(err, medical.ErrDoctorNotFound) || errors.Is(err, domain.ErrAdminNotFound) {
+			return uuid.Nil, domain.ErrInvalidToken
+		}
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (s *Service) cooldown(ctx context.Context, phone string, now time.Time) error {
+	existing, err := s.otps.GetByPhoneNumber(ctx, phone)
+	if errors.Is(err, domain.ErrOTPNotFound) {
+		// The blocking code was used up in the meantime, so the client may ask again right away
+		return &CooldownError{RetryAfter: time.Second}
+	}
+	if err != nil {
+		return err
+	}
+	retryAfter := existing.SentAt.Add(s.config.ResendCooldown).Sub(now)
+	return &CooldownError{RetryAfter: max(retryAfter, time.Second).Round(time.Second)}
+}
+
+func (s *Service) hash(phone, code string) string {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(phone + ":" + code))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func randomCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate login code: %w", err)
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
@@ -0,0 +1,520 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+// Mock repositories
+
+type MockOTPRepository struct {
+	mock.Mock
+}
+
+func (m *MockOTPRepository) Save(ctx context.Context, otp *domain.OTP, resendAfter time.Time) (bool, error) {
+	args := m.Called(ctx, otp, resendAfter)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOTPRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.OTP, error) {
+	args := m.Called(ctx, phoneNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OTP), args.Error(1)
+}
+
+func (m *MockOTPRepository) RecordAttempt(ctx context.Context, phoneNumber string) (*domain.OTP, error) {
+	args := m.Called(ctx, phoneNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OTP), args.Error(1)
+}
+
+func (m *MockOTPRepository) Delete(ctx context.Context, phoneNumber, codeHash string) error {
+	args := m.Called(ctx, phoneNumber, codeHash)
+	return args.Error(0)
+}
+
+type MockPatientRepository struct {
+	mock.Mock
+}
+
+func (m *MockPatientRepository) GetByID(ctx context.Context, id uuid.UUID) (*medical.Patient, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*medical.Patient), args.Error(1)
+}
+
+func (m *MockPatientRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*medical.Patient, error) {
+	args := m.Called(ctx, phoneNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*medical.Patient), args.Error(1)
+}
+
+func (m *MockPatientRepository) GetOrCreateByPhoneNumber(ctx context.Context, phoneNumber string) (*medical.Patient, error) {
+	args := m.Called(ctx, phoneNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*medical.Patient), args.Error(1)
+}
+
+type MockDoctorLookup struct {
+	mock.Mock
+}
+
+func (m *MockDoctorLookup) GetByID(ctx context.Context, id uuid.UUID) (*medical.Doctor, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*medical.Doctor), args.Error(1)
+}
+
+func (m *MockDoctorLookup) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*medical.Doctor, error) {
+	args := m.Called(ctx, phoneNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*medical.Doctor), args.Error(1)
+}
+
+type MockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Admin, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Admin), args.Error(1)
+}
+
+func (m *MockAdminRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Admin, error) {
+	args := m.Called(ctx, phoneNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Admin), args.Error(1)
+}
+
+type fakeSender struct {
+	sent []string
+	err  error
+}
+
+func (f *fakeSender) Send(_ context.Context, phoneNumber, message string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, phoneNumber+": "+message)
+	return nil
+}
+
+// Helper functions
+
+const testPhone = "+989121234567"
+
+var testNow = time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+
+func newTestService(otps *MockOTPRepository, patients *MockPatientRepository, sender *fakeSender) *Service {
+	service := NewService(otps, patients, new(MockDoctorLookup), new(MockAdminRepository), sender, newTestIssuer(testNow), []byte("otp-key"), OTPConfig{
+		TTL:            2 * time.Minute,
+		MaxAttempts:    3,
+		ResendCooldown: time.Minute,
+	})
+	service.now = func() time.Time { return testNow }
+	service.newCode = func() (string, error) { return "123456", nil }
+	return service
+}
+
+func storedOTP(service *Service, attempts int, expiresAt time.Time) *domain.OTP {
+	return &domain.OTP{
+		PhoneNumber: testPhone,
+		CodeHash:    service.hash(testPhone, "123456"),
+		Attempts:    attempts,
+		ExpiresAt:   expiresAt,
+		SentAt:      expiresAt.Add(-2 * time.Minute),
+	}
+}
+
+// Table-driven tests
+
+func TestService_RequestOTP(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		sender     *fakeSender
+		mockSetup  func(*MockOTPRepository)
+		wantErr    error
+		wantRetry  time.Duration
+		wantSentTo int
+	}{
+		{
+			name:   "code is stored hashed and sent",
+			sender: &fakeSender{},
+			mockSetup: func(m *MockOTPRepository) {
+				m.On("Save", ctx, mock.MatchedBy(func(otp *domain.OTP) bool {
+					return otp.PhoneNumber == testPhone && otp.CodeHash != "123456" && len(otp.CodeHash) == 64 &&
+						otp.ExpiresAt.Equal(testNow.Add(2*time.Minute)) && otp.Attempts == 0
+				}), testNow.Add(-time.Minute)).Return(true, nil)
+			},
+			wantSentTo: 1,
+		},
+		{
+			name:   "cooldown reports the remaining wait",
+			sender: &fakeSender{},
+			mockSetup: func(m *MockOTPRepository) {
+				m.On("Save", ctx, mock.Anything, mock.Anything).Return(false, nil)
+				m.On("GetByPhoneNumber", ctx, testPhone).Return(&domain.OTP{SentAt: testNow.Add(-20 * time.Second)}, nil)
+			},
+			wantErr:   domain.ErrOTPCooldown,
+			wantRetry: 40 * time.Second,
+		},
+		{
+			name:   "failed delivery releases the cooldown",
+			sender: &fakeSender{err: errors.New("gateway down")},
+			mockSetup: func(m *MockOTPRepository) {
+				m.On("Save", ctx, mock.Anything, mock.Anything).Return(true, nil)
+				m.On("Delete", ctx, testPhone, mock.Anything).Return(nil)
+			},
+			wantErr: errors.New("failed to send login code: gateway down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			otps := new(MockOTPRepository)
+			tt.mockSetup(otps)
+			service := newTestService(otps, new(MockPatientRepository), tt.sender)
+
+			challenge, err := service.RequestOTP(ctx, "0912 123 4567")
+
+			otps.AssertExpectations(t)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.Nil(t, challenge)
+				if errors.Is(err, tt.wantErr) {
+					var cooldown *CooldownError
+					require.ErrorAs(t, err, &cooldown)
+					assert.Equal(t, tt.wantRetry, cooldown.RetryAfter)
+				} else {
+					assert.EqualError(t, err, tt.wantErr.Error())
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testPhone, challenge.PhoneNumber)
+			assert.Equal(t, testNow.Add(time.Minute), challenge.ResendAt)
+			require.Len(t, tt.sender.sent, tt.wantSentTo)
+			assert.Contains(t, tt.sender.sent[0], "123456")
+		})
+	}
+}
+
+func TestService_RequestOTP_InvalidPhone(t *testing.T) {
+	service := newTestService(new(MockOTPRepository), new(MockPatientRepository), &fakeSender{})
+
+	_, err := service.RequestOTP(context.Background(), "021-12345678")
+
+	assert.ErrorIs(t, err, ErrInvalidPhoneNumber)
+}
+
+func TestService_VerifyOTP(t *testing.T) {
+	ctx := context.Background()
+	patient := &medical.Patient{ID: uuid.New(), PhoneNumber: testPhone}
+
+	tests := []struct {
+		name      string
+		code      string
+		mockSetup func(*Service, *MockOTPRepository, *MockPatientRepository)
+		wantErr   error
+	}{
+		{
+			name: "correct code logs the patient in",
+			code: "123456",
+			mockSetup: func(s *Service, o *MockOTPRepository, p *MockPatientRepository) {
+				o.On("RecordAttempt", ctx, testPhone).Return(storedOTP(s, 1, testNow.Add(time.Minute)), nil)
+				o.On("Delete", ctx, testPhone, s.hash(testPhone, "123456")).Return(nil)
+				p.On("GetOrCreateByPhoneNumber", ctx, testPhone).Return(patient, nil)
+			},
+		},
+		{
+			name: "code used up by a concurrent verification",
+			code: "123456",
+			mockSetup: func(s *Service, o *MockOTPRepository, p *MockPatientRepository) {
+				o.On("RecordAttempt", ctx, testPhone).Return(storedOTP(s, 1, testNow.Add(time.Minute)), nil)
+				o.On("Delete", ctx, testPhone, s.hash(testPhone, "123456")).Return(domain.ErrOTPNotFound)
+			},
+			wantErr: domain.ErrOTPInvalid,
+		},
+		{
+			name: "wrong code",
+			code: "654321",
+			mockSetup: func(s *Service, o *MockOTPRepository, p *MockPatientRepository) {
+				o.On("RecordAttempt", ctx, testPhone).Return(storedOTP(s, 1, testNow.Add(time.Minute)), nil)
+			},
+			wantErr: domain.ErrOTPInvalid,
+		},
+		{
+			name: "no code requested",
+			code: "123456",
+			mockSetup: func(s *Service, o *MockOTPRepository, p *MockPatientRepository) {
+				o.On("RecordAttempt", ctx, testPhone).Return(nil, domain.ErrOTPNotFound)
+			},
+			wantErr: domain.ErrOTPInvalid,
+		},
+		{
+			name: "expired code",
+			code: "123456",
+			mockSetup: func(s *Service, o *MockOTPRepository, p *MockPatientRepository) {
+				o.On("RecordAttempt", ctx, testPhone).Return(storedOTP(s, 1, testNow), nil)
+			},
+			wantErr: domain.ErrOTPExpired,
+		},
+		{
+			name: "correct code after too many attempts",
+			code: "123456",
+			mockSetup: func(s *Service, o *MockOTPRepository, p *MockPatientRepository) {
+				o.On("RecordAttempt", ctx, testPhone).Return(storedOTP(s, 4, testNow.Add(time.Minute)), nil)
+			},
+			wantErr: domain.ErrOTPAttemptsExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			otps := new(MockOTPRepository)
+			patients := new(MockPatientRepository)
+			service := newTestService(otps, patients, &fakeSender{})
+			tt.mockSetup(service, otps, patients)
+
+			login, err := service.VerifyOTP(ctx, "09121234567", tt.code)
+
+			otps.AssertExpectations(t)
+			patients.AssertExpectations(t)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, login)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, patient, login.Patient)
+
+			claims, err := service.tokens.Parse(login.AccessToken, domain.TokenTypeAccess)
+			require.NoError(t, err)
+			assert.Equal(t, patient.ID.String(), claims.Subject)
+			assert.Equal(t, domain.RolePatient, claims.Role)
+		})
+	}
+}
+
+func TestService_VerifyDoctorOTP(t *testing.T) {
+	ctx := context.Background()
+	doctor := &medical.Doctor{ID: uuid.New(), PhoneNumber: testPhone}
+
+	tests := []struct {
+		name      string
+		mockSetup func(*MockDoctorLookup)
+		wantErr   error
+	}{
+		{
+			name: "registered doctor is logged in",
+			mockSetup: func(d *MockDoctorLookup) {
+				d.On("GetByPhoneNumber", ctx, testPhone).Return(doctor, nil)
+			},
+		},
+		{
+			name: "phone number without a doctor account",
+			mockSetup: func(d *MockDoctorLookup) {
+				d.On("GetByPhoneNumber", ctx, testPhone).Return(nil, medical.ErrDoctorNotFound)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			otps := new(MockOTPRepository)
+			doctors := new(MockDoctorLookup)
+			service := newTestService(otps, new(MockPatientRepository), &fakeSender{})
+			service.doctors = doctors
+			otps.On("RecordAttempt", ctx, testPhone).Return(storedOTP(service, 1, testNow.Add(time.Minute)), nil)
+			otps.On("Delete", ctx, testPhone, service.hash(testPhone, "123456")).Return(nil)
+			tt.mockSetup(doctors)
+
+			login, err := service.VerifyDoctorOTP(ctx, "09121234567", "123456")
+
+			otps.AssertExpectations(t)
+			doctors.AssertExpectations(t)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, login)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, doctor, login.Doctor)
+
+			claims, err := service.tokens.Parse(login.AccessToken, domain.TokenTypeAccess)
+			require.NoError(t, err)
+			assert.Equal(t, doctor.ID.String(), claims.Subject)
+			assert.Equal(t, domain.RoleDoctor, claims.Role)
+		})
+	}
+}
+
+func TestService_VerifyAdminOTP(t *testing.T) {
+	ctx := context.Background()
+	admin := &domain.Admin{ID: uuid.New(), Name: "مدیر سامانه", PhoneNumber: testPhone}
+
+	tests := []struct {
+		name      string
+		mockSetup func(*MockAdminRepository)
+		wantErr   error
+	}{
+		{
+			name: "registered admin is logged in",
+			mockSetup: func(a *MockAdminRepository) {
+				a.On("GetByPhoneNumber", ctx, testPhone).Return(admin, nil)
+			},
+		},
+		{
+			name: "phone number without an admin account",
+			mockSetup: func(a *MockAdminRepository) {
+				a.On("GetByPhoneNumber", ctx, testPhone).Return(nil, domain.ErrAdminNotFound)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			otps := new(MockOTPRepository)
+			admins := new(MockAdminRepository)
+			service := newTestService(otps, new(MockPatientRepository), &fakeSender{})
+			service.admins = admins
+			otps.On("RecordAttempt", ctx, testPhone).Return(storedOTP(service, 1, testNow.Add(time.Minute)), nil)
+			otps.On("Delete", ctx, testPhone, service.hash(testPhone, "123456")).Return(nil)
+			tt.mockSetup(admins)
+
+			login, err := service.VerifyAdminOTP(ctx, "09121234567", "123456")
+
+			otps.AssertExpectations(t)
+			admins.AssertExpectations(t)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, login)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, admin, login.Admin)
+
+			claims, err := service.tokens.Parse(login.AccessToken, domain.TokenTypeAccess)
+			require.NoError(t, err)
+			assert.Equal(t, admin.ID.String(), claims.Subject)
+			assert.Equal(t, domain.RoleAdmin, claims.Role)
+		})
+	}
+}
+
+func TestService_Refresh(t *testing.T) {
+	ctx := context.Background()
+	patient := &medical.Patient{ID: uuid.New(), PhoneNumber: testPhone}
+
+	t.Run("valid refresh token issues a new pair", func(t *testing.T) {
+		patients := new(MockPatientRepository)
+		service := newTestService(new(MockOTPRepository), patients, &fakeSender{})
+		pair, err := service.tokens.Issue(patient.ID, domain.RolePatient)
+		require.NoError(t, err)
+		patients.On("GetByID", ctx, patient.ID).Return(patient, nil)
+
+		refreshed, err := service.Refresh(ctx, pair.RefreshToken)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, pair.RefreshToken, refreshed.RefreshToken)
+		patients.AssertExpectations(t)
+	})
+
+	t.Run("access token is rejected", func(t *testing.T) {
+		service := newTestService(new(MockOTPRepository), new(MockPatientRepository), &fakeSender{})
+		pair, err := service.tokens.Issue(patient.ID, domain.RolePatient)
+		require.NoError(t, err)
+
+		_, err = service.Refresh(ctx, pair.AccessToken)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+
+	t.Run("deleted patient is rejected", func(t *testing.T) {
+		patients := new(MockPatientRepository)
+		service := newTestService(new(MockOTPRepository), patients, &fakeSender{})
+		pair, err := service.tokens.Issue(patient.ID, domain.RolePatient)
+		require.NoError(t, err)
+		patients.On("GetByID", ctx, patient.ID).Return(nil, medical.ErrPatientNotFound)
+
+		_, err = service.Refresh(ctx, pair.RefreshToken)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+
+	t.Run("doctor token is checked against doctors", func(t *testing.T) {
+		doctors := new(MockDoctorLookup)
+		service := newTestService(new(MockOTPRepository), new(MockPatientRepository), &fakeSender{})
+		service.doctors = doctors
+		doctorID := uuid.New()
+		pair, err := service.tokens.Issue(doctorID, domain.RoleDoctor)
+		require.NoError(t, err)
+		doctors.On("GetByID", ctx, doctorID).Return(&medical.Doctor{ID: doctorID}, nil)
+
+		refreshed, err := service.Refresh(ctx, pair.RefreshToken)
+
+		require.NoError(t, err)
+		claims, err := service.tokens.Parse(refreshed.AccessToken, domain.TokenTypeAccess)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleDoctor, claims.Role)
+		doctors.AssertExpectations(t)
+	})
+	t.Run("deleted admin is rejected", func(t *testing.T) {
+		admins := new(MockAdminRepository)
+		service := newTestService(new(MockOTPRepository), new(MockPatientRepository), &fakeSender{})
+		service.admins = admins
+		adminID := uuid.New()
+		pair, err := service.tokens.Issue(adminID, domain.RoleAdmin)
+		require.NoError(t, err)
+		admins.On("GetByID", ctx, adminID).Return(nil, domain.ErrAdminNotFound)
+
+		_, err = service.Refresh(ctx, pair.RefreshToken)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+		admins.AssertExpectations(t)
+	})
+}
+
+func TestOTPKey(t *testing.T) {
+	secret := []byte("a-jwt-secret-of-at-least-32-bytes")
+
+	key := OTPKey(secret)
+
+	assert.Len(t, key, 32)
+	assert.NotEqual(t, secret, key)
+	assert.Equal(t, key, OTPKey(secret))
+	assert.NotEqual(t, key, OTPKey([]byte("another-jwt-secret-of-32-bytes!!")))
+}
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidPhoneNumber is returned for anything that is not an Iranian mobile number
+var ErrInvalidPhoneNumber = errors.New("phone number must be a mobile number such as 09121234567")
+
+// NormalizePhoneNumber converts the ways users type a mobile number (0912…, 912…, +98912…,
+// 0098912…, with spaces, dashes or Persian/Arabic digits) to E.164, e.g. +989121234567
+func NormalizePhoneNumber(raw string) (string, error) {
+	var digits strings.Builder
+	for i, r := range strings.TrimSpace(raw) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r >= '۰' && r <= '۹':
+			digits.WriteRune('0' + r - '۰')
+		case r >= '٠' && r <= '٩':
+			digits.WriteRune('0' + r - '٠')
+		case r == '+' && i == 0, r == ' ', r == '-', r == '(', r == ')':
+		default:
+			return "", ErrInvalidPhoneNumber
+		}
+	}
+
+	number := digits.String()
+	for _, prefix := range []string{"0098", "98", "0"} {
+		if len(number) > 10 && strings.HasPrefix(number, prefix) {
+			number = strings.TrimPrefix(number, prefix)
+			break
+		}
+	}
+
+	if len(number) != 10 || number[0] != '9' {
+		return "", ErrInvalidPhoneNumber
+	}
+	return "+98" + number, nil
+}
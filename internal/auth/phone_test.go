@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{name: "national format", input: "09121234567", want: "+989121234567"},
+		{name: "without leading zero", input: "9121234567", want: "+989121234567"},
+		{name: "international format", input: "+989121234567", want: "+989121234567"},
+		{name: "international with 00", input: "00989121234567", want: "+989121234567"},
+		{name: "country code without plus", input: "989121234567", want: "+989121234567"},
+		{name: "spaces and dashes", input: " 0912-123 4567 ", want: "+989121234567"},
+		{name: "persian digits", input: "۰۹۱۲۱۲۳۴۵۶۷", want: "+989121234567"},
+		{name: "arabic digits", input: "٠٩١٢١٢٣٤٥٦٧", want: "+989121234567"},
+		{name: "landline", input: "02112345678", wantErr: ErrInvalidPhoneNumber},
+		{name: "too short", input: "0912123456", wantErr: ErrInvalidPhoneNumber},
+		{name: "too long", input: "091212345678", wantErr: ErrInvalidPhoneNumber},
+		{name: "letters", input: "0912abc4567", wantErr: ErrInvalidPhoneNumber},
+		{name: "plus in the middle", input: "0912+1234567", wantErr: ErrInvalidPhoneNumber},
+		{name: "empty", input: "", wantErr: ErrInvalidPhoneNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePhoneNumber(tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
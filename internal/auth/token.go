@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/random"
+)
+
+// jwtHeader is the only header the API issues or accepts, which rules out "alg: none" and key confusion
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// TokenIssuer issues and verifies HS256 signed JWT access and refresh tokens
+type TokenIssuer struct {
+	key        []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	now        func() time.Time
+}
+
+func NewTokenIssuer(key []byte, accessTTL, refreshTTL time.Duration) *TokenIssuer {
+	return &TokenIssuer{key: key, accessTTL: accessTTL, refreshTTL: refreshTTL, now: time.Now}
+}
+
+// Issue returns a new access and refresh token pair for the subject
+func (i *TokenIssuer) Issue(subject uuid.UUID, role domain.Role) (*domain.TokenPair, error) {
+	access, err := i.sign(subject, role, domain.TokenTypeAccess, i.accessTTL)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := i.sign(subject, role, domain.TokenTypeRefresh, i.refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenPair{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(i.accessTTL / time.Second),
+	}, nil
+}
+
+// Parse verifies the token signature, expiry and type and returns its claims
+func (i *TokenIssuer) Parse(token string, tokenType domain.TokenType) (*domain.Claims, error) {
+	header, rest, ok := strings.Cut(token, ".")
+	if !ok || header != jwtHeader {
+		return nil, domain.ErrInvalidToken
+	}
+	encodedPayload, encodedSignature, ok := strings.Cut(rest, ".")
+	if !ok {
+		return nil, domain.ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, i.mac(header+"."+encodedPayload)) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+	var claims domain.Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if claims.Type != tokenType || i.now().Unix() >= claims.ExpiresAt {
+		return nil, domain.ErrInvalidToken
+	}
+	return &claims, nil
+}
+
+func (i *TokenIssuer) sign(subject uuid.UUID, role domain.Role, tokenType domain.TokenType, ttl time.Duration) (string, error) {
+	now := i.now()
+	payload, err := json.Marshal(domain.Claims{
+		Subject:   subject.String(),
+		Role:      role,
+		Type:      tokenType,
+		ID:        random.Hex(16),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode token claims: %w", err)
+	}
+
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(i.mac(unsigned)), nil
+}
+
+func (i *TokenIssuer) mac(data string) []byte {
+	h := hmac.New(sha256.New, i.key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func parseSubject(claims *domain.Claims) (uuid.UUID, error) {
+	id, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, domain.ErrInvalidToken
+	}
+	return id, nil
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+)
+
+func newTestIssuer(now time.Time) *TokenIssuer {
+	issuer := NewTokenIssuer([]byte("test-signing-key-test-signing-key"), 15*time.Minute, 24*time.Hour)
+	issuer.now = func() time.Time { return now }
+	return issuer
+}
+
+func TestTokenIssuer_IssueAndParse(t *testing.T) {
+	now := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	issuer := newTestIssuer(now)
+	subject := uuid.New()
+
+	pair, err := issuer.Issue(subject, domain.RolePatient)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer", pair.TokenType)
+	assert.Equal(t, int64(900), pair.ExpiresIn)
+	assert.Len(t, strings.Split(pair.AccessToken, "."), 3)
+
+	claims, err := issuer.Parse(pair.AccessToken, domain.TokenTypeAccess)
+	require.NoError(t, err)
+	assert.Equal(t, subject.String(), claims.Subject)
+	assert.Equal(t, domain.RolePatient, claims.Role)
+	assert.Equal(t, now.Add(15*time.Minute).Unix(), claims.ExpiresAt)
+	assert.NotEmpty(t, claims.ID)
+
+	refresh, err := issuer.Parse(pair.RefreshToken, domain.TokenTypeRefresh)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(24*time.Hour).Unix(), refresh.ExpiresAt)
+	assert.NotEqual(t, claims.ID, refresh.ID)
+}
+
+func TestTokenIssuer_Parse_Invalid(t *testing.T) {
+	now := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	issuer := newTestIssuer(now)
+	pair, err := issuer.Issue(uuid.New(), domain.RolePatient)
+	require.NoError(t, err)
+
+	parts := strings.Split(pair.AccessToken, ".")
+	forgedPayload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"x","role":"admin","typ":"access","exp":9999999999}`))
+	noneHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+
+	tests := []struct {
+		name      string
+		token     string
+		tokenType domain.TokenType
+		issuer    *TokenIssuer
+	}{
+		{name: "refresh token used as access token", token: pair.RefreshToken, tokenType: domain.TokenTypeAccess, issuer: issuer},
+		{name: "access token used as refresh token", token: pair.AccessToken, tokenType: domain.TokenTypeRefresh, issuer: issuer},
+		{name: "expired", token: pair.AccessToken, tokenType: domain.TokenTypeAccess, issuer: newTestIssuer(now.Add(15 * time.Minute))},
+		{name: "forged payload", token: parts[0] + "." + forgedPayload + "." + parts[2], tokenType: domain.TokenTypeAccess, issuer: issuer},
+		{name: "alg none", token: noneHeader + "." + parts[1] + ".", tokenType: domain.TokenTypeAccess, issuer: issuer},
+		{name: "signed with another key", token: pair.AccessToken, tokenType: domain.TokenTypeAccess, issuer: NewTokenIssuer([]byte("another-key"), time.Hour, time.Hour)},
+		{name: "garbage", token: "not-a-token", tokenType: domain.TokenTypeAccess, issuer: issuer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := tt.issuer.Parse(tt.token, tt.tokenType)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidToken)
+			assert.Nil(t, claims)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// System is the calendar dates are exchanged in with a client
+type System string
+
+const (
+	Gregorian System = "gregorian"
+	Jalali    System = "jalali"
+)
+
+// ParseSystem reads a calendar name; an empty name is Gregorian
+func ParseSystem(name string) (System, error) {
+	switch System(strings.ToLower(strings.TrimSpace(name))) {
+	case "", Gregorian:
+		return Gregorian, nil
+	case Jalali:
+		return Jalali, nil
+	default:
+		return "", fmt.Errorf("calendar must be %s or %s", Gregorian, Jalali)
+	}
+}
+
+// FormatTime writes t like time.RFC3339Nano, with the date in the calendar. Jalali times are
+// not RFC 3339 and are only sent in the _jalali fields next to the Gregorian ones.
+func (s System) FormatTime(t time.Time) string {
+	if s != Jalali {
+		return t.Format(time.RFC3339Nano)
+	}
+	return ToJalali(t).String() + t.Format("T15:04:05.999999999Z07:00")
+}
+
+// GregorianDate converts a date written in the calendar to the Gregorian YYYY-MM-DD form
+// the rest of the API works with. Empty values stay empty.
+func (s System) GregorianDate(value string) (string, error) {
+	if s != Jalali || value == "" {
+		return value, nil
+	}
+	date, err := ParseJalaliDate(value)
+	if err != nil {
+		return "", err
+	}
+	return date.Time(time.UTC).Format(time.DateOnly), nil
+}
+
+// Presenter is a response whose timestamps are also written in the calendar of the client
+type Presenter interface {
+	// Present returns the response with its _jalali fields filled when system is Jalali
+	Present(system System) any
+}
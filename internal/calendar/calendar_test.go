@@ -0,0 +1,74 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSystem(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      System
+		expectedError bool
+	}{
+		{name: "empty is gregorian", value: "", expected: Gregorian},
+		{name: "gregorian", value: "gregorian", expected: Gregorian},
+		{name: "jalali in any case", value: " Jalali ", expected: Jalali},
+		{name: "unknown", value: "hijri", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSystem(tt.value)
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestSystem_FormatTime(t *testing.T) {
+	moment := time.Date(2024, time.March, 20, 6, 30, 0, 0, time.UTC)
+	tehran := time.FixedZone("IRST", 3*3600+1800)
+
+	assert.Equal(t, "2024-03-20T06:30:00Z", Gregorian.FormatTime(moment))
+	assert.Equal(t, "1403-01-01T06:30:00Z", Jalali.FormatTime(moment))
+	assert.Equal(t, "1403-01-01T10:00:00+03:30", Jalali.FormatTime(moment.In(tehran)))
+	assert.Equal(t, "1402-12-29T23:59:59.5Z", Jalali.FormatTime(moment.Add(-6*time.Hour-30*time.Minute-500*time.Millisecond)))
+}
+
+func TestSystem_GregorianDate(t *testing.T) {
+	tests := []struct {
+		name          string
+		system        System
+		value         string
+		expected      string
+		expectedError bool
+	}{
+		{name: "gregorian is kept", system: Gregorian, value: "2024-03-20", expected: "2024-03-20"},
+		{name: "jalali is converted", system: Jalali, value: "1403-01-01", expected: "2024-03-20"},
+		{name: "jalali leap day", system: Jalali, value: "1403-12-30", expected: "2025-03-20"},
+		{name: "empty stays empty", system: Jalali, value: "", expected: ""},
+		{name: "missing leap day", system: Jalali, value: "1402-12-30", expectedError: true},
+		{name: "malformed jalali date", system: Jalali, value: "1403/01/01", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.system.GregorianDate(tt.value)
+			if tt.expectedError {
+				require.ErrorIs(t, err, ErrInvalidJalaliDate)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Package calendar converts between the Gregorian and the Solar Hijri (Jalali) calendars
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// JalaliDateLayout describes how Jalali dates are written: year, month and day, zero padded
+const JalaliDateLayout = "YYYY-MM-DD"
+
+// Supported range of Jalali years. The leap rule below is the astronomical one fitted to
+// these years and is not defined outside them.
+const (
+	minJalaliYear = -61
+	maxJalaliYear = 3177
+)
+
+var ErrInvalidJalaliDate = errors.New("invalid jalali date")
+
+var jalaliDatePattern = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+
+// jalaliBreaks are the years in which the 33 year leap cycle of the calendar is interrupted
+var jalaliBreaks = []int{
+	-61, 9, 38, 199, 426, 686, 756, 818, 1111, 1181, 1210,
+	1635, 2060, 2097, 2192, 2262, 2324, 2394, 2456, 3178,
+}
+
+// JalaliDate is a day of the Solar Hijri calendar. Months are 1 (Farvardin) to 12 (Esfand).
+type JalaliDate struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+// jalaliYear describes where a Jalali year falls in the Gregorian calendar
+type jalaliYear struct {
+	// sinceLeap is 0 in leap years and counts the years since the last leap year otherwise
+	sinceLeap int
+	// gregorianYear is the Gregorian year the Jalali year starts in
+	gregorianYear int
+	// march is the day of March the year starts on (Nowruz)
+	march int
+}
+
+// lookupJalaliYear follows the algorithm of Kazimierz M. Borkowski used by most Jalali libraries
+func lookupJalaliYear(year int) (jalaliYear, error) {
+	if year < minJalaliYear || year > maxJalaliYear {
+		return jalaliYear{}, fmt.Errorf("%w: year %d is outside %d to %d", ErrInvalidJalaliDate, year, minJalaliYear, maxJalaliYear)
+	}
+
+	gregorianYear := year + 621
+	leapJ := -14
+	jp := jalaliBreaks[0]
+	jump := 0
+	for _, jm := range jalaliBreaks[1:] {
+		jump = jm - jp
+		if year < jm {
+			break
+		}
+		leapJ += jump/33*8 + jump%33/4
+		jp = jm
+	}
+
+	n := year - jp
+	leapJ += n/33*8 + (n%33+3)/4
+	if jump%33 == 4 && jump-n == 4 {
+		leapJ++
+	}
+	leapG := gregorianYear/4 - (gregorianYear/100+1)*3/4 - 150
+	march := 20 + leapJ - leapG
+
+	if jump-n < 6 {
+		n = n - jump + (jump+4)/33*33
+	}
+	sinceLeap := ((n+1)%33 - 1) % 4
+	if sinceLeap == -1 {
+		sinceLeap = 4
+	}
+
+	return jalaliYear{sinceLeap: sinceLeap, gregorianYear: gregorianYear, march: march}, nil
+}
+
+// IsJalaliLeapYear reports whether Esfand, the last month of year, has 30 days
+func IsJalaliLeapYear(year int) bool {
+	y, err := lookupJalaliYear(year)
+	return err == nil && y.sinceLeap == 0
+}
+
+// JalaliMonthLength returns the number of days in month of year, or 0 for an invalid month
+func JalaliMonthLength(year, month int) int {
+	switch {
+	case month >= 1 && month <= 6:
+		return 31
+	case month >= 7 && month <= 11:
+		return 30
+	case month == 12 && IsJalaliLeapYear(year):
+		return 30
+	case month == 12:
+		return 29
+	default:
+		return 0
+	}
+}
+
+// NewJalaliDate checks that the day exists in the calendar
+func NewJalaliDate(year, month, day int) (JalaliDate, error) {
+	if _, err := lookupJalaliYear(year); err != nil {
+		return JalaliDate{}, err
+	}
+	if month < 1 || month > 12 {
+		return JalaliDate{}, fmt.Errorf("%w: month %d", ErrInvalidJalaliDate, month)
+	}
+	if day < 1 || day > JalaliMonthLength(year, month) {
+		return JalaliDate{}, fmt.Errorf("%w: day %d of %d-%02d", ErrInvalidJalaliDate, day, year, month)
+	}
+	return JalaliDate{Year: year, Month: month, Day: day}, nil
+}
+
+// ParseJalaliDate reads a date written in JalaliDateLayout
+func ParseJalaliDate(value string) (JalaliDate, error) {
+	if !jalaliDatePattern.MatchString(value) {
+		return JalaliDate{}, fmt.Errorf("%w: %q is not in %s format", ErrInvalidJalaliDate, value, JalaliDateLayout)
+	}
+	year, _ := strconv.Atoi(value[0:4])
+	month, _ := strconv.Atoi(value[5:7])
+	day, _ := strconv.Atoi(value[8:10])
+	return NewJalaliDate(year, month, day)
+}
+
+// ToJalali returns the Jalali day t falls on in its own location
+func ToJalali(t time.Time) JalaliDate {
+	year, month, day := t.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	jy := year - 621
+	y, err := lookupJalaliYear(jy)
+	if err != nil {
+		// Outside the supported range; clamp instead of returning garbage
+		if jy < minJalaliYear {
+			return JalaliDate{Year: minJalaliYear, Month: 1, Day: 1}
+		}
+		return JalaliDate{Year: maxJalaliYear, Month: 12, Day: JalaliMonthLength(maxJalaliYear, 12)}
+	}
+
+	nowruz := time.Date(y.gregorianYear, time.March, y.march, 0, 0, 0, 0, time.UTC)
+	k := int(date.Sub(nowruz).Hours() / 24)
+	if k >= 0 {
+		if k <= 185 {
+			return JalaliDate{Year: jy, Month: 1 + k/31, Day: k%31 + 1}
+		}
+		k -= 186
+	} else {
+		// Before Nowruz the day belongs to the last months of the previous Jalali year
+		jy--
+		k += 179
+		if y.sinceLeap == 1 {
+			k++
+		}
+	}
+	return JalaliDate{Year: jy, Month: 7 + k/30, Day: k%30 + 1}
+}
+
+// Time returns the start of the day in loc
+func (d JalaliDate) Time(loc *time.Location) time.Time {
+	y, err := lookupJalaliYear(d.Year)
+	if err != nil {
+		return time.Time{}
+	}
+	days := (d.Month-1)*31 - d.Month/7*(d.Month-7) + d.Day - 1
+	return time.Date(y.gregorianYear, time.March, y.march+days, 0, 0, 0, 0, loc)
+}
+
+// String writes the date in JalaliDateLayout
+func (d JalaliDate) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
@@ -0,0 +1,198 @@
+package calendar
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsJalaliLeapYear(t *testing.T) {
+	tests := []struct {
+		year     int
+		expected bool
+	}{
+		// Regular four year steps of the 33 year cycle
+		{year: 1370, expected: true},
+		{year: 1375, expected: true},
+		{year: 1379, expected: true},
+		{year: 1383, expected: true},
+		{year: 1387, expected: true},
+		{year: 1391, expected: true},
+		{year: 1395, expected: true},
+		{year: 1399, expected: true},
+		{year: 1403, expected: true},
+		// Five year step at the end of the cycle
+		{year: 1404, expected: false},
+		{year: 1405, expected: false},
+		{year: 1406, expected: false},
+		{year: 1407, expected: false},
+		{year: 1408, expected: true},
+		{year: 1412, expected: true},
+		{year: 1400, expected: false},
+		{year: 1401, expected: false},
+		{year: 1402, expected: false},
+		{year: 1398, expected: false},
+		{year: 1371, expected: false},
+		{year: 1393, expected: false},
+		{year: 1394, expected: false},
+		{year: 1396, expected: false},
+		// Outside the supported range
+		{year: -62, expected: false},
+		{year: 3178, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.year), func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsJalaliLeapYear(tt.year))
+		})
+	}
+}
+
+func TestJalaliMonthLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		year     int
+		month    int
+		expected int
+	}{
+		{name: "Farvardin", year: 1402, month: 1, expected: 31},
+		{name: "Shahrivar", year: 1402, month: 6, expected: 31},
+		{name: "Mehr", year: 1402, month: 7, expected: 30},
+		{name: "Bahman", year: 1402, month: 11, expected: 30},
+		{name: "Esfand of a common year", year: 1402, month: 12, expected: 29},
+		{name: "Esfand of a leap year", year: 1403, month: 12, expected: 30},
+		{name: "month zero", year: 1403, month: 0, expected: 0},
+		{name: "month thirteen", year: 1403, month: 13, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, JalaliMonthLength(tt.year, tt.month))
+		})
+	}
+}
+
+func TestToJalali(t *testing.T) {
+	tests := []struct {
+		name      string
+		gregorian time.Time
+		expected  JalaliDate
+	}{
+		{name: "Nowruz 1403", gregorian: date(2024, time.March, 20), expected: JalaliDate{1403, 1, 1}},
+		{name: "last day of common year 1402", gregorian: date(2024, time.March, 19), expected: JalaliDate{1402, 12, 29}},
+		{name: "Gregorian leap day", gregorian: date(2024, time.February, 29), expected: JalaliDate{1402, 12, 10}},
+		{name: "last day of leap year 1403", gregorian: date(2025, time.March, 20), expected: JalaliDate{1403, 12, 30}},
+		{name: "Nowruz 1404", gregorian: date(2025, time.March, 21), expected: JalaliDate{1404, 1, 1}},
+		{name: "last day of leap year 1399", gregorian: date(2021, time.March, 20), expected: JalaliDate{1399, 12, 30}},
+		{name: "Nowruz 1400", gregorian: date(2021, time.March, 21), expected: JalaliDate{1400, 1, 1}},
+		{name: "last day of leap year 1395", gregorian: date(2017, time.March, 20), expected: JalaliDate{1395, 12, 30}},
+		{name: "last day of Shahrivar", gregorian: date(2023, time.September, 22), expected: JalaliDate{1402, 6, 31}},
+		{name: "first day of Mehr", gregorian: date(2023, time.September, 23), expected: JalaliDate{1402, 7, 1}},
+		{name: "Yalda night", gregorian: date(2023, time.December, 21), expected: JalaliDate{1402, 9, 30}},
+		{name: "Gregorian new year", gregorian: date(2024, time.January, 1), expected: JalaliDate{1402, 10, 11}},
+		{name: "22 Bahman 1357", gregorian: date(1979, time.February, 11), expected: JalaliDate{1357, 11, 22}},
+		{name: "Gregorian year 2000 is leap", gregorian: date(2000, time.February, 29), expected: JalaliDate{1378, 12, 10}},
+		{name: "Nowruz 1395 plus 22 days", gregorian: date(2016, time.April, 11), expected: JalaliDate{1395, 1, 23}},
+		{name: "mid Mordad", gregorian: date(1981, time.August, 17), expected: JalaliDate{1360, 5, 26}},
+		{
+			name:      "day is read in the time's own location",
+			gregorian: time.Date(2024, time.March, 19, 22, 0, 0, 0, time.FixedZone("IRST", 3*3600+1800)).Add(2 * time.Hour),
+			expected:  JalaliDate{1403, 1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ToJalali(tt.gregorian))
+		})
+	}
+}
+
+func TestJalaliDate_Time(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     JalaliDate
+		expected time.Time
+	}{
+		{name: "Nowruz 1403", date: JalaliDate{1403, 1, 1}, expected: date(2024, time.March, 20)},
+		{name: "30 Esfand 1403", date: JalaliDate{1403, 12, 30}, expected: date(2025, time.March, 20)},
+		{name: "29 Esfand 1402", date: JalaliDate{1402, 12, 29}, expected: date(2024, time.March, 19)},
+		{name: "1 Mehr 1402", date: JalaliDate{1402, 7, 1}, expected: date(2023, time.September, 23)},
+		{name: "30 Esfand 1408", date: JalaliDate{1408, 12, 30}, expected: date(2030, time.March, 20)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.date.Time(time.UTC))
+		})
+	}
+}
+
+func TestJalali_RoundTrip(t *testing.T) {
+	start := date(1900, time.January, 1)
+	end := date(2200, time.January, 1)
+
+	previous := ToJalali(start.AddDate(0, 0, -1))
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		jalali := ToJalali(day)
+		require.Equal(t, day, jalali.Time(time.UTC), "round trip of %s via %s", day.Format("2006-01-02"), jalali)
+
+		_, err := NewJalaliDate(jalali.Year, jalali.Month, jalali.Day)
+		require.NoError(t, err, "%s from %s", jalali, day.Format("2006-01-02"))
+
+		// Consecutive days either advance the day or start the next month or year
+		switch {
+		case jalali.Year == previous.Year && jalali.Month == previous.Month:
+			require.Equal(t, previous.Day+1, jalali.Day, "after %s", previous)
+		case jalali.Year == previous.Year:
+			require.Equal(t, previous.Month+1, jalali.Month, "after %s", previous)
+			require.Equal(t, 1, jalali.Day)
+			require.Equal(t, JalaliMonthLength(previous.Year, previous.Month), previous.Day)
+		default:
+			require.Equal(t, previous.Year+1, jalali.Year, "after %s", previous)
+			require.Equal(t, JalaliDate{jalali.Year, 1, 1}, jalali)
+			require.Equal(t, JalaliDate{previous.Year, 12, JalaliMonthLength(previous.Year, 12)}, previous)
+		}
+		previous = jalali
+	}
+}
+
+func TestParseJalaliDate(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      JalaliDate
+		expectedError bool
+	}{
+		{name: "valid date", value: "1403-01-15", expected: JalaliDate{1403, 1, 15}},
+		{name: "30 Esfand of a leap year", value: "1403-12-30", expected: JalaliDate{1403, 12, 30}},
+		{name: "30 Esfand of a common year", value: "1402-12-30", expectedError: true},
+		{name: "31 Mehr", value: "1402-07-31", expectedError: true},
+		{name: "month thirteen", value: "1402-13-01", expectedError: true},
+		{name: "day zero", value: "1402-01-00", expectedError: true},
+		{name: "not padded", value: "1402-1-5", expectedError: true},
+		{name: "trailing text", value: "1402-01-05T10:00", expectedError: true},
+		{name: "signed month", value: "1402-+1-05", expectedError: true},
+		{name: "empty", value: "", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJalaliDate(tt.value)
+			if tt.expectedError {
+				require.ErrorIs(t, err, ErrInvalidJalaliDate)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+			assert.Equal(t, tt.value, got.String())
+		})
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,228 @@
+// Package config loads the application configuration from an optional YAML file and the environment
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+)
+
+// Secret is a string that never reveals its value when printed or marshalled
+type Secret string
+
+const redacted = "[REDACTED]"
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+func (s Secret) MarshalYAML() (any, error) {
+	return s.String(), nil
+}
+
+type Config struct {
+	Server       ServerConfig      `yaml:"server"`
+	Database     DatabaseConfig    `yaml:"database"`
+	Cursor       CursorConfig      `yaml:"cursor"`
+	Auth         AuthConfig        `yaml:"auth"`
+	SMS          SMSConfig         `yaml:"sms"`
+	Appointments AppointmentConfig `yaml:"appointments"`
+}
+
+type ServerConfig struct {
+	Port            int           `yaml:"port"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+type DatabaseConfig struct {
+	Host            string        `yaml:"host"`
+	Port            int           `yaml:"port"`
+	User            string        `yaml:"user"`
+	Password        Secret        `yaml:"password"`
+	Name            string        `yaml:"name"`
+	SSLMode         string        `yaml:"ssl_mode"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnectTimeout  time.Duration `yaml:"connect_timeout"`
+}
+
+type CursorConfig struct {
+	// Secret signs pagination cursors; when empty a random key is used per process
+	Secret Secret `yaml:"secret"`
+	// TTL is how long a cursor stays valid, zero means forever
+	TTL time.Duration `yaml:"ttl"`
+}
+
+type AuthConfig struct {
+	// JWTSecret signs access and refresh tokens and derives the key of login code hashes; when empty a random key is used per process
+	JWTSecret         Secret        `yaml:"jwt_secret"`
+	AccessTokenTTL    time.Duration `yaml:"access_token_ttl"`
+	RefreshTokenTTL   time.Duration `yaml:"refresh_token_ttl"`
+	OTPTTL            time.Duration `yaml:"otp_ttl"`
+	OTPMaxAttempts    int           `yaml:"otp_max_attempts"`
+	OTPResendCooldown time.Duration `yaml:"otp_resend_cooldown"`
+}
+
+type SMSConfig struct {
+	// Driver selects the SMS sender: "console" prints messages, "file" appends them to File
+	Driver string `yaml:"driver"`
+	File   string `yaml:"file"`
+}
+
+// AppointmentConfig holds how long before an appointment starts it can still be changed
+type AppointmentConfig struct {
+	PatientCancelCutoff time.Duration `yaml:"patient_cancel_cutoff"`
+	DoctorCancelCutoff  time.Duration `yaml:"doctor_cancel_cutoff"`
+	RescheduleCutoff    time.Duration `yaml:"reschedule_cutoff"`
+}
+
+// Default returns the configuration used for every value that is not set explicitly
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Port:            8000,
+			ShutdownTimeout: 5 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			User:            "postgres",
+			Password:        "postgres",
+			Name:            "drgo",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnectTimeout:  5 * time.Second,
+		},
+		Auth: AuthConfig{
+			AccessTokenTTL:    15 * time.Minute,
+			RefreshTokenTTL:   30 * 24 * time.Hour,
+			OTPTTL:            2 * time.Minute,
+			OTPMaxAttempts:    5,
+			OTPResendCooldown: time.Minute,
+		},
+		SMS: SMSConfig{
+			Driver: "console",
+			File:   "sms.log",
+		},
+		Appointments: AppointmentConfig{
+			PatientCancelCutoff: 2 * time.Hour,
+			RescheduleCutoff:    2 * time.Hour,
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, then the YAML file at path
+// (skipped when path is empty), then the environment, and validates the result.
+// Every invalid value is reported, not only the first one.
+func Load(path string) (*Config, error) {
+	return load(path, os.LookupEnv)
+}
+
+func load(path string, lookup func(string) (string, bool)) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		if err := cfg.readFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	env := &envReader{lookup: lookup}
+	cfg.readEnv(env)
+
+	if err := errors.Join(env.err(), cfg.Validate()); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	return &cfg, nil
+}
+
+func (c *Config) readFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	decoder := yaml.NewDecoder(f)
+	// Misspelled keys would otherwise be ignored silently
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) readEnv(env *envReader) {
+	env.int("PORT", &c.Server.Port)
+	env.duration("SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+
+	env.string("DB_HOST", &c.Database.Host)
+	env.int("DB_PORT", &c.Database.Port)
+	env.string("DB_USER", &c.Database.User)
+	env.secret("DB_PASSWORD", &c.Database.Password)
+	env.string("DB_NAME", &c.Database.Name)
+	env.string("DB_SSL_MODE", &c.Database.SSLMode)
+	env.int("DB_MAX_OPEN_CONNS", &c.Database.MaxOpenConns)
+	env.int("DB_MAX_IDLE_CONNS", &c.Database.MaxIdleConns)
+	env.duration("DB_CONN_MAX_LIFETIME", &c.Database.ConnMaxLifetime)
+	env.duration("DB_CONNECT_TIMEOUT", &c.Database.ConnectTimeout)
+
+	env.secret("CURSOR_SECRET", &c.Cursor.Secret)
+	env.duration("CURSOR_TTL", &c.Cursor.TTL)
+
+	env.secret("JWT_SECRET", &c.Auth.JWTSecret)
+	env.duration("ACCESS_TOKEN_TTL", &c.Auth.AccessTokenTTL)
+	env.duration("REFRESH_TOKEN_TTL", &c.Auth.RefreshTokenTTL)
+	env.duration("OTP_TTL", &c.Auth.OTPTTL)
+	env.int("OTP_MAX_ATTEMPTS", &c.Auth.OTPMaxAttempts)
+	env.duration("OTP_RESEND_COOLDOWN", &c.Auth.OTPResendCooldown)
+
+	env.string("SMS_DRIVER", &c.SMS.Driver)
+	env.string("SMS_FILE", &c.SMS.File)
+
+	env.duration("PATIENT_CANCEL_CUTOFF", &c.Appointments.PatientCancelCutoff)
+	env.duration("DOCTOR_CANCEL_CUTOFF", &c.Appointments.DoctorCancelCutoff)
+	env.duration("RESCHEDULE_CUTOFF", &c.Appointments.RescheduleCutoff)
+}
+
+// String renders the effective configuration as YAML with secrets redacted
+func (c Config) String() string {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c); err != nil {
+		return fmt.Sprintf("failed to render config: %v", err)
+	}
+	return buf.String()
+}
+
+// Connection returns the settings database.Connect expects
+func (c DatabaseConfig) Connection() database.Config {
+	return database.Config{
+		Host:            c.Host,
+		Port:            c.Port,
+		User:            c.User,
+		Password:        string(c.Password),
+		DBName:          c.Name,
+		SSLMode:         c.SSLMode,
+		MaxOpenConns:    c.MaxOpenConns,
+		MaxIdleConns:    c.MaxIdleConns,
+		ConnMaxLifetime: c.ConnMaxLifetime,
+	}
+}
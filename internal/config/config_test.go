@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Helper functions
+
+func envMap(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// Table-driven tests
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := load("", envMap(nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, Default(), *cfg)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  port: 9000
+database:
+  host: db.internal
+  port: 6432
+  max_open_conns: 50
+  conn_max_lifetime: 1h
+cursor:
+  ttl: 15m
+`)
+
+	cfg, err := load(path, envMap(map[string]string{
+		"DB_HOST":           "override.internal",
+		"DB_PASSWORD":       "s3cret",
+		"CURSOR_TTL":        "30m",
+		"DB_SSL_MODE":       "",
+		"UNRELATED":         "ignored",
+		"DB_MAX_IDLE_CONNS": "10",
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, 9000, cfg.Server.Port)
+	assert.Equal(t, "override.internal", cfg.Database.Host, "environment wins over the file")
+	assert.Equal(t, 6432, cfg.Database.Port, "file wins over the defaults")
+	assert.Equal(t, Secret("s3cret"), cfg.Database.Password)
+	assert.Equal(t, "disable", cfg.Database.SSLMode, "empty variables are ignored")
+	assert.Equal(t, 50, cfg.Database.MaxOpenConns)
+	assert.Equal(t, 10, cfg.Database.MaxIdleConns)
+	assert.Equal(t, time.Hour, cfg.Database.ConnMaxLifetime)
+	assert.Equal(t, 30*time.Minute, cfg.Cursor.TTL)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		env      map[string]string
+		wantErrs []string
+	}{
+		{
+			name: "malformed environment values are all reported",
+			env: map[string]string{
+				"DB_PORT":    "five",
+				"PORT":       "eighty",
+				"CURSOR_TTL": "soon",
+			},
+			wantErrs: []string{
+				`PORT: "eighty" is not an integer`,
+				`DB_PORT: "five" is not an integer`,
+				`CURSOR_TTL: "soon" is not a duration`,
+			},
+		},
+		{
+			name: "validation errors are aggregated",
+			env: map[string]string{
+				"DB_PORT":           "70000",
+				"DB_HOST":           " ",
+				"DB_SSL_MODE":       "maybe",
+				"DB_MAX_OPEN_CONNS": "5",
+				"DB_MAX_IDLE_CONNS": "10",
+				"CURSOR_SECRET":     "short",
+				"SMS_DRIVER":        "pigeon",
+				"REFRESH_TOKEN_TTL": "1m",
+				"RESCHEDULE_CUTOFF": "-1h",
+			},
+			wantErrs: []string{
+				"database.host: is required",
+				"database.port: must be between 1 and 65535, got 70000",
+				`database.ssl_mode: must be one of [disable allow prefer require verify-ca verify-full], got "maybe"`,
+				"database.max_idle_conns: must not exceed max_open_conns (5)",
+				"cursor.secret: must be at least 32 bytes",
+				"auth.refresh_token_ttl: must be longer than access_token_ttl",
+				`sms.driver: must be one of [console file], got "pigeon"`,
+				"appointments.reschedule_cutoff: must not be negative",
+			},
+		},
+		{
+			name:     "unknown keys in the file are rejected",
+			file:     "database:\n  hostname: localhost\n",
+			wantErrs: []string{"field hostname not found"},
+		},
+		{
+			name:     "malformed durations in the file are rejected",
+			file:     "server:\n  shutdown_timeout: later\n",
+			wantErrs: []string{"failed to parse config file"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := ""
+			if tt.file != "" {
+				path = writeConfigFile(t, tt.file)
+			}
+
+			cfg, err := load(path, envMap(tt.env))
+
+			require.Error(t, err)
+			assert.Nil(t, cfg)
+			for _, want := range tt.wantErrs {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+// The documented example must stay loadable and in sync with the defaults
+func TestLoad_ExampleFile(t *testing.T) {
+	cfg, err := load(filepath.Join("..", "..", "config.example.yaml"), envMap(nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, Default(), *cfg)
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	_, err := load(filepath.Join(t.TempDir(), "missing.yaml"), envMap(nil))
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestConfig_String_RedactsSecrets(t *testing.T) {
+	cfg := Default()
+	cfg.Database.Password = "db-password"
+	cfg.Cursor.Secret = Secret(strings.Repeat("k", 32))
+
+	out := cfg.String()
+
+	assert.NotContains(t, out, "db-password")
+	assert.NotContains(t, out, string(cfg.Cursor.Secret))
+	assert.Contains(t, out, "password: '[REDACTED]'")
+	assert.Contains(t, out, "conn_max_lifetime: 30m0s")
+	assert.Contains(t, out, "host: localhost")
+	assert.NotContains(t, fmt.Sprintf("%v %+v", cfg.Database.Password, cfg.Database), "db-password")
+}
+
+func TestDatabaseConfig_Connection(t *testing.T) {
+	cfg := Default().Database
+	cfg.Password = "s3cret"
+
+	conn := cfg.Connection()
+
+	assert.Equal(t, "drgo", conn.DBName)
+	assert.Equal(t, "s3cret", conn.Password)
+	assert.Equal(t, 25, conn.MaxOpenConns)
+	assert.Equal(t, 30*time.Minute, conn.ConnMaxLifetime)
+}
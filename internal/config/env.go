@@ -0,0 +1,62 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// envReader overrides config values from environment variables and collects
+// every malformed value instead of silently keeping the previous one
+type envReader struct {
+	lookup func(string) (string, bool)
+	errs   []error
+}
+
+func (r *envReader) value(key string) (string, bool) {
+	value, ok := r.lookup(key)
+	return value, ok && value != ""
+}
+
+func (r *envReader) string(key string, dst *string) {
+	if value, ok := r.value(key); ok {
+		*dst = value
+	}
+}
+
+func (r *envReader) secret(key string, dst *Secret) {
+	if value, ok := r.value(key); ok {
+		*dst = Secret(value)
+	}
+}
+
+func (r *envReader) int(key string, dst *int) {
+	value, ok := r.value(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s: %q is not an integer", key, value))
+		return
+	}
+	*dst = parsed
+}
+
+func (r *envReader) duration(key string, dst *time.Duration) {
+	value, ok := r.value(key)
+	if !ok {
+		return
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s: %q is not a duration such as 30s or 5m", key, value))
+		return
+	}
+	*dst = parsed
+}
+
+func (r *envReader) err() error {
+	return errors.Join(r.errs...)
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// minSecretLength matches the output size of the HMAC-SHA256 used to sign cursors and tokens
+const minSecretLength = 32
+
+var smsDrivers = []string{"console", "file"}
+
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// Validate reports every invalid setting at once, each prefixed with its YAML path
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, field, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	check(validPort(c.Server.Port), "server.port", "must be between 1 and 65535, got %d", c.Server.Port)
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout", "must be positive")
+
+	db := c.Database
+	check(strings.TrimSpace(db.Host) != "", "database.host", "is required")
+	check(validPort(db.Port), "database.port", "must be between 1 and 65535, got %d", db.Port)
+	check(strings.TrimSpace(db.User) != "", "database.user", "is required")
+	check(strings.TrimSpace(db.Name) != "", "database.name", "is required")
+	check(slices.Contains(sslModes, db.SSLMode), "database.ssl_mode", "must be one of %v, got %q", sslModes, db.SSLMode)
+	check(db.MaxOpenConns >= 0, "database.max_open_conns", "must not be negative")
+	check(db.MaxIdleConns >= 0, "database.max_idle_conns", "must not be negative")
+	check(db.MaxOpenConns == 0 || db.MaxIdleConns <= db.MaxOpenConns, "database.max_idle_conns",
+		"must not exceed max_open_conns (%d)", db.MaxOpenConns)
+	check(db.ConnMaxLifetime >= 0, "database.conn_max_lifetime", "must not be negative")
+	check(db.ConnectTimeout > 0, "database.connect_timeout", "must be positive")
+
+	check(c.Cursor.Secret == "" || len(c.Cursor.Secret) >= minSecretLength, "cursor.secret",
+		"must be at least %d bytes", minSecretLength)
+	check(c.Cursor.TTL >= 0, "cursor.ttl", "must not be negative")
+
+	auth := c.Auth
+	check(auth.JWTSecret == "" || len(auth.JWTSecret) >= minSecretLength, "auth.jwt_secret",
+		"must be at least %d bytes", minSecretLength)
+	check(auth.AccessTokenTTL > 0, "auth.access_token_ttl", "must be positive")
+	check(auth.RefreshTokenTTL > auth.AccessTokenTTL, "auth.refresh_token_ttl", "must be longer than access_token_ttl")
+	check(auth.OTPTTL > 0, "auth.otp_ttl", "must be positive")
+	check(auth.OTPMaxAttempts > 0, "auth.otp_max_attempts", "must be positive")
+	check(auth.OTPResendCooldown >= 0, "auth.otp_resend_cooldown", "must not be negative")
+
+	check(slices.Contains(smsDrivers, c.SMS.Driver), "sms.driver", "must be one of %v, got %q", smsDrivers, c.SMS.Driver)
+	check(c.SMS.Driver != "file" || strings.TrimSpace(c.SMS.File) != "", "sms.file", "is required by the file driver")
+
+	appointments := c.Appointments
+	check(appointments.PatientCancelCutoff >= 0, "appointments.patient_cancel_cutoff", "must not be negative")
+	check(appointments.DoctorCancelCutoff >= 0, "appointments.doctor_cancel_cutoff", "must not be negative")
+	check(appointments.RescheduleCutoff >= 0, "appointments.reschedule_cutoff", "must not be negative")
+
+	return errors.Join(errs...)
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
@@ -0,0 +1,391 @@
+package migrate
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// lockKey identifies the session-level advisory lock that serializes concurrent migrators
+const lockKey int64 = 0x4472417070742d4d // "DrAppt-M"
+
+const (
+	migrationsTable  = "schema_migrations"
+	repeatablesTable = "schema_repeatables"
+)
+
+var (
+	ErrChecksumMismatch = errors.New("migration was modified after it was applied")
+	ErrNoDownScript     = errors.New("migration has no down script")
+	ErrUnknownMigration = errors.New("applied migration is missing from the source")
+)
+
+// Migration states reported by Status
+const (
+	StateApplied  = "applied"
+	StatePending  = "pending"
+	StateModified = "modified"
+	StateMissing  = "missing"
+)
+
+// Status describes a migration as seen by both the source files and the database
+type Status struct {
+	Version   int64
+	Name      string
+	State     string
+	AppliedAt *time.Time
+}
+
+type appliedMigration struct {
+	version   int64
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+type Migrator struct {
+	db     *sql.DB
+	source *Source
+}
+
+func NewMigrator(db *sql.DB, source *Source) *Migrator {
+	return &Migrator{db: db, source: source}
+}
+
+// Up applies changed repeatable scripts and then every pending migration in version order
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var migrated []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.applyRepeatables(ctx, conn); err != nil {
+			return err
+		}
+
+		for _, migration := range m.source.Migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			migrated = append(migrated, migration)
+		}
+		return nil
+	})
+	return migrated, err
+}
+
+// Down rolls back the given number of most recently applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var rolledBack []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.latestApplied(applied, steps) {
+			if err := m.rollback(ctx, conn, migration); err != nil {
+				return err
+			}
+			rolledBack = append(rolledBack, migration)
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Redo rolls back the most recently applied migration and applies it again
+func (m *Migrator) Redo(ctx context.Context) (*Migration, error) {
+	var redone *Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		latest := m.latestApplied(applied, 1)
+		if len(latest) == 0 {
+			return nil
+		}
+		if err := m.rollback(ctx, conn, latest[0]); err != nil {
+			return err
+		}
+		if err := m.apply(ctx, conn, latest[0]); err != nil {
+			return err
+		}
+		redone = &latest[0]
+		return nil
+	})
+	return redone, err
+}
+
+// Status lists every known migration, including applied ones whose file is gone
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.source.Migrations {
+			status := Status{Version: migration.Version, Name: migration.Name, State: StatePending}
+			if row, ok := applied[migration.Version]; ok {
+				status.State = StateApplied
+				if row.checksum != migration.Checksum {
+					status.State = StateModified
+				}
+				status.AppliedAt = &row.appliedAt
+				delete(applied, migration.Version)
+			}
+			statuses = append(statuses, status)
+		}
+		for _, row := range applied {
+			statuses = append(statuses, Status{Version: row.version, Name: row.name, State: StateMissing, AppliedAt: &row.appliedAt})
+		}
+		return nil
+	})
+
+	slices.SortFunc(statuses, func(a, b Status) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return statuses, err
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock.
+// Session-level advisory locks belong to a connection, so all work must go through conn.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func(conn *sql.Conn) {
+		err := conn.Close()
+		if err != nil {
+			log.Printf("failed to close connection: %v", err)
+		}
+	}(conn)
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// The lock must be released even when ctx is already cancelled
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey); err != nil {
+			log.Printf("failed to release migration lock: %v", err)
+		}
+	}()
+
+	if err := m.ensureTables(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func (m *Migrator) ensureTables(ctx context.Context, conn *sql.Conn) error {
+	migrations := sqlbuilder.PostgreSQL.NewCreateTableBuilder()
+	migrations.CreateTable(migrationsTable).IfNotExists()
+	migrations.Define("version", "BIGINT", "PRIMARY KEY")
+	migrations.Define("name", "TEXT", "NOT NULL")
+	migrations.Define("checksum", "CHAR(64)", "NOT NULL")
+	migrations.Define("applied_at", "TIMESTAMP WITH TIME ZONE", "NOT NULL", "DEFAULT NOW()")
+
+	repeatables := sqlbuilder.PostgreSQL.NewCreateTableBuilder()
+	repeatables.CreateTable(repeatablesTable).IfNotExists()
+	repeatables.Define("name", "TEXT", "PRIMARY KEY")
+	repeatables.Define("checksum", "CHAR(64)", "NOT NULL")
+	repeatables.Define("applied_at", "TIMESTAMP WITH TIME ZONE", "NOT NULL", "DEFAULT NOW()")
+
+	for _, ctb := range []*sqlbuilder.CreateTableBuilder{migrations, repeatables} {
+		query, args := ctb.Build()
+		if _, err := conn.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to create migration tables: %w", err)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("version", "name", "checksum", "applied_at")
+	sb.From(migrationsTable)
+	sb.OrderByAsc("version")
+
+	query, args := sb.Build()
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch applied migrations: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %v", err)
+		}
+	}(rows)
+
+	applied := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var row appliedMigration
+		if err := rows.Scan(&row.version, &row.name, &row.checksum, &row.appliedAt); err != nil {
+			return nil, err
+		}
+		applied[row.version] = row
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+// verify refuses to run when history and source disagree, since that means the
+// database schema is no longer what the migration files describe
+func (m *Migrator) verify(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	for version, row := range applied {
+		idx := slices.IndexFunc(m.source.Migrations, func(migration Migration) bool { return migration.Version == version })
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: %03d_%s", ErrUnknownMigration, version, row.name)
+		}
+		if m.source.Migrations[idx].Checksum != row.checksum {
+			return nil, fmt.Errorf("%w: %s", ErrChecksumMismatch, m.source.Migrations[idx])
+		}
+	}
+	return applied, nil
+}
+
+func (m *Migrator) latestApplied(applied map[int64]appliedMigration, steps int) []Migration {
+	var latest []Migration
+	for _, migration := range slices.Backward(m.source.Migrations) {
+		if len(latest) >= steps {
+			break
+		}
+		if _, ok := applied[migration.Version]; ok {
+			latest = append(latest, migration)
+		}
+	}
+	return latest
+}
+
+func (m *Migrator) applyRepeatables(ctx context.Context, conn *sql.Conn) error {
+	checksums, err := m.repeatableChecksums(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	for _, repeatable := range m.source.Repeatables {
+		if checksums[repeatable.Name] == repeatable.Checksum {
+			continue
+		}
+
+		ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+		ib.InsertInto(repeatablesTable)
+		ib.Cols("name", "checksum")
+		ib.Values(repeatable.Name, repeatable.Checksum)
+		ib.SQL("ON CONFLICT (name) DO UPDATE SET checksum = EXCLUDED.checksum, applied_at = NOW()")
+
+		if err := inTx(ctx, conn, repeatable.SQL, ib); err != nil {
+			return fmt.Errorf("failed to apply %s: %w", repeatable.Name, err)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) repeatableChecksums(ctx context.Context, conn *sql.Conn) (map[string]string, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("name", "checksum")
+	sb.From(repeatablesTable)
+
+	query, args := sb.Build()
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch applied repeatable scripts: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %v", err)
+		}
+	}(rows)
+
+	checksums := make(map[string]string)
+	for rows.Next() {
+		var name, sum string
+		if err := rows.Scan(&name, &sum); err != nil {
+			return nil, err
+		}
+		checksums[name] = sum
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return checksums, nil
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto(migrationsTable)
+	ib.Cols("version", "name", "checksum")
+	ib.Values(migration.Version, migration.Name, migration.Checksum)
+
+	if err := inTx(ctx, conn, migration.Up, ib); err != nil {
+		return fmt.Errorf("failed to apply migration %s: %w", migration, err)
+	}
+	return nil
+}
+
+func (m *Migrator) rollback(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	if migration.Down == "" {
+		return fmt.Errorf("%w: %s", ErrNoDownScript, migration)
+	}
+
+	db := sqlbuilder.PostgreSQL.NewDeleteBuilder()
+	db.DeleteFrom(migrationsTable)
+	db.Where(db.Equal("version", migration.Version))
+
+	if err := inTx(ctx, conn, migration.Down, db); err != nil {
+		return fmt.Errorf("failed to roll back migration %s: %w", migration, err)
+	}
+	return nil
+}
+
+// inTx runs a script and its bookkeeping statement atomically, so a failed script leaves no trace
+func inTx(ctx context.Context, conn *sql.Conn, script string, bookkeeping sqlbuilder.Builder) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("failed to roll back transaction: %v", err)
+		}
+	}(tx)
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	query, args := bookkeeping.Build()
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
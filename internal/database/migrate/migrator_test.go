@@ -0,0 +1,270 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	selectAppliedQuery     = "SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version ASC"
+	selectRepeatablesQuery = "SELECT name, checksum FROM schema_repeatables"
+	insertAppliedQuery     = "INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)"
+	deleteAppliedQuery     = "DELETE FROM schema_migrations WHERE version = $1"
+	upsertRepeatableQuery  = "INSERT INTO schema_repeatables (name, checksum) VALUES ($1, $2) ON CONFLICT (name) DO UPDATE SET checksum = EXCLUDED.checksum, applied_at = NOW()"
+)
+
+// Helper functions
+
+func newTestSource() *Source {
+	return &Source{
+		Migrations: []Migration{
+			{Version: 0, Name: "create_specialties", Up: "CREATE TABLE specialties ();", Down: "DROP TABLE specialties;", Checksum: checksum([]byte("CREATE TABLE specialties ();"))},
+			{Version: 1, Name: "create_doctors", Up: "CREATE TABLE doctors ();", Down: "DROP TABLE doctors;", Checksum: checksum([]byte("CREATE TABLE doctors ();"))},
+			{Version: 2, Name: "create_holidays", Up: "CREATE TABLE holidays ();", Checksum: checksum([]byte("CREATE TABLE holidays ();"))},
+		},
+		Repeatables: []Repeatable{
+			{Name: "functions/touch.sql", SQL: "CREATE OR REPLACE FUNCTION touch() ...", Checksum: checksum([]byte("CREATE OR REPLACE FUNCTION touch() ..."))},
+		},
+	}
+}
+
+func setupTestDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return db, mock
+}
+
+func exact(query string) string {
+	return regexp.QuoteMeta(query)
+}
+
+func expectLock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(exact("SELECT pg_advisory_lock($1)")).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(exact("CREATE TABLE IF NOT EXISTS schema_migrations")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(exact("CREATE TABLE IF NOT EXISTS schema_repeatables")).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(exact("SELECT pg_advisory_unlock($1)")).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func appliedRows(source *Source, versions ...int64) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"version", "name", "checksum", "applied_at"})
+	for _, v := range versions {
+		m := source.Migrations[v]
+		rows.AddRow(m.Version, m.Name, m.Checksum, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	}
+	return rows
+}
+
+func expectScript(mock sqlmock.Sqlmock, script, bookkeeping string, args ...driver.Value) {
+	mock.ExpectBegin()
+	mock.ExpectExec(exact(script)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(exact(bookkeeping)).WithArgs(args...).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+// Table-driven tests
+
+func TestMigrator_Up(t *testing.T) {
+	ctx := context.Background()
+	source := newTestSource()
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		want      []int64
+		wantErr   error
+	}{
+		{
+			name: "fresh database applies functions then every migration",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				expectLock(m)
+				m.ExpectQuery(exact(selectAppliedQuery)).WillReturnRows(appliedRows(source))
+				m.ExpectQuery(exact(selectRepeatablesQuery)).WillReturnRows(sqlmock.NewRows([]string{"name", "checksum"}))
+				expectScript(m, source.Repeatables[0].SQL, upsertRepeatableQuery, "functions/touch.sql", source.Repeatables[0].Checksum)
+				for _, mig := range source.Migrations {
+					expectScript(m, mig.Up, insertAppliedQuery, mig.Version, mig.Name, mig.Checksum)
+				}
+				expectUnlock(m)
+			},
+			want: []int64{0, 1, 2},
+		},
+		{
+			name: "only pending migrations and changed functions are applied",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				expectLock(m)
+				m.ExpectQuery(exact(selectAppliedQuery)).WillReturnRows(appliedRows(source, 0, 1))
+				m.ExpectQuery(exact(selectRepeatablesQuery)).WillReturnRows(
+					sqlmock.NewRows([]string{"name", "checksum"}).AddRow("functions/touch.sql", source.Repeatables[0].Checksum))
+				expectScript(m, source.Migrations[2].Up, insertAppliedQuery, int64(2), "create_holidays", source.Migrations[2].Checksum)
+				expectUnlock(m)
+			},
+			want: []int64{2},
+		},
+		{
+			name: "edited applied migration is refused",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				expectLock(m)
+				m.ExpectQuery(exact(selectAppliedQuery)).WillReturnRows(
+					sqlmock.NewRows([]string{"version", "name", "checksum", "applied_at"}).
+						AddRow(0, "create_specialties", "stale", time.Now()))
+				expectUnlock(m)
+			},
+			wantErr: ErrChecksumMismatch,
+		},
+		{
+			name: "applied migration missing from source is refused",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				expectLock(m)
+				m.ExpectQuery(exact(selectAppliedQuery)).WillReturnRows(
+					sqlmock.NewRows([]string{"version", "name", "checksum", "applied_at"}).
+						AddRow(9, "dropped", "x", time.Now()))
+				expectUnlock(m)
+			},
+			wantErr: ErrUnknownMigration,
+		},
+		{
+			name: "failed script is rolled back and stops the run",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				expectLock(m)
+				m.ExpectQuery(exact(selectAppliedQuery)).WillReturnRows(appliedRows(source, 0, 1))
+				m.ExpectQuery(exact(selectRepeatablesQuery)).WillReturnRows(
+					sqlmock.NewRows([]string{"name", "checksum"}).AddRow("functions/touch.sql", source.Repeatables[0].Checksum))
+				m.ExpectBegin()
+				m.ExpectExec(exact(source.Migrations[2].Up)).WillReturnError(errors.New("syntax error"))
+				m.ExpectRollback()
+				expectUnlock(m)
+			},
+			wantErr: errors.New("failed to apply migration 002_create_holidays: syntax error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			got, err := NewMigrator(db, source).Up(ctx)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				if errors.Is(err, tt.wantErr) {
+					assert.ErrorIs(t, err, tt.wantErr)
+				} else {
+					assert.EqualError(t, err, tt.wantErr.Error())
+				}
+			} else {
+				require.NoError(t, err)
+			}
+			var versions []int64
+			for _, m := range got {
+				versions = append(versions, m.Version)
+			}
+			assert.Equal(t, tt.want, versions)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMigrator_Down(t *testing.T) {
+	ctx := context.Background()
+	source := newTestSource()
+
+	t.Run("rolls back the latest migrations in reverse order", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		defer db.Close()
+
+		expectLock(mock)
+		mock.ExpectQuery(exact(selectAppliedQuery)).WillReturnRows(appliedRows(source, 0, 1))
+		expectScript(mock, "DROP TABLE doctors;", deleteAppliedQuery, int64(1))
+		expectScript(mock, "DROP TABLE specialties;", deleteAppliedQuery, int64(0))
+		expectUnlock(mock)
+
+		got, err := NewMigrator(db, source).Down(ctx, 5)
+
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, "create_doctors", got[0].Name)
+		assert.Equal(t, "create_specialties", got[1].Name)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("migration without down script is refused", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		defer db.Close()
+
+		expectLock(mock)
+		mock.ExpectQuery(exact(selectAppliedQuery)).WillReturnRows(appliedRows(source, 0, 1, 2))
+		expectUnlock(mock)
+
+		got, err := NewMigrator(db, source).Down(ctx, 1)
+
+		assert.ErrorIs(t, err, ErrNoDownScript)
+		assert.Empty(t, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigrator_Redo(t *testing.T) {
+	ctx := context.Background()
+	source := newTestSource()
+
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	expectLock(mock)
+	mock.ExpectQuery(exact(selectAppliedQuery)).WillReturnRows(appliedRows(source, 0, 1))
+	expectScript(mock, "DROP TABLE doctors;", deleteAppliedQuery, int64(1))
+	expectScript(mock, "CREATE TABLE doctors ();", insertAppliedQuery, int64(1), "create_doctors", source.Migrations[1].Checksum)
+	expectUnlock(mock)
+
+	got, err := NewMigrator(db, source).Redo(ctx)
+
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, int64(1), got.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Status(t *testing.T) {
+	ctx := context.Background()
+	source := newTestSource()
+	appliedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	expectLock(mock)
+	mock.ExpectQuery(exact(selectAppliedQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"version", "name", "checksum", "applied_at"}).
+			AddRow(0, "create_specialties", source.Migrations[0].Checksum, appliedAt).
+			AddRow(1, "create_doctors", "stale", appliedAt).
+			AddRow(7, "dropped_feature", "x", appliedAt))
+	expectUnlock(mock)
+
+	got, err := NewMigrator(db, source).Status(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+	assert.Equal(t, StateApplied, got[0].State)
+	assert.Equal(t, StateModified, got[1].State)
+	assert.Equal(t, StatePending, got[2].State)
+	assert.Nil(t, got[2].AppliedAt)
+	assert.Equal(t, int64(7), got[3].Version)
+	assert.Equal(t, StateMissing, got[3].State)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
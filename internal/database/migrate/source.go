@@ -0,0 +1,146 @@
+package migrate
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// functionsDir holds repeatable scripts such as shared trigger functions
+const functionsDir = "functions"
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change made of an up script and an optional down script
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+	// Checksum of the up script, used to detect edits to already applied migrations
+	Checksum string
+}
+
+// Repeatable is an idempotent script that is re-applied whenever its content changes
+type Repeatable struct {
+	Name     string
+	SQL      string
+	Checksum string
+}
+
+// Source is the ordered set of scripts loaded from a migrations directory
+type Source struct {
+	Migrations  []Migration
+	Repeatables []Repeatable
+}
+
+// Load reads NNN_name.up.sql / NNN_name.down.sql pairs from the root of fsys and
+// repeatable scripts from its functions/ directory
+func Load(fsys fs.FS) (*Source, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration %s: file name must look like 001_name.up.sql or 001_name.down.sql", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: invalid version: %w", entry.Name(), err)
+		}
+		name, direction := match[2], match[3]
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("migration %s: version %d is already used by %s", entry.Name(), version, m.Name)
+		}
+		if direction == "up" {
+			m.Up = string(content)
+			m.Checksum = checksum(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	source := &Source{}
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %s has no up script", m)
+		}
+		source.Migrations = append(source.Migrations, *m)
+	}
+	slices.SortFunc(source.Migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	source.Repeatables, err = loadRepeatables(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+func loadRepeatables(fsys fs.FS) ([]Repeatable, error) {
+	entries, err := fs.ReadDir(fsys, functionsDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", functionsDir, err)
+	}
+
+	var repeatables []Repeatable
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, path.Join(functionsDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s/%s: %w", functionsDir, entry.Name(), err)
+		}
+		repeatables = append(repeatables, Repeatable{
+			Name:     path.Join(functionsDir, entry.Name()),
+			SQL:      string(content),
+			Checksum: checksum(content),
+		})
+	}
+	// fs.ReadDir already sorts by file name, which is the apply order
+
+	return repeatables, nil
+}
+
+// String returns the file name stem, e.g. 001_create_doctors_table
+func (m Migration) String() string {
+	return fmt.Sprintf("%03d_%s", m.Version, m.Name)
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256([]byte(strings.ReplaceAll(string(content), "\r\n", "\n")))
+	return hex.EncodeToString(sum[:])
+}
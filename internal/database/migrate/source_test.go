@@ -0,0 +1,98 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database/migrations"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"002_create_appointments.up.sql":      {Data: []byte("CREATE TABLE appointments ();")},
+		"002_create_appointments.down.sql":    {Data: []byte("DROP TABLE appointments;")},
+		"001_create_doctors.up.sql":           {Data: []byte("CREATE TABLE doctors ();")},
+		"embed.go":                            {Data: []byte("package migrations")},
+		"functions/update_updated_at.sql":     {Data: []byte("CREATE OR REPLACE FUNCTION f() ...")},
+		"functions/normalize_search_text.sql": {Data: []byte("CREATE OR REPLACE FUNCTION g() ...")},
+	}
+
+	source, err := Load(fsys)
+	require.NoError(t, err)
+
+	require.Len(t, source.Migrations, 2)
+	assert.Equal(t, int64(1), source.Migrations[0].Version)
+	assert.Equal(t, "create_doctors", source.Migrations[0].Name)
+	assert.Empty(t, source.Migrations[0].Down)
+	assert.Equal(t, "001_create_doctors", source.Migrations[0].String())
+	assert.Equal(t, int64(2), source.Migrations[1].Version)
+	assert.Equal(t, "DROP TABLE appointments;", source.Migrations[1].Down)
+	assert.Len(t, source.Migrations[1].Checksum, 64)
+
+	require.Len(t, source.Repeatables, 2)
+	assert.Equal(t, "functions/normalize_search_text.sql", source.Repeatables[0].Name)
+	assert.Equal(t, "functions/update_updated_at.sql", source.Repeatables[1].Name)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		wantErr string
+	}{
+		{
+			name:    "file without direction",
+			fsys:    fstest.MapFS{"001_create_doctors.sql": {Data: []byte("SELECT 1;")}},
+			wantErr: "file name must look like",
+		},
+		{
+			name:    "down without up",
+			fsys:    fstest.MapFS{"001_create_doctors.down.sql": {Data: []byte("DROP TABLE doctors;")}},
+			wantErr: "001_create_doctors has no up script",
+		},
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{
+				"001_create_doctors.up.sql":     {Data: []byte("SELECT 1;")},
+				"001_create_specialties.up.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantErr: "version 1 is already used",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.fsys)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestChecksum_IgnoresLineEndings(t *testing.T) {
+	assert.Equal(t, checksum([]byte("SELECT 1;\nSELECT 2;\n")), checksum([]byte("SELECT 1;\r\nSELECT 2;\r\n")))
+	assert.NotEqual(t, checksum([]byte("SELECT 1;")), checksum([]byte("SELECT 2;")))
+}
+
+// The embedded schema must always load and stay fully reversible
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	source, err := Load(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, source.Migrations)
+
+	for i, m := range source.Migrations {
+		assert.Equal(t, int64(i), m.Version, "migration versions must be contiguous")
+		assert.NotEmpty(t, m.Down, "%s has no down script", m)
+	}
+
+	var names []string
+	for _, r := range source.Repeatables {
+		names = append(names, r.Name)
+	}
+	assert.Contains(t, names, "functions/update_updated_at_column.sql")
+	assert.Contains(t, names, "functions/normalize_search_text.sql")
+}
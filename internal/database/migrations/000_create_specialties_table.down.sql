@@ -0,0 +1,2 @@
+-- Drop specialties table
+DROP TABLE IF EXISTS specialties;
@@ -0,0 +1,2 @@
+-- btree_gist is left installed; other schemas may rely on it
+DROP TABLE IF EXISTS appointments;
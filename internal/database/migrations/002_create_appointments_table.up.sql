@@ -0,0 +1,32 @@
+-- Required for combining uuid equality with range overlap in a GiST exclusion constraint
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+--
+CREATE TABLE IF NOT EXISTS appointments (
+    id UUID DEFAULT uuidv7() PRIMARY KEY,
+    doctor_id UUID NOT NULL,
+    patient_id UUID NOT NULL,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_appointments_doctor_id FOREIGN KEY (doctor_id) REFERENCES doctors(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    CONSTRAINT chk_appointments_time_range CHECK (end_time > start_time),
+    CONSTRAINT chk_appointments_status CHECK (status IN ('pending', 'confirmed', 'cancelled', 'completed')),
+    -- A doctor can not have two active appointments whose time ranges overlap
+    CONSTRAINT excl_appointments_doctor_time_range EXCLUDE USING gist (
+        doctor_id WITH =,
+        tstzrange(start_time, end_time, '[)') WITH &&
+    ) WHERE (status IN ('pending', 'confirmed'))
+);
+
+--
+CREATE INDEX IF NOT EXISTS idx_appointments_doctor_id_start_time ON appointments(doctor_id, start_time);
+CREATE INDEX IF NOT EXISTS idx_appointments_patient_id ON appointments(patient_id);
+
+--
+CREATE TRIGGER update_appointments_updated_at
+    BEFORE UPDATE ON appointments
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
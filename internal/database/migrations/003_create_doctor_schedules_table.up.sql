@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS doctor_schedules (
+    id UUID DEFAULT uuidv7() PRIMARY KEY,
+    doctor_id UUID NOT NULL,
+    day_of_week SMALLINT NOT NULL,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    slot_duration_minutes INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_doctor_schedules_doctor_id FOREIGN KEY (doctor_id) REFERENCES doctors(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_doctor_schedules_day_of_week CHECK (day_of_week BETWEEN 0 AND 6),
+    CONSTRAINT chk_doctor_schedules_time_range CHECK (end_time > start_time),
+    CONSTRAINT chk_doctor_schedules_slot_duration CHECK (slot_duration_minutes > 0)
+);
+
+--
+CREATE INDEX IF NOT EXISTS idx_doctor_schedules_doctor_id_day_of_week ON doctor_schedules(doctor_id, day_of_week);
+
+--
+CREATE TRIGGER update_doctor_schedules_updated_at
+    BEFORE UPDATE ON doctor_schedules
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS doctor_time_off (
+    id UUID DEFAULT uuidv7() PRIMARY KEY,
+    doctor_id UUID NOT NULL,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_doctor_time_off_doctor_id FOREIGN KEY (doctor_id) REFERENCES doctors(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_doctor_time_off_time_range CHECK (end_time > start_time)
+);
+
+--
+CREATE INDEX IF NOT EXISTS idx_doctor_time_off_doctor_id_start_time ON doctor_time_off(doctor_id, start_time);
+
+--
+CREATE TRIGGER update_doctor_time_off_updated_at
+    BEFORE UPDATE ON doctor_time_off
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS holidays (
+    id UUID DEFAULT uuidv7() PRIMARY KEY,
+    date DATE NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+--
+CREATE TRIGGER update_holidays_updated_at
+    BEFORE UPDATE ON holidays
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_doctors_name_search_trgm;
+
+--
+ALTER TABLE doctors DROP COLUMN IF EXISTS name_search;
@@ -0,0 +1,10 @@
+-- Trigram indexes make LIKE '%...%' and fuzzy similarity searches index-backed
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+--
+-- Normalized at write time by functions/normalize_search_text.sql
+ALTER TABLE doctors
+    ADD COLUMN IF NOT EXISTS name_search TEXT GENERATED ALWAYS AS (normalize_search_text(name)) STORED;
+
+--
+CREATE INDEX IF NOT EXISTS idx_doctors_name_search_trgm ON doctors USING gin (name_search gin_trgm_ops);
@@ -0,0 +1,4 @@
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS fk_appointments_patient_id;
+
+--
+DROP TABLE IF EXISTS patients;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS patients (
+    id UUID DEFAULT uuidv7() PRIMARY KEY,
+    -- E.164 formatted mobile number, e.g. +989121234567
+    phone_number VARCHAR(20) NOT NULL UNIQUE,
+    name VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+--
+CREATE TRIGGER update_patients_updated_at
+    BEFORE UPDATE ON patients
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+--
+-- Appointments booked before patient accounts existed are not checked, new ones must reference a patient
+ALTER TABLE appointments
+    ADD CONSTRAINT fk_appointments_patient_id FOREIGN KEY (patient_id) REFERENCES patients(id) ON DELETE RESTRICT ON UPDATE CASCADE NOT VALID;
@@ -0,0 +1,10 @@
+-- At most one outstanding login code per phone number; requesting a new code replaces it
+CREATE TABLE IF NOT EXISTS otp_codes (
+    phone_number VARCHAR(20) PRIMARY KEY,
+    -- HMAC-SHA256 of the code, hex encoded; the code itself is never stored
+    code_hash CHAR(64) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_otp_codes_attempts CHECK (attempts >= 0)
+);
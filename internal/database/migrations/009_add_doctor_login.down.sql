@@ -0,0 +1,11 @@
+-- no_show has no equivalent in the old status set; the visit is over either way
+UPDATE appointments SET status = 'completed' WHERE status = 'no_show';
+
+--
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS chk_appointments_status;
+ALTER TABLE appointments
+    ADD CONSTRAINT chk_appointments_status CHECK (status IN ('pending', 'confirmed', 'cancelled', 'completed'));
+
+--
+-- Normalized phone numbers are kept, they are valid in the old schema too
+DROP INDEX IF EXISTS uq_doctors_phone_number;
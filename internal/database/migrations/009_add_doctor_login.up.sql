@@ -0,0 +1,14 @@
+-- Doctors sign in with the same login codes as patients, so their numbers use the same E.164 form
+UPDATE doctors
+SET phone_number = '+98' || substr(phone_number, 2)
+WHERE phone_number ~ '^09[0-9]{9}$';
+
+--
+-- A phone number identifies at most one doctor account
+CREATE UNIQUE INDEX IF NOT EXISTS uq_doctors_phone_number ON doctors(phone_number);
+
+--
+-- Doctors mark past appointments the patient did not attend as no_show
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS chk_appointments_status;
+ALTER TABLE appointments
+    ADD CONSTRAINT chk_appointments_status CHECK (status IN ('pending', 'confirmed', 'cancelled', 'completed', 'no_show'));
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS audit_log;
+
+--
+DROP TABLE IF EXISTS admins;
@@ -0,0 +1,36 @@
+-- Admins are added by hand; they sign in with login codes like doctors do
+CREATE TABLE IF NOT EXISTS admins (
+    id UUID DEFAULT uuidv7() PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    -- E.164 formatted mobile number, e.g. +989121234567
+    phone_number VARCHAR(20) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+--
+CREATE TRIGGER update_admins_updated_at
+    BEFORE UPDATE ON admins
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+--
+-- Append-only record of changes made through the admin panel. Actors and entities are not
+-- foreign keys, so entries outlive the accounts and rows they describe.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID DEFAULT uuidv7() PRIMARY KEY,
+    actor_id UUID NOT NULL,
+    actor_role VARCHAR(20) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    -- The entity as JSON before and after the change; NULL for creations and deletions respectively
+    before JSONB,
+    after JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_audit_log_action CHECK (action IN ('create', 'update', 'delete'))
+);
+
+--
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(entity_type, entity_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor_id ON audit_log(actor_id, created_at);
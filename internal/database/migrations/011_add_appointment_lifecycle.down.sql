@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS appointment_transitions;
+
+--
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS chk_appointments_status;
+UPDATE appointments SET status = 'cancelled' WHERE status IN ('cancelled_by_patient', 'cancelled_by_doctor');
+ALTER TABLE appointments
+    ADD CONSTRAINT chk_appointments_status CHECK (status IN ('pending', 'confirmed', 'cancelled', 'completed', 'no_show'));
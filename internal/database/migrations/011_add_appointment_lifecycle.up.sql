@@ -0,0 +1,28 @@
+-- Cancellations record who cancelled; until now only patients could cancel
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS chk_appointments_status;
+UPDATE appointments SET status = 'cancelled_by_patient' WHERE status = 'cancelled';
+ALTER TABLE appointments
+    ADD CONSTRAINT chk_appointments_status CHECK (status IN (
+        'pending', 'confirmed', 'cancelled_by_patient', 'cancelled_by_doctor', 'completed', 'no_show'
+    ));
+
+--
+-- Every booking, status change and reschedule of an appointment, oldest first.
+-- Appointments booked before this migration have no history.
+CREATE TABLE IF NOT EXISTS appointment_transitions (
+    id UUID DEFAULT uuidv7() PRIMARY KEY,
+    appointment_id UUID NOT NULL,
+    -- NULL for the booking itself; equal to to_status for a reschedule
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    -- The appointment's time range after the change
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    actor_id UUID NOT NULL,
+    actor_role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_appointment_transitions_appointment_id FOREIGN KEY (appointment_id) REFERENCES appointments(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+--
+CREATE INDEX IF NOT EXISTS idx_appointment_transitions_appointment_id ON appointment_transitions(appointment_id, created_at);
@@ -0,0 +1 @@
+ALTER TABLE doctors DROP COLUMN IF EXISTS time_zone;
@@ -0,0 +1,3 @@
+-- Weekly schedules are wall-clock hours in the doctor's time zone, an IANA name such as Asia/Tehran.
+-- The name is checked by the application; PostgreSQL only knows it once a timestamp is converted.
+ALTER TABLE doctors ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT 'Asia/Tehran';
@@ -0,0 +1,11 @@
+// Package migrations embeds the SQL schema so binaries can migrate without the source tree.
+//
+// Versioned migrations are NNN_name.up.sql / NNN_name.down.sql pairs applied in order.
+// Files in functions/ are repeatable: they are re-applied before versioned migrations
+// whenever their content changes, so they must be idempotent (CREATE OR REPLACE).
+package migrations
+
+import "embed"
+
+//go:embed *.sql functions/*.sql
+var FS embed.FS
@@ -0,0 +1,25 @@
+-- Folds Arabic letter variants to their Persian forms, drops diacritics and tatweel,
+-- turns ZWNJ into a space and maps Persian/Arabic digits to ASCII.
+-- Must stay in sync with filter.NormalizeSearchText, which is applied to search input.
+-- doctors.name_search is a STORED generated column of this function (migration 006), so
+-- existing rows keep the values of the old version when this script changes. Any change
+-- needs a versioned migration that recomputes them, e.g. by dropping and re-adding the
+-- column and its trigram index.
+CREATE OR REPLACE FUNCTION normalize_search_text(input TEXT)
+    RETURNS TEXT AS $$
+SELECT btrim(regexp_replace(
+    translate(
+        lower(regexp_replace(input, '[\u064B-\u065F\u0670\u0640]', '', 'g')),
+        -- ي ى ئ ك ة ۀ أ إ آ ٱ ؤ, Persian digits, Arabic digits, ZWNJ, ZWJ (dropped)
+        E'\u064A\u0649\u0626\u0643\u0629\u06C0\u0623\u0625\u0622\u0671\u0624'
+            || E'\u06F0\u06F1\u06F2\u06F3\u06F4\u06F5\u06F6\u06F7\u06F8\u06F9'
+            || E'\u0660\u0661\u0662\u0663\u0664\u0665\u0666\u0667\u0668\u0669'
+            || E'\u200C\u200D',
+        E'\u06CC\u06CC\u06CC\u06A9\u0647\u0647\u0627\u0627\u0627\u0627\u0648'
+            || '0123456789'
+            || '0123456789'
+            || ' '
+    ),
+    '\s+', ' ', 'g'
+))
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// pqSerializationFailure is the SQLSTATE PostgreSQL reports when a transaction could not be
+// serialized with concurrent ones; running it again from the start may succeed
+const pqSerializationFailure = "40001"
+
+const (
+	defaultMaxAttempts  = 3
+	defaultRetryBackoff = 20 * time.Millisecond
+)
+
+// Querier runs queries; it is satisfied by both *sql.DB and *sql.Tx so repositories
+// can be built on the pool or on a transaction owned by the caller
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+var (
+	_ Querier = (*sql.DB)(nil)
+	_ Querier = (*sql.Tx)(nil)
+)
+
+// UnitOfWork runs a function in a transaction that is committed only when the function succeeds.
+// A transaction aborted by a serialization failure is retried from the start, so the function
+// must not have side effects outside the transaction.
+type UnitOfWork struct {
+	db           *sql.DB
+	opts         *sql.TxOptions
+	maxAttempts  int
+	retryBackoff time.Duration
+}
+
+// NewUnitOfWork creates a unit of work on db; nil opts use the database's default isolation level
+func NewUnitOfWork(db *sql.DB, opts *sql.TxOptions) *UnitOfWork {
+	return &UnitOfWork{
+		db:           db,
+		opts:         opts,
+		maxAttempts:  defaultMaxAttempts,
+		retryBackoff: defaultRetryBackoff,
+	}
+}
+
+// Do runs fn in a transaction, retrying up to the attempt limit when it fails to serialize
+func (u *UnitOfWork) Do(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	var err error
+	for attempt := 1; attempt <= u.maxAttempts; attempt++ {
+		err = u.run(ctx, fn)
+		if !IsSerializationFailure(err) || attempt == u.maxAttempts {
+			return err
+		}
+
+		// Back off a little longer each time so the conflicting transactions can finish
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(time.Duration(attempt) * u.retryBackoff):
+		}
+	}
+	return err
+}
+
+func (u *UnitOfWork) run(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := u.db.BeginTx(ctx, u.opts)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("failed to roll back transaction: %v", err)
+		}
+	}(tx)
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// IsSerializationFailure reports whether err comes from a transaction PostgreSQL aborted
+// because it conflicted with a concurrent one
+func IsSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pqSerializationFailure
+}
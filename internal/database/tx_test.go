@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return db, mock
+}
+
+func TestUnitOfWork_Do(t *testing.T) {
+	serializationFailure := &pq.Error{Code: pqSerializationFailure}
+	errBusiness := errors.New("slot is no longer free")
+
+	tests := []struct {
+		name         string
+		results      []error
+		mockSetup    func(sqlmock.Sqlmock)
+		wantErr      error
+		wantAttempts int
+	}{
+		{
+			name:    "committed on success",
+			results: []error{nil},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectCommit()
+			},
+			wantAttempts: 1,
+		},
+		{
+			name:    "retried after a serialization failure",
+			results: []error{serializationFailure, nil},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectRollback()
+				m.ExpectBegin()
+				m.ExpectCommit()
+			},
+			wantAttempts: 2,
+		},
+		{
+			name:    "other errors are not retried",
+			results: []error{errBusiness},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectRollback()
+			},
+			wantErr:      errBusiness,
+			wantAttempts: 1,
+		},
+		{
+			name:    "gives up after the last attempt",
+			results: []error{serializationFailure, serializationFailure, serializationFailure},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				for range defaultMaxAttempts {
+					m.ExpectBegin()
+					m.ExpectRollback()
+				}
+			},
+			wantErr:      serializationFailure,
+			wantAttempts: defaultMaxAttempts,
+		},
+		{
+			name:    "serialization failure on commit is retried",
+			results: []error{nil, nil},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectCommit().WillReturnError(serializationFailure)
+				m.ExpectBegin()
+				m.ExpectCommit()
+			},
+			wantAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+			tt.mockSetup(mock)
+
+			uow := NewUnitOfWork(db, nil)
+			uow.retryBackoff = 0
+
+			attempts := 0
+			err := uow.Do(context.Background(), func(tx *sql.Tx) error {
+				err := tt.results[attempts]
+				attempts++
+				return err
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantAttempts, attempts)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUnitOfWork_Do_StopsWhenContextIsDone(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	err := NewUnitOfWork(db, nil).Do(ctx, func(tx *sql.Tx) error {
+		attempts++
+		cancel()
+		return &pq.Error{Code: pqSerializationFailure}
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.True(t, IsSerializationFailure(err))
+	assert.Equal(t, 1, attempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
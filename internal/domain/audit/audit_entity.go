@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+)
+
+// Action is the kind of change an audit entry records
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Entity types recorded in the audit log
+const (
+	EntitySpecialty = "specialty"
+	EntityDoctor    = "doctor"
+)
+
+// Entry records who changed an entity and what it looked like before and after.
+// Before is empty for creations and After is empty for deletions.
+type Entry struct {
+	ID         uuid.UUID       `json:"id" db:"id"`
+	ActorID    uuid.UUID       `json:"actor_id" db:"actor_id"`
+	ActorRole  auth.Role       `json:"actor_role" db:"actor_role"`
+	Action     Action          `json:"action" db:"action"`
+	EntityType string          `json:"entity_type" db:"entity_type"`
+	EntityID   uuid.UUID       `json:"entity_id" db:"entity_id"`
+	Before     json.RawMessage `json:"before,omitempty" db:"before"`
+	After      json.RawMessage `json:"after,omitempty" db:"after"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain"
+)
+
+var ErrAdminNotFound = fmt.Errorf("admin %w", domain.ErrNotFound)
+
+// Admin is a staff account that manages the doctor and specialty catalog
+type Admin struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	PhoneNumber string    `json:"phone_number" db:"phone_number"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
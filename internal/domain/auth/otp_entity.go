@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain"
+)
+
+var (
+	ErrOTPNotFound = fmt.Errorf("login code %w", domain.ErrNotFound)
+	// ErrOTPInvalid is returned when the submitted code does not match, or no code was requested
+	ErrOTPInvalid = errors.New("login code is invalid")
+	ErrOTPExpired = errors.New("login code has expired")
+	// ErrOTPAttemptsExceeded is returned once a code has been guessed wrong too many times
+	ErrOTPAttemptsExceeded = errors.New("too many attempts for this login code")
+	// ErrOTPCooldown is returned when a new code is requested too soon after the previous one
+	ErrOTPCooldown = errors.New("login code was requested too recently")
+)
+
+// OTP is a one-time login code sent to a phone number. Only a keyed hash of the code is stored.
+type OTP struct {
+	PhoneNumber string    `db:"phone_number"`
+	CodeHash    string    `db:"code_hash"`
+	Attempts    int       `db:"attempts"`
+	ExpiresAt   time.Time `db:"expires_at"`
+	SentAt      time.Time `db:"sent_at"`
+}
+
+// IsExpired reports whether the code can no longer be used at now
+func (o OTP) IsExpired(now time.Time) bool {
+	return !now.Before(o.ExpiresAt)
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidToken is returned for malformed, forged, expired or wrongly typed tokens
+	ErrInvalidToken = errors.New("token is invalid or expired")
+	// ErrUnauthenticated is returned when a protected route is called without a token
+	ErrUnauthenticated = errors.New("authentication required")
+	// ErrForbidden is returned when the caller's role may not use a route
+	ErrForbidden = errors.New("insufficient permissions")
+)
+
+// Role is the kind of account a token was issued to
+type Role string
+
+const (
+	RolePatient Role = "patient"
+	RoleDoctor  Role = "doctor"
+	RoleAdmin   Role = "admin"
+)
+
+func (r Role) IsValid() bool {
+	switch r {
+	case RolePatient, RoleDoctor, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	ID   uuid.UUID
+	Role Role
+}
+
+// TokenType separates short-lived access tokens from refresh tokens, so one cannot be used as the other
+type TokenType string
+
+const (
+	TokenTypeAccess  TokenType = "access"
+	TokenTypeRefresh TokenType = "refresh"
+)
+
+// Claims are the JWT claims issued by the API
+type Claims struct {
+	Subject   string    `json:"sub"`
+	Role      Role      `json:"role"`
+	Type      TokenType `json:"typ"`
+	ID        string    `json:"jti"`
+	IssuedAt  int64     `json:"iat"`
+	ExpiresAt int64     `json:"exp"`
+}
+
+// TokenPair is returned to a client after a successful login or refresh
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	// ExpiresIn is the access token lifetime in seconds
+	ExpiresIn int64 `json:"expires_in"`
+}
@@ -3,3 +3,9 @@ package domain
 type ModelEntity interface {
 	GetId() string
 }
+
+// SortableEntity is implemented by entities that can be keyset-paginated by fields other than id
+type SortableEntity interface {
+	ModelEntity
+	GetSortValue(field string) (any, bool)
+}
@@ -0,0 +1,7 @@
+package domain
+
+import "errors"
+
+// ErrNotFound is wrapped by every entity specific not-found error so callers can
+// tell a missing row from a database failure without knowing the entity
+var ErrNotFound = errors.New("not found")
@@ -0,0 +1,116 @@
+package medical
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain"
+)
+
+var (
+	ErrAppointmentNotFound = fmt.Errorf("appointment %w", domain.ErrNotFound)
+	// ErrSlotTaken is returned when the requested time overlaps another active appointment of the doctor
+	ErrSlotTaken = errors.New("time slot is already taken")
+	// ErrSlotUnavailable is returned when the requested time is not one of the doctor's slots: it lies
+	// outside the weekly schedule, during the doctor's time off or on a public holiday
+	ErrSlotUnavailable = errors.New("time is not one of the doctor's slots")
+	// ErrInvalidTransition is returned when the appointment's current status does not allow the change,
+	// including when another request changed the status first
+	ErrInvalidTransition = errors.New("not allowed in the appointment's current status")
+	// ErrAppointmentNotCancellable is returned when cancelling an appointment that is no longer active
+	ErrAppointmentNotCancellable = fmt.Errorf("appointment cannot be cancelled: %w", ErrInvalidTransition)
+	// ErrAppointmentNotConfirmable is returned when confirming an appointment that is not pending or has started
+	ErrAppointmentNotConfirmable = fmt.Errorf("appointment cannot be confirmed: %w", ErrInvalidTransition)
+	// ErrAppointmentNotFinishable is returned when marking an appointment that has not started yet,
+	// or is no longer active, as completed or no-show
+	ErrAppointmentNotFinishable = fmt.Errorf("appointment cannot be marked as completed or no-show: %w", ErrInvalidTransition)
+	// ErrAppointmentNotReschedulable is returned when moving an appointment that is no longer active
+	ErrAppointmentNotReschedulable = fmt.Errorf("appointment cannot be rescheduled: %w", ErrInvalidTransition)
+	// ErrCancellationCutoffPassed is returned when an appointment is cancelled too close to its start
+	ErrCancellationCutoffPassed = errors.New("appointment starts too soon to be cancelled")
+	// ErrRescheduleCutoffPassed is returned when an appointment is rescheduled too close to its start
+	ErrRescheduleCutoffPassed = errors.New("appointment starts too soon to be rescheduled")
+)
+
+// AppointmentStatus represents the lifecycle state of an appointment
+type AppointmentStatus string
+
+const (
+	AppointmentStatusPending            AppointmentStatus = "pending"
+	AppointmentStatusConfirmed          AppointmentStatus = "confirmed"
+	AppointmentStatusCancelledByPatient AppointmentStatus = "cancelled_by_patient"
+	AppointmentStatusCancelledByDoctor  AppointmentStatus = "cancelled_by_doctor"
+	AppointmentStatusCompleted          AppointmentStatus = "completed"
+	// AppointmentStatusNoShow marks a past appointment the patient did not attend
+	AppointmentStatusNoShow AppointmentStatus = "no_show"
+)
+
+// appointmentTransitions lists the statuses each status may move to; statuses without an entry are final
+var appointmentTransitions = map[AppointmentStatus][]AppointmentStatus{
+	AppointmentStatusPending: {
+		AppointmentStatusConfirmed,
+		AppointmentStatusCancelledByPatient,
+		AppointmentStatusCancelledByDoctor,
+		AppointmentStatusCompleted,
+		AppointmentStatusNoShow,
+	},
+	AppointmentStatusConfirmed: {
+		AppointmentStatusCancelledByPatient,
+		AppointmentStatusCancelledByDoctor,
+		AppointmentStatusCompleted,
+		AppointmentStatusNoShow,
+	},
+}
+
+// IsValid reports whether the status is one of the known appointment statuses
+func (s AppointmentStatus) IsValid() bool {
+	switch s {
+	case AppointmentStatusPending, AppointmentStatusConfirmed, AppointmentStatusCancelledByPatient,
+		AppointmentStatusCancelledByDoctor, AppointmentStatusCompleted, AppointmentStatusNoShow:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsActive reports whether an appointment in this status still occupies its time slot
+func (s AppointmentStatus) IsActive() bool {
+	return s == AppointmentStatusPending || s == AppointmentStatusConfirmed
+}
+
+// CanTransitionTo reports whether an appointment in this status may be moved to next
+func (s AppointmentStatus) CanTransitionTo(next AppointmentStatus) bool {
+	return slices.Contains(appointmentTransitions[s], next)
+}
+
+// Appointment represents a patient's booked visit with a doctor
+type Appointment struct {
+	ID        uuid.UUID         `json:"id" db:"id"`
+	DoctorID  uuid.UUID         `json:"doctor_id" db:"doctor_id"`
+	PatientID uuid.UUID         `json:"patient_id" db:"patient_id"`
+	StartTime time.Time         `json:"start_time" db:"start_time"`
+	EndTime   time.Time         `json:"end_time" db:"end_time"`
+	Status    AppointmentStatus `json:"status" db:"status"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at" db:"updated_at"`
+	// TimeZone is the doctor's time zone and LocalStartTime and LocalEndTime the times in it;
+	// they are set by Localize and not stored
+	TimeZone       string    `json:"time_zone"`
+	LocalStartTime time.Time `json:"local_start_time"`
+	LocalEndTime   time.Time `json:"local_end_time"`
+}
+
+func (a Appointment) GetId() string {
+	return a.ID.String()
+}
+
+// Localize sets the local times of the appointment in loc, the doctor's time zone
+func (a *Appointment) Localize(loc *time.Location) {
+	a.TimeZone = loc.String()
+	a.LocalStartTime = a.StartTime.In(loc)
+	a.LocalEndTime = a.EndTime.In(loc)
+}
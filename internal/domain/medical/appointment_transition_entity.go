@@ -0,0 +1,36 @@
+package medical
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+)
+
+// AppointmentTransition is one entry in an appointment's history: its booking, a status change or
+// a reschedule. FromStatus is empty for the booking and equals ToStatus for a reschedule.
+// StartTime and EndTime are the appointment's time range after the change.
+type AppointmentTransition struct {
+	ID            uuid.UUID         `json:"id" db:"id"`
+	AppointmentID uuid.UUID         `json:"appointment_id" db:"appointment_id"`
+	FromStatus    AppointmentStatus `json:"from_status,omitempty" db:"from_status"`
+	ToStatus      AppointmentStatus `json:"to_status" db:"to_status"`
+	StartTime     time.Time         `json:"start_time" db:"start_time"`
+	EndTime       time.Time         `json:"end_time" db:"end_time"`
+	ActorID       uuid.UUID         `json:"actor_id" db:"actor_id"`
+	ActorRole     auth.Role         `json:"actor_role" db:"actor_role"`
+	CreatedAt     time.Time         `json:"created_at" db:"created_at"`
+	// TimeZone is the doctor's time zone and LocalStartTime and LocalEndTime the times in it;
+	// they are set by Localize and not stored
+	TimeZone       string    `json:"time_zone"`
+	LocalStartTime time.Time `json:"local_start_time"`
+	LocalEndTime   time.Time `json:"local_end_time"`
+}
+
+// Localize sets the local times of the transition in loc, the doctor's time zone
+func (t *AppointmentTransition) Localize(loc *time.Location) {
+	t.TimeZone = loc.String()
+	t.LocalStartTime = t.StartTime.In(loc)
+	t.LocalEndTime = t.EndTime.In(loc)
+}
@@ -1,23 +1,83 @@
 package medical
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain"
+)
+
+var (
+	ErrDoctorNotFound = fmt.Errorf("doctor %w", domain.ErrNotFound)
+	// ErrPhoneNumberTaken is returned when a doctor's phone number is already used by another doctor
+	ErrPhoneNumberTaken = errors.New("phone number is already in use")
+	// ErrDoctorHasAppointments is returned when a doctor with appointments on record is deleted
+	ErrDoctorHasAppointments = errors.New("doctor has appointments")
 )
 
+// DefaultTimeZone is the time zone of doctors created without one
+const DefaultTimeZone = "Asia/Tehran"
+
+// DoctorSortFields are the doctor fields clients may order and keyset-paginate by, besides id
+var DoctorSortFields = []string{"name", "created_at"}
+
 type Doctor struct {
 	ID          uuid.UUID `json:"id" db:"id"`
-	Name        string    `json:"name" db:"name"`
-	SpecialtyID uuid.UUID `json:"specialty_id" db:"specialty_id"`
-	PhoneNumber string    `json:"phone_number" db:"phone_number"`
-	AvatarURL   string    `json:"avatar_url" db:"avatar_url"`
-	Description string    `json:"description" db:"description"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	Name        string    `json:"name" db:"name" validate:"required,min=1,max=100"`
+	SpecialtyID uuid.UUID `json:"specialty_id" db:"specialty_id" validate:"required"`
+	PhoneNumber string    `json:"phone_number" db:"phone_number" validate:"required,e164"`
+	AvatarURL   string    `json:"avatar_url" db:"avatar_url" validate:"omitempty,url,max=2048"`
+	Description string    `json:"description" db:"description" validate:"max=2000"`
+	// TimeZone is the IANA time zone the doctor's weekly schedule is kept in
+	TimeZone  string    `json:"time_zone" db:"time_zone" validate:"required,timezone"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
 
 // GetId returns the ID as a string for pagination compatibility
 func (d Doctor) GetId() string {
 	return d.ID.String()
 }
+
+// Location loads the doctor's time zone; a doctor without one works in UTC
+func (d Doctor) Location() (*time.Location, error) {
+	loc, err := time.LoadLocation(d.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("load time zone of doctor %s: %w", d.ID, err)
+	}
+	return loc, nil
+}
+
+// Validate checks the doctor against its validate tags
+func (d Doctor) Validate() error {
+	return validate.Struct(d)
+}
+
+// GetSortValue returns the value of a sortable field for keyset pagination
+func (d Doctor) GetSortValue(field string) (any, bool) {
+	switch field {
+	case "name":
+		return d.Name, true
+	case "created_at":
+		return d.CreatedAt, true
+	default:
+		return nil, false
+	}
+}
+
+// DoctorProfileUpdate holds the profile fields a doctor may change; nil fields are left as they are
+type DoctorProfileUpdate struct {
+	Description *string
+	AvatarURL   *string
+	PhoneNumber *string
+	TimeZone    *string
+}
+
+// DoctorDetail is a doctor together with its specialty, as shown on the doctor profile page
+type DoctorDetail struct {
+	Doctor
+	Specialty Specialty `json:"specialty"`
+}
@@ -0,0 +1,20 @@
+package medical
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Holiday is a calendar day on which the whole clinic is closed
+type Holiday struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Date      time.Time `json:"date" db:"date"`
+	Name      string    `json:"name" db:"name" validate:"required,min=1,max=100"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (h Holiday) GetId() string {
+	return h.ID.String()
+}
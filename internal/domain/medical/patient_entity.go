@@ -0,0 +1,25 @@
+package medical
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain"
+)
+
+var ErrPatientNotFound = fmt.Errorf("patient %w", domain.ErrNotFound)
+
+// Patient is a user account identified by its mobile number
+type Patient struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	PhoneNumber string    `json:"phone_number" db:"phone_number"`
+	Name        *string   `json:"name" db:"name"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (p Patient) GetId() string {
+	return p.ID.String()
+}
@@ -0,0 +1,144 @@
+package medical
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClockTime is a wall-clock time of day stored as minutes since midnight
+type ClockTime int
+
+// ParseClockTime parses a "15:04" or "15:04:05" formatted time of day
+func ParseClockTime(value string) (ClockTime, error) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return ClockTime(t.Hour()*60 + t.Minute()), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time of day: %q", value)
+}
+
+func (c ClockTime) Hour() int {
+	return int(c) / 60
+}
+
+func (c ClockTime) Minute() int {
+	return int(c) % 60
+}
+
+func (c ClockTime) String() string {
+	return fmt.Sprintf("%02d:%02d", c.Hour(), c.Minute())
+}
+
+// On returns the instant clocks in loc show this time on the given day. A time skipped by a
+// daylight saving change moves forward by the length of the gap, and a time that occurs twice
+// is the first of its two instants; time.Date leaves both cases unspecified.
+func (c ClockTime) On(year int, month time.Month, day int, loc *time.Location) time.Time {
+	wall := time.Date(year, month, day, c.Hour(), c.Minute(), 0, 0, time.UTC)
+
+	// Offsets in effect a day either side; they differ only around a transition
+	_, before := wall.Add(-24 * time.Hour).In(loc).Zone()
+	_, after := wall.Add(24 * time.Hour).In(loc).Zone()
+	beforeInstant := wall.Add(-time.Duration(before) * time.Second)
+	afterInstant := wall.Add(-time.Duration(after) * time.Second)
+
+	first, second := beforeInstant, afterInstant
+	if second.Before(first) {
+		first, second = second, first
+	}
+	for _, instant := range []time.Time{first, second} {
+		if local := instant.In(loc); sameWallClock(local, wall) {
+			return local
+		}
+	}
+
+	// Skipped: reading the time with the offset before the gap lands as far past it as the time was into it
+	return beforeInstant.In(loc)
+}
+
+func sameWallClock(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd && a.Hour() == b.Hour() && a.Minute() == b.Minute()
+}
+
+func (c ClockTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *ClockTime) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	parsed, err := ParseClockTime(value)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// Scan implements sql.Scanner for PostgreSQL TIME columns
+func (c *ClockTime) Scan(src any) error {
+	switch v := src.(type) {
+	case time.Time:
+		*c = ClockTime(v.Hour()*60 + v.Minute())
+		return nil
+	case []byte:
+		return c.scanString(string(v))
+	case string:
+		return c.scanString(v)
+	default:
+		return fmt.Errorf("cannot scan %T into ClockTime", src)
+	}
+}
+
+func (c *ClockTime) scanString(value string) error {
+	parsed, err := ParseClockTime(value)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// Value implements driver.Valuer for PostgreSQL TIME columns
+func (c ClockTime) Value() (driver.Value, error) {
+	return c.String(), nil
+}
+
+// Schedule is a weekly working-hour template of a doctor
+type Schedule struct {
+	ID           uuid.UUID    `json:"id" db:"id"`
+	DoctorID     uuid.UUID    `json:"doctor_id" db:"doctor_id"`
+	DayOfWeek    time.Weekday `json:"day_of_week" db:"day_of_week"`
+	StartTime    ClockTime    `json:"start_time" db:"start_time"`
+	EndTime      ClockTime    `json:"end_time" db:"end_time"`
+	SlotDuration int          `json:"slot_duration_minutes" db:"slot_duration_minutes"`
+	CreatedAt    time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+func (s Schedule) GetId() string {
+	return s.ID.String()
+}
+
+// Slot is a concrete bookable time range computed from a doctor's schedule.
+// StartTime and EndTime are in UTC, the local times in the doctor's time zone.
+type Slot struct {
+	StartTime      time.Time `json:"start_time"`
+	EndTime        time.Time `json:"end_time"`
+	LocalStartTime time.Time `json:"local_start_time"`
+	LocalEndTime   time.Time `json:"local_end_time"`
+}
+
+// Availability is the free slots of a doctor and the time zone of their local times
+type Availability struct {
+	TimeZone string `json:"time_zone"`
+	Slots    []Slot `json:"slots"`
+}
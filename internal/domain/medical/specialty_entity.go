@@ -1,9 +1,21 @@
 package medical
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain"
+)
+
+var (
+	ErrSpecialtyNotFound = fmt.Errorf("specialty %w", domain.ErrNotFound)
+	// ErrSpecialtyNameTaken is returned when another specialty already has the name
+	ErrSpecialtyNameTaken = errors.New("specialty name is already in use")
+	// ErrSpecialtyInUse is returned when a specialty that doctors still belong to is deleted
+	ErrSpecialtyInUse = errors.New("specialty still has doctors")
 )
 
 // Specialty represents a medical specialty
@@ -17,3 +29,14 @@ type Specialty struct {
 func (s Specialty) GetId() string {
 	return s.ID.String()
 }
+
+// Validate checks the specialty against its validate tags
+func (s Specialty) Validate() error {
+	return validate.Struct(s)
+}
+
+// SpecialtySummary is a specialty listed in the catalog together with how many doctors practice it
+type SpecialtySummary struct {
+	Specialty
+	DoctorCount int `json:"doctor_count" db:"doctor_count"`
+}
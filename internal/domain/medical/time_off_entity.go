@@ -0,0 +1,27 @@
+package medical
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain"
+)
+
+var ErrTimeOffNotFound = fmt.Errorf("time off %w", domain.ErrNotFound)
+
+// TimeOff is a period in which a doctor does not accept appointments
+type TimeOff struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	DoctorID  uuid.UUID `json:"doctor_id" db:"doctor_id"`
+	StartTime time.Time `json:"start_time" db:"start_time"`
+	EndTime   time.Time `json:"end_time" db:"end_time"`
+	Reason    string    `json:"reason" db:"reason"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (t TimeOff) GetId() string {
+	return t.ID.String()
+}
@@ -0,0 +1,7 @@
+package medical
+
+import "github.com/shayesteh1hs/DrAppointment/internal/validation"
+
+// validate checks the `validate` tags of entities before they are written.
+// It caches struct metadata and is safe for concurrent use.
+var validate = validation.New()
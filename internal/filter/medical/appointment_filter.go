@@ -0,0 +1,101 @@
+package medical
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/calendar"
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/scheduling"
+)
+
+type AppointmentQueryParam struct {
+	PatientID string `form:"patient_id" binding:"omitempty,uuid"`
+	DoctorID  string `form:"doctor_id" binding:"omitempty,uuid"`
+	Status    string `form:"status"`
+	// From and To are inclusive dates the appointment starts on, as observed in Location
+	From string `form:"from"`
+	To   string `form:"to"`
+	// Location is the time zone dates are read in, set by the service; dates are read in UTC when it is nil
+	Location *time.Location `form:"-"`
+}
+
+func (f AppointmentQueryParam) Validate() error {
+	if f.Status != "" && !domain.AppointmentStatus(f.Status).IsValid() {
+		return fmt.Errorf("invalid appointment status: %s", f.Status)
+	}
+	from, err := parseAppointmentDate(f.From)
+	if err != nil {
+		return fmt.Errorf("from must be a date in %s format", time.DateOnly)
+	}
+	to, err := parseAppointmentDate(f.To)
+	if err != nil {
+		return fmt.Errorf("to must be a date in %s format", time.DateOnly)
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return errors.New("to must not be before from")
+	}
+	return nil
+}
+
+// Chronological reports whether results should be listed oldest first. A window with
+// a start date reads forward, e.g. upcoming appointments; otherwise the newest come first.
+func (f AppointmentQueryParam) Chronological() bool {
+	return f.From != ""
+}
+
+func (f AppointmentQueryParam) Apply(sb *sqlbuilder.SelectBuilder) *sqlbuilder.SelectBuilder {
+	if f.PatientID != "" {
+		sb.Where(sb.Equal("patient_id", f.PatientID))
+	}
+	if f.DoctorID != "" {
+		sb.Where(sb.Equal("doctor_id", f.DoctorID))
+	}
+	if f.Status != "" {
+		sb.Where(sb.Equal("status", f.Status))
+	}
+	// Invalid dates are rejected by Validate and ignored here
+	if from, err := parseAppointmentDate(f.From); err == nil && from != nil {
+		sb.Where(sb.GreaterEqualThan("start_time", from.In(f.location())))
+	}
+	if to, err := parseAppointmentDate(f.To); err == nil && to != nil {
+		sb.Where(sb.LessThan("start_time", to.AddDays(1).In(f.location())))
+	}
+	return sb
+}
+
+func (f AppointmentQueryParam) location() *time.Location {
+	if f.Location == nil {
+		return time.UTC
+	}
+	return f.Location
+}
+
+// parseAppointmentDate reads a from or to filter; an empty one is nil
+func parseAppointmentDate(value string) (*scheduling.Date, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := scheduling.ParseDate(value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+// ToGregorian rewrites From and To, written in system, as the Gregorian dates the filter works with
+func (f *AppointmentQueryParam) ToGregorian(system calendar.System) error {
+	from, err := system.GregorianDate(f.From)
+	if err != nil {
+		return fmt.Errorf("from: %w", err)
+	}
+	to, err := system.GregorianDate(f.To)
+	if err != nil {
+		return fmt.Errorf("to: %w", err)
+	}
+	f.From, f.To = from, to
+	return nil
+}
@@ -0,0 +1,136 @@
+package medical
+
+import (
+	"testing"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/calendar"
+)
+
+func TestAppointmentQueryParam_Apply(t *testing.T) {
+	tehran, err := time.LoadLocation("Asia/Tehran")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		filter   AppointmentQueryParam
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:    "empty filter adds no conditions",
+			filter:  AppointmentQueryParam{},
+			wantSQL: "SELECT * FROM appointments",
+		},
+		{
+			name:     "dates cover whole days",
+			filter:   AppointmentQueryParam{From: "2025-03-03", To: "2025-03-05"},
+			wantSQL:  "SELECT * FROM appointments WHERE start_time >= $1 AND start_time < $2",
+			wantArgs: []interface{}{time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "dates are days in the doctor's time zone",
+			filter:   AppointmentQueryParam{From: "2025-03-03", To: "2025-03-05", Location: tehran},
+			wantSQL:  "SELECT * FROM appointments WHERE start_time >= $1 AND start_time < $2",
+			wantArgs: []interface{}{time.Date(2025, 3, 3, 0, 0, 0, 0, tehran), time.Date(2025, 3, 6, 0, 0, 0, 0, tehran)},
+		},
+		{
+			name:     "a Tehran day starts the UTC evening before",
+			filter:   AppointmentQueryParam{From: "2025-03-10", To: "2025-03-10", Location: tehran},
+			wantSQL:  "SELECT * FROM appointments WHERE start_time >= $1 AND start_time < $2",
+			wantArgs: []interface{}{time.Date(2025, 3, 9, 20, 30, 0, 0, time.UTC).In(tehran), time.Date(2025, 3, 10, 20, 30, 0, 0, time.UTC).In(tehran)},
+		},
+		{
+			name:     "doctor and status",
+			filter:   AppointmentQueryParam{DoctorID: "d", Status: "no_show"},
+			wantSQL:  "SELECT * FROM appointments WHERE doctor_id = $1 AND status = $2",
+			wantArgs: []interface{}{"d", "no_show"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+			sb.Select("*").From("appointments")
+
+			sql, args := tt.filter.Apply(sb).Build()
+			assert.Equal(t, tt.wantSQL, sql)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestAppointmentQueryParam_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  AppointmentQueryParam
+		wantErr string
+	}{
+		{name: "valid window", filter: AppointmentQueryParam{From: "2025-03-03", To: "2025-03-03"}},
+		{name: "unknown status", filter: AppointmentQueryParam{Status: "lost"}, wantErr: "invalid appointment status: lost"},
+		{name: "malformed date", filter: AppointmentQueryParam{From: "03/03/2025"}, wantErr: "from must be a date in 2006-01-02 format"},
+		{name: "reversed window", filter: AppointmentQueryParam{From: "2025-03-05", To: "2025-03-03"}, wantErr: "to must not be before from"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAppointmentQueryParam_ToGregorian(t *testing.T) {
+	tests := []struct {
+		name    string
+		system  calendar.System
+		filter  AppointmentQueryParam
+		want    AppointmentQueryParam
+		wantErr string
+	}{
+		{
+			name:   "gregorian dates are kept",
+			system: calendar.Gregorian,
+			filter: AppointmentQueryParam{From: "2025-03-20", To: "2025-03-21"},
+			want:   AppointmentQueryParam{From: "2025-03-20", To: "2025-03-21"},
+		},
+		{
+			name:   "jalali window across Nowruz",
+			system: calendar.Jalali,
+			filter: AppointmentQueryParam{Status: "pending", From: "1403-12-30", To: "1404-01-01"},
+			want:   AppointmentQueryParam{Status: "pending", From: "2025-03-20", To: "2025-03-21"},
+		},
+		{
+			name:   "open ended jalali window",
+			system: calendar.Jalali,
+			filter: AppointmentQueryParam{From: "1402-12-29"},
+			want:   AppointmentQueryParam{From: "2024-03-19"},
+		},
+		{
+			name:    "leap day of a common year",
+			system:  calendar.Jalali,
+			filter:  AppointmentQueryParam{To: "1402-12-30"},
+			wantErr: "to: invalid jalali date: day 30 of 1402-12",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.ToGregorian(tt.system)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, tt.filter)
+		})
+	}
+}
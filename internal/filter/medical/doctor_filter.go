@@ -1,27 +1,59 @@
 package medical
 
 import (
+	"fmt"
 	"strings"
 
-	"github.com/google/uuid"
 	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/filter"
 )
 
+// likeEscaper escapes LIKE wildcards so user input only ever matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type DoctorQueryParam struct {
-	Name        string    `form:"name"`
-	SpecialtyID uuid.UUID `form:"specialty_id"`
+	Name string `form:"name"`
+	// SpecialtyID is bound as a string since gin cannot bind query values into uuid.UUID
+	SpecialtyID string `form:"specialty_id" binding:"omitempty,uuid"`
+	// Search is a fuzzy, typo tolerant match on the doctor name, ranked by similarity
+	Search string `form:"search"`
 }
 
 func (f DoctorQueryParam) Validate() error {
 	return nil
 }
+
 func (f DoctorQueryParam) Apply(sb *sqlbuilder.SelectBuilder) *sqlbuilder.SelectBuilder {
-	trimmedName := strings.TrimSpace(f.Name)
-	if trimmedName != "" {
-		sb.Where(sb.Like("name", "%"+trimmedName+"%"))
+	name := filter.NormalizeSearchText(f.Name)
+	if name != "" {
+		sb.Where(sb.Like("name_search", "%"+likeEscaper.Replace(name)+"%"))
+	}
+	if f.SpecialtyID != "" {
+		sb.Where(sb.Equal("specialty_id", f.SpecialtyID))
+	}
+	search := filter.NormalizeSearchText(f.Search)
+	if search != "" {
+		// <% is pg_trgm's word similarity operator; both branches are served by the trigram index
+		sb.Where(sb.Or(
+			sb.Like("name_search", "%"+likeEscaper.Replace(search)+"%"),
+			fmt.Sprintf("%s <%% name_search", sb.Var(search)),
+		))
 	}
-	if f.SpecialtyID != uuid.Nil {
-		sb.Where(sb.Equal("specialty_id", f.SpecialtyID.String()))
+	return sb
+}
+
+// Rank orders search results by similarity to the search text, best match first.
+// It is kept out of Apply because the same filters are used for counting.
+func (f DoctorQueryParam) Rank(sb *sqlbuilder.SelectBuilder) *sqlbuilder.SelectBuilder {
+	search := filter.NormalizeSearchText(f.Search)
+	if search != "" {
+		sb.OrderByDesc(fmt.Sprintf("word_similarity(%s, name_search)", sb.Var(search)))
 	}
 	return sb
 }
+
+// IsSearch reports whether results are ranked by relevance rather than a stable sort key
+func (f DoctorQueryParam) IsSearch() bool {
+	return filter.NormalizeSearchText(f.Search) != ""
+}
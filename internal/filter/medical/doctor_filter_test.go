@@ -12,7 +12,7 @@ import (
 
 // Helper functions
 
-func newTestFilter(name string, specialtyID uuid.UUID) DoctorQueryParam {
+func newTestFilter(name string, specialtyID string) DoctorQueryParam {
 	return DoctorQueryParam{
 		Name:        name,
 		SpecialtyID: specialtyID,
@@ -81,15 +81,15 @@ func TestDoctorQueryParam_Apply(t *testing.T) {
 		},
 		{
 			name:            "name filter only adds LIKE condition",
-			filter:          newTestFilter("Smith", uuid.Nil),
+			filter:          newTestFilter("Smith", ""),
 			expectWhere:     true,
 			expectName:      true,
 			expectSpecialty: false,
-			nameValue:       "Smith",
+			nameValue:       "smith",
 		},
 		{
 			name:            "specialty filter only adds equality condition",
-			filter:          newTestFilter("", testSpecialtyID),
+			filter:          newTestFilter("", testSpecialtyID.String()),
 			expectWhere:     true,
 			expectName:      false,
 			expectSpecialty: true,
@@ -97,23 +97,23 @@ func TestDoctorQueryParam_Apply(t *testing.T) {
 		},
 		{
 			name:            "both filters add AND condition",
-			filter:          newTestFilter("John", testSpecialtyID),
+			filter:          newTestFilter("John", testSpecialtyID.String()),
 			expectWhere:     true,
 			expectName:      true,
 			expectSpecialty: true,
-			nameValue:       "John",
+			nameValue:       "john",
 			specialtyValue:  testSpecialtyID.String(),
 		},
 		{
 			name:            "whitespace name is treated as empty",
-			filter:          newTestFilter("   ", uuid.Nil),
+			filter:          newTestFilter("   ", ""),
 			expectWhere:     false,
 			expectName:      false,
 			expectSpecialty: false,
 		},
 		{
-			name:            "nil UUID is treated as empty",
-			filter:          newTestFilter("", uuid.Nil),
+			name:            "empty specialty id is treated as empty",
+			filter:          newTestFilter("", ""),
 			expectWhere:     false,
 			expectName:      false,
 			expectSpecialty: false,
@@ -138,11 +138,11 @@ func TestDoctorQueryParam_Apply(t *testing.T) {
 
 			// Verify name LIKE condition
 			if tt.expectName {
-				assertSQLContains(t, sql, "name LIKE")
+				assertSQLContains(t, sql, "name_search LIKE")
 				assert.True(t, assertParameterExists(t, args, tt.nameValue, "%"),
 					"Expected name parameter %%%s%% not found in args: %v", tt.nameValue, args)
 			} else {
-				assertSQLNotContains(t, sql, "name LIKE")
+				assertSQLNotContains(t, sql, "name_search LIKE")
 			}
 
 			// Verify specialty_id equality condition
@@ -172,3 +172,62 @@ func TestDoctorQueryParam_Apply(t *testing.T) {
 		})
 	}
 }
+
+func TestDoctorQueryParam_Apply_Search(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    DoctorQueryParam
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "search matches by substring or word similarity",
+			filter:    DoctorQueryParam{Search: "  Smith "},
+			wantWhere: "WHERE (name_search LIKE $1 OR $2 <% name_search)",
+			wantArgs:  []interface{}{"%smith%", "smith"},
+		},
+		{
+			name:      "search text is normalized",
+			filter:    DoctorQueryParam{Search: "علي"},
+			wantWhere: "WHERE (name_search LIKE $1 OR $2 <% name_search)",
+			wantArgs:  []interface{}{"%علی%", "علی"},
+		},
+		{
+			name:      "LIKE wildcards are matched literally",
+			filter:    DoctorQueryParam{Name: "100%_dr"},
+			wantWhere: "WHERE name_search LIKE $1",
+			wantArgs:  []interface{}{`%100\%\_dr%`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := tt.filter.Apply(newTestSelectBuilder())
+
+			sql, args := sb.Build()
+			assert.Contains(t, sql, tt.wantWhere)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestDoctorQueryParam_Rank(t *testing.T) {
+	t.Run("search orders by similarity", func(t *testing.T) {
+		f := DoctorQueryParam{Search: "Smith"}
+		sb := f.Rank(f.Apply(newTestSelectBuilder()))
+
+		sql, args := sb.Build()
+		assert.Contains(t, sql, "ORDER BY word_similarity($3, name_search) DESC")
+		assert.Equal(t, []interface{}{"%smith%", "smith", "smith"}, args)
+		assert.True(t, f.IsSearch())
+	})
+
+	t.Run("no search leaves order untouched", func(t *testing.T) {
+		f := DoctorQueryParam{Name: "Smith", Search: "   "}
+		sb := f.Rank(f.Apply(newTestSelectBuilder()))
+
+		sql, _ := sb.Build()
+		assertSQLNotContains(t, sql, "ORDER BY")
+		assert.False(t, f.IsSearch())
+	})
+}
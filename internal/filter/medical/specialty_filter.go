@@ -0,0 +1,28 @@
+package medical
+
+import (
+	"strings"
+
+	"github.com/huandu/go-sqlbuilder"
+)
+
+type SpecialtyQueryParam struct {
+	Name string `form:"name" binding:"omitempty,max=100"`
+	// HasDoctors hides specialties nobody practices, e.g. for the doctor search dropdown
+	HasDoctors bool `form:"has_doctors"`
+}
+
+func (f SpecialtyQueryParam) Validate() error {
+	return nil
+}
+
+func (f SpecialtyQueryParam) Apply(sb *sqlbuilder.SelectBuilder) *sqlbuilder.SelectBuilder {
+	name := likeEscaper.Replace(strings.TrimSpace(f.Name))
+	if name != "" {
+		sb.Where(sb.ILike("specialties.name", "%"+name+"%"))
+	}
+	if f.HasDoctors {
+		sb.Where("EXISTS (SELECT 1 FROM doctors WHERE doctors.specialty_id = specialties.id)")
+	}
+	return sb
+}
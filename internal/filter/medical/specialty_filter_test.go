@@ -0,0 +1,51 @@
+package medical
+
+import (
+	"testing"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecialtyQueryParam_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   SpecialtyQueryParam
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:    "empty filter adds no conditions",
+			filter:  SpecialtyQueryParam{},
+			wantSQL: "SELECT * FROM specialties",
+		},
+		{
+			name:     "name is matched case-insensitively",
+			filter:   SpecialtyQueryParam{Name: " Cardio "},
+			wantSQL:  "SELECT * FROM specialties WHERE specialties.name ILIKE $1",
+			wantArgs: []interface{}{"%Cardio%"},
+		},
+		{
+			name:    "has doctors keeps only practiced specialties",
+			filter:  SpecialtyQueryParam{HasDoctors: true},
+			wantSQL: "SELECT * FROM specialties WHERE EXISTS (SELECT 1 FROM doctors WHERE doctors.specialty_id = specialties.id)",
+		},
+		{
+			name:     "LIKE wildcards are matched literally",
+			filter:   SpecialtyQueryParam{Name: "50%"},
+			wantSQL:  "SELECT * FROM specialties WHERE specialties.name ILIKE $1",
+			wantArgs: []interface{}{`%50\%%`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+			sb.Select("*").From("specialties")
+
+			sql, args := tt.filter.Apply(sb).Build()
+			assert.Equal(t, tt.wantSQL, sql)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
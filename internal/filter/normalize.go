@@ -0,0 +1,42 @@
+package filter
+
+import (
+	"strings"
+	"unicode"
+)
+
+// searchReplacer folds Arabic letter variants to their Persian forms and ZWNJ to a space
+var searchReplacer = strings.NewReplacer(
+	"\u064A", "\u06CC", // Arabic yeh
+	"\u0649", "\u06CC", // alef maksura
+	"\u0626", "\u06CC", // yeh with hamza
+	"\u0643", "\u06A9", // Arabic kaf
+	"\u0629", "\u0647", // teh marbuta
+	"\u06C0", "\u0647", // heh with yeh
+	"\u0623", "\u0627", // alef with hamza above
+	"\u0625", "\u0627", // alef with hamza below
+	"\u0622", "\u0627", // alef with madda
+	"\u0671", "\u0627", // alef wasla
+	"\u0624", "\u0648", // waw with hamza
+	"\u200C", " ", // zero-width non-joiner
+	"\u200D", "", // zero-width joiner
+)
+
+// NormalizeSearchText mirrors the normalize_search_text SQL function used to fill doctors.name_search,
+// so user input and stored names compare equal regardless of keyboard layout
+func NormalizeSearchText(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= '\u064B' && r <= '\u065F', r == '\u0670', r == '\u0640':
+			// Diacritics and tatweel carry no meaning for matching
+			return -1
+		case r >= '\u06F0' && r <= '\u06F9':
+			return '0' + r - '\u06F0'
+		case r >= '\u0660' && r <= '\u0669':
+			return '0' + r - '\u0660'
+		default:
+			return unicode.ToLower(r)
+		}
+	}, s)
+	return strings.Join(strings.Fields(searchReplacer.Replace(s)), " ")
+}
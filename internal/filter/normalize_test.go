@@ -0,0 +1,34 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSearchText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "empty", input: "", want: ""},
+		{name: "latin is lower cased", input: "Dr. John SMITH", want: "dr. john smith"},
+		{name: "whitespace is collapsed and trimmed", input: "  John \t  Smith ", want: "john smith"},
+		{name: "Arabic yeh and kaf become Persian", input: "\u0639\u0644\u064A \u0643\u0631\u064A\u0645\u064A", want: "\u0639\u0644\u06CC \u06A9\u0631\u06CC\u0645\u06CC"},
+		{name: "Persian input is unchanged", input: "\u0639\u0644\u06CC \u06A9\u0631\u06CC\u0645\u06CC", want: "\u0639\u0644\u06CC \u06A9\u0631\u06CC\u0645\u06CC"},
+		{name: "alef maksura and yeh with hamza become yeh", input: "\u0645\u0648\u0633\u0649 \u0631\u0626\u06CC\u0633", want: "\u0645\u0648\u0633\u06CC \u0631\u06CC\u06CC\u0633"},
+		{name: "teh marbuta becomes heh", input: "\u0641\u0627\u0637\u0645\u0629", want: "\u0641\u0627\u0637\u0645\u0647"},
+		{name: "hamza alefs are folded", input: "\u0623\u062D\u0645\u062F \u0622\u0631\u06CC\u0627 \u0625\u0628\u0631\u0627\u0647\u06CC\u0645", want: "\u0627\u062D\u0645\u062F \u0627\u0631\u06CC\u0627 \u0627\u0628\u0631\u0627\u0647\u06CC\u0645"},
+		{name: "ZWNJ becomes a space", input: "\u0645\u062D\u0645\u062F\u200C\u0631\u0636\u0627", want: "\u0645\u062D\u0645\u062F \u0631\u0636\u0627"},
+		{name: "ZWNJ next to a space does not leave a double space", input: "\u0645\u062D\u0645\u062F\u200C \u0631\u0636\u0627", want: "\u0645\u062D\u0645\u062F \u0631\u0636\u0627"},
+		{name: "diacritics and tatweel are dropped", input: "\u0645\u064F\u062D\u064E\u0645\u0651\u0640\u062F", want: "\u0645\u062D\u0645\u062F"},
+		{name: "Persian and Arabic digits become ASCII", input: "\u06F1\u06F2\u06F3 \u0664\u0665\u0666", want: "123 456"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeSearchText(tt.input))
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// TieBreaker is always accepted as a sort field and appended last so row order is total
+const TieBreaker = "id"
+
+// OrderField is a single sort key; a '-' prefix in the DSL marks it descending
+type OrderField struct {
+	Name string
+	Desc bool
+}
+
+// Ordering is a validated sort spec parsed from a list such as "name,-created_at"
+type Ordering []OrderField
+
+// ParseOrdering parses a comma-separated ordering, accepting only the allowed fields and id
+func ParseOrdering(raw string, allowed []string) (Ordering, error) {
+	var ordering Ordering
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field := OrderField{Name: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if field.Name != TieBreaker && !slices.Contains(allowed, field.Name) {
+			return nil, fmt.Errorf("ordering by '%s' is not supported", field.Name)
+		}
+		if ordering.Contains(field.Name) {
+			return nil, fmt.Errorf("ordering by '%s' is given more than once", field.Name)
+		}
+		ordering = append(ordering, field)
+	}
+	return ordering, nil
+}
+
+func (o Ordering) Contains(name string) bool {
+	return slices.ContainsFunc(o, func(f OrderField) bool { return f.Name == name })
+}
+
+// Apply adds the ORDER BY clause, appending id as a tie-breaker unless it is already sorted on
+func (o Ordering) Apply(sb *sqlbuilder.SelectBuilder) *sqlbuilder.SelectBuilder {
+	for _, field := range o {
+		if field.Desc {
+			sb.OrderByDesc(field.Name)
+		} else {
+			sb.OrderByAsc(field.Name)
+		}
+	}
+	if !o.Contains(TieBreaker) {
+		sb.OrderByAsc(TieBreaker)
+	}
+	return sb
+}
@@ -0,0 +1,81 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOrdering(t *testing.T) {
+	allowed := []string{"name", "created_at"}
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    Ordering
+		wantErr string
+	}{
+		{name: "empty", raw: "", want: nil},
+		{name: "single ascending", raw: "name", want: Ordering{{Name: "name"}}},
+		{name: "single descending", raw: "-created_at", want: Ordering{{Name: "created_at", Desc: true}}},
+		{
+			name: "multiple fields",
+			raw:  "name,-created_at",
+			want: Ordering{{Name: "name"}, {Name: "created_at", Desc: true}},
+		},
+		{name: "surrounding whitespace", raw: " name , -id ", want: Ordering{{Name: "name"}, {Name: "id", Desc: true}}},
+		{name: "empty items are skipped", raw: "name,,", want: Ordering{{Name: "name"}}},
+		{name: "id is always allowed", raw: "-id", want: Ordering{{Name: "id", Desc: true}}},
+		{name: "unknown field", raw: "name,phone_number", wantErr: "ordering by 'phone_number' is not supported"},
+		{name: "sql injection", raw: "name desc; drop table doctors", wantErr: "is not supported"},
+		{name: "duplicate field", raw: "name,-name", wantErr: "ordering by 'name' is given more than once"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOrdering(tt.raw, allowed)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOrdering_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		ordering Ordering
+		want     string
+	}{
+		{name: "empty ordering sorts by id", ordering: nil, want: "ORDER BY id ASC"},
+		{
+			name:     "id is appended as tie-breaker",
+			ordering: Ordering{{Name: "name"}, {Name: "created_at", Desc: true}},
+			want:     "ORDER BY name ASC, created_at DESC, id ASC",
+		},
+		{
+			name:     "explicit id is not repeated",
+			ordering: Ordering{{Name: "id", Desc: true}, {Name: "name"}},
+			want:     "ORDER BY id DESC, name ASC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+			sb.Select("*").From("doctors")
+
+			tt.ordering.Apply(sb)
+
+			sql, _ := sb.Build()
+			assert.Equal(t, "SELECT * FROM doctors "+tt.want, sql)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+)
+
+const principalKey = "principal"
+
+// TokenParser verifies a token and returns its claims; auth.TokenIssuer implements it
+type TokenParser interface {
+	Parse(token string, tokenType auth.TokenType) (*auth.Claims, error)
+}
+
+// Authenticate requires a valid access token in the Authorization header and
+// stores the caller as the request principal. It must run before RequireRole.
+func Authenticate(tokens TokenParser) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			abortUnauthorized(c, auth.ErrUnauthenticated)
+			return
+		}
+
+		claims, err := tokens.Parse(token, auth.TokenTypeAccess)
+		if err != nil {
+			abortUnauthorized(c, err)
+			return
+		}
+		id, err := uuid.Parse(claims.Subject)
+		if err != nil || !claims.Role.IsValid() {
+			abortUnauthorized(c, auth.ErrInvalidToken)
+			return
+		}
+
+		c.Set(principalKey, auth.Principal{ID: id, Role: claims.Role})
+		c.Next()
+	}
+}
+
+// RequireRole lets the request through only when the principal has one of roles
+func RequireRole(roles ...auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFrom(c)
+		if !ok {
+			abortUnauthorized(c, auth.ErrUnauthenticated)
+			return
+		}
+		if !slices.Contains(roles, principal.Role) {
+			_ = c.Error(auth.ErrForbidden)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// PrincipalFrom returns the caller stored by Authenticate
+func PrincipalFrom(c *gin.Context) (auth.Principal, bool) {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return auth.Principal{}, false
+	}
+	principal, ok := value.(auth.Principal)
+	return principal, ok
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func abortUnauthorized(c *gin.Context, err error) {
+	c.Header("WWW-Authenticate", `Bearer realm="api"`)
+	_ = c.Error(err)
+	c.Abort()
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authService "github.com/shayesteh1hs/DrAppointment/internal/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/validation"
+)
+
+func TestAuthenticate(t *testing.T) {
+	tokens := authService.NewTokenIssuer([]byte("0123456789abcdef0123456789abcdef"), 15*time.Minute, time.Hour)
+	otherTokens := authService.NewTokenIssuer([]byte("fedcba9876543210fedcba9876543210"), 15*time.Minute, time.Hour)
+
+	patientID := uuid.New()
+	patient, err := tokens.Issue(patientID, auth.RolePatient)
+	require.NoError(t, err)
+	doctor, err := tokens.Issue(uuid.New(), auth.RoleDoctor)
+	require.NoError(t, err)
+	forged, err := otherTokens.Issue(patientID, auth.RolePatient)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler(validation.NewTranslator()))
+	router.GET("/patient", Authenticate(tokens), RequireRole(auth.RolePatient), func(c *gin.Context) {
+		principal, ok := PrincipalFrom(c)
+		require.True(t, ok)
+		c.JSON(http.StatusOK, gin.H{"id": principal.ID, "role": principal.Role})
+	})
+
+	tests := []struct {
+		name               string
+		authorization      string
+		expectedStatusCode int
+		expectedCode       string
+	}{
+		{
+			name:               "patient token",
+			authorization:      "Bearer " + patient.AccessToken,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "scheme is case insensitive",
+			authorization:      "bearer " + patient.AccessToken,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "missing header",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedCode:       "authentication_required",
+		},
+		{
+			name:               "basic auth",
+			authorization:      "Basic dXNlcjpwYXNz",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedCode:       "authentication_required",
+		},
+		{
+			name:               "refresh token",
+			authorization:      "Bearer " + patient.RefreshToken,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedCode:       "invalid_token",
+		},
+		{
+			name:               "signed with another key",
+			authorization:      "Bearer " + forged.AccessToken,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedCode:       "invalid_token",
+		},
+		{
+			name:               "wrong role",
+			authorization:      "Bearer " + doctor.AccessToken,
+			expectedStatusCode: http.StatusForbidden,
+			expectedCode:       "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/patient", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code, w.Body.String())
+			if tt.expectedStatusCode == http.StatusOK {
+				assert.JSONEq(t, `{"id":"`+patientID.String()+`","role":"patient"}`, w.Body.String())
+				return
+			}
+			var response Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.Code)
+			if tt.expectedStatusCode == http.StatusUnauthorized {
+				assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestRequireRole_WithoutAuthenticate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler(validation.NewTranslator()))
+	router.GET("/admin", RequireRole(auth.RoleAdmin), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
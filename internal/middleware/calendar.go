@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
+	"github.com/shayesteh1hs/DrAppointment/internal/calendar"
+)
+
+const calendarKey = "calendar"
+
+// CalendarHeader selects the calendar when the calendar query parameter is absent
+const CalendarHeader = "X-Calendar"
+
+// Calendar stores the calendar the client exchanges dates in, chosen with ?calendar=jalali
+// or the X-Calendar header. Gregorian is the default.
+func Calendar() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name, ok := c.GetQuery("calendar")
+		if !ok {
+			name = c.GetHeader(CalendarHeader)
+		}
+
+		system, err := calendar.ParseSystem(name)
+		if err != nil {
+			_ = c.Error(apperror.Invalid(err.Error(), err))
+			c.Abort()
+			return
+		}
+
+		c.Set(calendarKey, system)
+		c.Next()
+	}
+}
+
+// CalendarFrom returns the calendar stored by Calendar, or Gregorian when it did not run
+func CalendarFrom(c *gin.Context) calendar.System {
+	if value, ok := c.Get(calendarKey); ok {
+		if system, ok := value.(calendar.System); ok {
+			return system
+		}
+	}
+	return calendar.Gregorian
+}
+
+// JSON renders v like c.JSON; a calendar.Presenter is presented in the calendar of the request first
+func JSON(c *gin.Context, status int, v any) {
+	if presenter, ok := v.(calendar.Presenter); ok {
+		v = presenter.Present(CalendarFrom(c))
+	}
+	c.JSON(status, v)
+}
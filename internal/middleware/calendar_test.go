@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/calendar"
+	"github.com/shayesteh1hs/DrAppointment/internal/validation"
+)
+
+// presentedDoctor is a calendar.Presenter with a Jalali companion to its created_at
+type presentedDoctor struct {
+	Name            string    `json:"name"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedAtJalali string    `json:"created_at_jalali,omitempty"`
+}
+
+func (d presentedDoctor) Present(system calendar.System) any {
+	if system == calendar.Jalali {
+		d.CreatedAtJalali = system.FormatTime(d.CreatedAt)
+	}
+	return d
+}
+
+func TestCalendar(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	createdAt := time.Date(2025, time.March, 20, 8, 0, 0, 0, time.UTC)
+	doctor := presentedDoctor{Name: "Dr. Karimi", CreatedAt: createdAt}
+
+	tests := []struct {
+		name               string
+		query              string
+		header             string
+		expectedStatusCode int
+		expectedJalali     string
+	}{
+		{
+			name:               "gregorian by default",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "jalali from query",
+			query:              "?calendar=jalali",
+			expectedStatusCode: http.StatusOK,
+			expectedJalali:     "1403-12-30T08:00:00Z",
+		},
+		{
+			name:               "jalali from header",
+			header:             "jalali",
+			expectedStatusCode: http.StatusOK,
+			expectedJalali:     "1403-12-30T08:00:00Z",
+		},
+		{
+			name:               "query wins over header",
+			query:              "?calendar=gregorian",
+			header:             "jalali",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "unknown calendar",
+			query:              "?calendar=julian",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(ErrorHandler(validation.NewTranslator()), Calendar())
+			router.GET("/doctors", func(c *gin.Context) {
+				JSON(c, http.StatusOK, doctor)
+			})
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/doctors"+tt.query, nil)
+			require.NoError(t, err)
+			if tt.header != "" {
+				req.Header.Set(CalendarHeader, tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var response map[string]string
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "2025-03-20T08:00:00Z", response["created_at"])
+			assert.Equal(t, tt.expectedJalali, response["created_at_jalali"])
+			assert.Equal(t, "Dr. Karimi", response["name"])
+		})
+	}
+}
@@ -2,78 +2,279 @@ package middleware
 
 import (
 	"errors"
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/validation"
 )
 
-type ValidationError struct {
-	Field   string `json:"field"`
-	Message string `json:"message"`
+// ProblemContentType is the media type of error responses (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// Problem is the body of every error response, an RFC 7807 problem details object.
+// Code is an extension member that identifies the error for programs.
+type Problem struct {
+	Type     string                `json:"type"`
+	Title    string                `json:"title"`
+	Status   int                   `json:"status"`
+	Detail   string                `json:"detail,omitempty"`
+	Instance string                `json:"instance,omitempty"`
+	Code     string                `json:"code"`
+	Errors   []apperror.FieldError `json:"errors,omitempty"`
+}
+
+// domainError maps a domain sentinel error to its HTTP representation.
+// Codes are part of the API contract and must not change once published.
+type domainError struct {
+	err     error
+	status  int
+	code    string
+	message string
 }
 
-type ErrorResponse struct {
-	Status  int               `json:"status"`
-	Message string            `json:"message"`
-	Errors  []ValidationError `json:"errors,omitempty"`
+var domainErrors = []domainError{
+	// Entity specific not-found errors share the code of domain.ErrNotFound, which they wrap
+	{
+		err:     medical.ErrDoctorNotFound,
+		status:  http.StatusNotFound,
+		code:    "not_found",
+		message: "Doctor not found",
+	},
+	{
+		err:     medical.ErrSpecialtyNotFound,
+		status:  http.StatusNotFound,
+		code:    "not_found",
+		message: "Specialty not found",
+	},
+	{
+		err:     medical.ErrPatientNotFound,
+		status:  http.StatusNotFound,
+		code:    "not_found",
+		message: "Patient not found",
+	},
+	{
+		err:     medical.ErrAppointmentNotFound,
+		status:  http.StatusNotFound,
+		code:    "not_found",
+		message: "Appointment not found",
+	},
+	{
+		err:     medical.ErrTimeOffNotFound,
+		status:  http.StatusNotFound,
+		code:    "not_found",
+		message: "Time off not found",
+	},
+	{
+		err:     domain.ErrNotFound,
+		status:  http.StatusNotFound,
+		code:    "not_found",
+		message: "The requested resource was not found",
+	},
+	{
+		err:     medical.ErrSlotTaken,
+		status:  http.StatusConflict,
+		code:    "slot_taken",
+		message: "The requested time slot is already booked",
+	},
+	{
+		err:     medical.ErrSlotUnavailable,
+		status:  http.StatusConflict,
+		code:    "slot_unavailable",
+		message: "The doctor does not see patients at the requested time",
+	},
+	{
+		err:     medical.ErrAppointmentNotCancellable,
+		status:  http.StatusConflict,
+		code:    "appointment_not_cancellable",
+		message: "Appointment can no longer be cancelled",
+	},
+	{
+		err:     medical.ErrAppointmentNotConfirmable,
+		status:  http.StatusConflict,
+		code:    "appointment_not_confirmable",
+		message: "Only pending appointments that have not started can be confirmed",
+	},
+	{
+		err:     medical.ErrAppointmentNotFinishable,
+		status:  http.StatusConflict,
+		code:    "appointment_not_finishable",
+		message: "Only active appointments that have started can be marked as completed or no-show",
+	},
+	{
+		err:     medical.ErrAppointmentNotReschedulable,
+		status:  http.StatusConflict,
+		code:    "appointment_not_reschedulable",
+		message: "Only pending or confirmed appointments can be rescheduled",
+	},
+	// Checked after the specific transition errors above, which wrap it
+	{
+		err:     medical.ErrInvalidTransition,
+		status:  http.StatusConflict,
+		code:    "invalid_transition",
+		message: "The appointment's current status does not allow this change",
+	},
+	{
+		err:     medical.ErrCancellationCutoffPassed,
+		status:  http.StatusConflict,
+		code:    "cancellation_cutoff_passed",
+		message: "The appointment starts too soon to be cancelled",
+	},
+	{
+		err:     medical.ErrRescheduleCutoffPassed,
+		status:  http.StatusConflict,
+		code:    "reschedule_cutoff_passed",
+		message: "The appointment starts too soon to be rescheduled",
+	},
+	{
+		err:     medical.ErrSpecialtyInUse,
+		status:  http.StatusConflict,
+		code:    "specialty_in_use",
+		message: "The specialty still has doctors; move them to another specialty or delete them first",
+	},
+	{
+		err:     medical.ErrDoctorHasAppointments,
+		status:  http.StatusConflict,
+		code:    "doctor_has_appointments",
+		message: "The doctor has appointments on record and can not be deleted",
+	},
+	{
+		err:     medical.ErrSpecialtyNameTaken,
+		status:  http.StatusConflict,
+		code:    "specialty_name_taken",
+		message: "Another specialty already has this name",
+	},
+	{
+		err:     medical.ErrPhoneNumberTaken,
+		status:  http.StatusConflict,
+		code:    "phone_number_taken",
+		message: "The phone number is already used by another doctor",
+	},
+	{
+		err:     auth.ErrOTPInvalid,
+		status:  http.StatusUnauthorized,
+		code:    "otp_invalid",
+		message: "The login code is incorrect",
+	},
+	{
+		err:     auth.ErrOTPExpired,
+		status:  http.StatusUnauthorized,
+		code:    "otp_expired",
+		message: "The login code has expired, request a new one",
+	},
+	{
+		err:     auth.ErrOTPAttemptsExceeded,
+		status:  http.StatusTooManyRequests,
+		code:    "otp_attempts_exceeded",
+		message: "Too many wrong login codes, request a new one",
+	},
+	{
+		err:     auth.ErrOTPCooldown,
+		status:  http.StatusTooManyRequests,
+		code:    "otp_cooldown",
+		message: "A login code was sent recently, wait before requesting another",
+	},
+	{
+		err:     auth.ErrInvalidToken,
+		status:  http.StatusUnauthorized,
+		code:    "invalid_token",
+		message: "The token is invalid or has expired",
+	},
+	{
+		err:     auth.ErrUnauthenticated,
+		status:  http.StatusUnauthorized,
+		code:    "authentication_required",
+		message: "A bearer token is required",
+	},
+	{
+		err:     auth.ErrForbidden,
+		status:  http.StatusForbidden,
+		code:    "forbidden",
+		message: "You are not allowed to access this resource",
+	},
 }
 
-func ErrorHandler() gin.HandlerFunc {
+// ErrorHandler renders the last error pushed with c.Error as a problem details response.
+// The title, detail and field errors are described by translator in the language the client
+// asks for with Accept-Language; field errors of a *validation.Validator are described by its own.
+// Errors that are neither an *apperror.Error nor a known domain error are logged and
+// reported as an internal error without their text.
+func ErrorHandler(translator *validation.Translator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
 
 		if len(c.Errors) > 0 && !c.Writer.Written() {
 			err := c.Errors.Last().Err
-			handleError(c, err)
+			handleError(c, err, translator)
 		}
 	}
 }
 
-func handleError(c *gin.Context, err error) {
+func handleError(c *gin.Context, err error, translator *validation.Translator) {
+	appErr := toAppError(err)
+	if appErr.Status >= http.StatusInternalServerError {
+		log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+	}
+
+	languages := validation.Languages(c.GetHeader("Accept-Language"))
+	problem := Problem{
+		Type:     "about:blank",
+		Title:    translator.Text(http.StatusText(appErr.Status), languages),
+		Status:   appErr.Status,
+		Detail:   translator.Text(appErr.Message, languages),
+		Instance: c.Request.URL.Path,
+		Code:     appErr.Code,
+		Errors:   appErr.Details,
+	}
+
 	var validationErrors validator.ValidationErrors
-	if errors.As(err, &validationErrors) {
-		handleValidationError(c, validationErrors)
-		return
+	if len(problem.Errors) == 0 && errors.As(err, &validationErrors) {
+		var own *validation.Errors
+		if errors.As(err, &own) {
+			translator = own.Translator
+		}
+		problem.Errors = fieldErrors(validationErrors, translator, languages)
 	}
 
-	c.JSON(http.StatusInternalServerError, ErrorResponse{
-		Status:  http.StatusInternalServerError,
-		Message: "Internal server error",
-	})
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(problem.Status, problem)
 }
 
-func handleValidationError(c *gin.Context, validationErrors validator.ValidationErrors) {
-	errs := make([]ValidationError, len(validationErrors))
-	for i, fieldError := range validationErrors {
-		errs[i] = ValidationError{
-			Field:   fieldError.Field(),
-			Message: getValidationErrorMessage(fieldError),
+// toAppError finds the client facing representation of err
+func toAppError(err error) *apperror.Error {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return apperror.Invalid("Validation failed", err)
+	}
+
+	for _, de := range domainErrors {
+		if errors.Is(err, de.err) {
+			return apperror.New(de.status, de.code, de.message)
 		}
 	}
 
-	c.JSON(http.StatusBadRequest, ErrorResponse{
-		Status:  http.StatusBadRequest,
-		Message: "Validation failed",
-		Errors:  errs,
-	})
+	return apperror.New(http.StatusInternalServerError, "internal_error", "Internal server error")
 }
 
-func getValidationErrorMessage(fieldError validator.FieldError) string {
-	switch fieldError.Tag() {
-	case "required":
-		return "This field is required"
-	case "min":
-		return "Value must be greater than or equal to " + fieldError.Param()
-	case "max":
-		return "Value must be less than or equal to " + fieldError.Param()
-	case "email":
-		return "Invalid email format"
-	case "url":
-		return "Invalid URL format"
-	case "e164":
-		return "Invalid phone number format"
-	default:
-		return "Invalid value"
+// fieldErrors describes each failing field in the first of languages that is supported
+func fieldErrors(validationErrors validator.ValidationErrors, translator *validation.Translator, languages []string) []apperror.FieldError {
+	errs := make([]apperror.FieldError, len(validationErrors))
+	for i, fieldError := range validationErrors {
+		errs[i] = apperror.FieldError{
+			Field:   fieldError.Field(),
+			Message: translator.Message(fieldError, languages),
+		}
 	}
+	return errs
 }
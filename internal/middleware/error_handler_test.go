@@ -0,0 +1,317 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/validation"
+)
+
+func TestErrorHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		err                error
+		expectedStatusCode int
+		expectedCode       string
+	}{
+		{
+			name:               "slot taken maps to conflict",
+			err:                medical.ErrSlotTaken,
+			expectedStatusCode: http.StatusConflict,
+			expectedCode:       "slot_taken",
+		},
+		{
+			name:               "wrapped slot taken maps to conflict",
+			err:                fmt.Errorf("booking failed: %w", medical.ErrSlotTaken),
+			expectedStatusCode: http.StatusConflict,
+			expectedCode:       "slot_taken",
+		},
+		{
+			name:               "restricted delete maps to conflict",
+			err:                medical.ErrSpecialtyInUse,
+			expectedStatusCode: http.StatusConflict,
+			expectedCode:       "specialty_in_use",
+		},
+		{
+			name:               "not found maps to 404",
+			err:                domain.ErrNotFound,
+			expectedStatusCode: http.StatusNotFound,
+			expectedCode:       "not_found",
+		},
+		{
+			name:               "entity not found maps to 404",
+			err:                fmt.Errorf("%w: 42", medical.ErrDoctorNotFound),
+			expectedStatusCode: http.StatusNotFound,
+			expectedCode:       "not_found",
+		},
+		{
+			name:               "wrong login code maps to 401",
+			err:                auth.ErrOTPInvalid,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedCode:       "otp_invalid",
+		},
+		{
+			name:               "wrapped login code cooldown maps to 429",
+			err:                fmt.Errorf("retry after 40s: %w", auth.ErrOTPCooldown),
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedCode:       "otp_cooldown",
+		},
+		{
+			name:               "invalid token maps to 401",
+			err:                auth.ErrInvalidToken,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedCode:       "invalid_token",
+		},
+		{
+			name:               "insufficient role maps to 403",
+			err:                auth.ErrForbidden,
+			expectedStatusCode: http.StatusForbidden,
+			expectedCode:       "forbidden",
+		},
+		{
+			name:               "unknown error maps to internal server error",
+			err:                errors.New("boom"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedCode:       "internal_error",
+		},
+		{
+			name:               "app error keeps its status and code",
+			err:                apperror.New(http.StatusUnprocessableEntity, "unprocessable", "Can not process"),
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedCode:       "unprocessable",
+		},
+		{
+			name:               "wrapped invalid request maps to 400",
+			err:                fmt.Errorf("bind: %w", apperror.Invalid("Invalid request", errors.New("bad json"))),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedCode:       apperror.CodeInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(ErrorHandler(validation.NewTranslator()))
+			router.GET("/", func(c *gin.Context) {
+				_ = c.Error(tt.err)
+			})
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+
+			var response Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "about:blank", response.Type)
+			assert.Equal(t, http.StatusText(tt.expectedStatusCode), response.Title)
+			assert.Equal(t, tt.expectedStatusCode, response.Status)
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.Equal(t, "/", response.Instance)
+			assert.NotEmpty(t, response.Detail)
+		})
+	}
+}
+
+func TestErrorHandler_ValidationErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		Name string `json:"name" validate:"required"`
+		Age  int    `form:"age" validate:"min=18"`
+	}
+	validationErr := validation.New().Struct(request{Age: 12})
+	require.Error(t, validationErr)
+
+	unregisteredErr := validator.New().Struct(request{Age: 12})
+	require.Error(t, unregisteredErr)
+
+	tests := []struct {
+		name           string
+		err            error
+		acceptLanguage string
+		expectedTitle  string
+		expectedDetail string
+		expectedErrors []apperror.FieldError
+	}{
+		{
+			name:           "bare validator errors default to English",
+			err:            validationErr,
+			expectedTitle:  http.StatusText(http.StatusBadRequest),
+			expectedDetail: "Validation failed",
+			expectedErrors: []apperror.FieldError{
+				{Field: "name", Message: "name is a required field"},
+				{Field: "age", Message: "age must be 18 or greater"},
+			},
+		},
+		{
+			name:           "validator errors wrapped in an invalid request",
+			err:            apperror.Invalid("Invalid patient request", validationErr),
+			acceptLanguage: "en-US",
+			expectedTitle:  http.StatusText(http.StatusBadRequest),
+			expectedDetail: "Invalid patient request",
+			expectedErrors: []apperror.FieldError{
+				{Field: "name", Message: "name is a required field"},
+				{Field: "age", Message: "age must be 18 or greater"},
+			},
+		},
+		{
+			name:           "Persian is preferred",
+			err:            validationErr,
+			acceptLanguage: "en;q=0.8, fa-IR, fa;q=0.9",
+			expectedTitle:  "درخواست نامعتبر",
+			expectedDetail: "اعتبارسنجی ناموفق بود",
+			expectedErrors: []apperror.FieldError{
+				{Field: "name", Message: "فیلد name اجباری میباشد"},
+				{Field: "age", Message: "age باید بزرگتر یا برابر 18 باشد"},
+			},
+		},
+		{
+			name:           "unsupported language falls back to English",
+			err:            validationErr,
+			acceptLanguage: "de-DE",
+			expectedTitle:  http.StatusText(http.StatusBadRequest),
+			expectedDetail: "Validation failed",
+			expectedErrors: []apperror.FieldError{
+				{Field: "name", Message: "name is a required field"},
+				{Field: "age", Message: "age must be 18 or greater"},
+			},
+		},
+		{
+			name:           "validator without translations reports invalid values",
+			err:            unregisteredErr,
+			acceptLanguage: "fa",
+			expectedTitle:  "درخواست نامعتبر",
+			expectedDetail: "اعتبارسنجی ناموفق بود",
+			expectedErrors: []apperror.FieldError{
+				{Field: "Name", Message: "Name نامعتبر است"},
+				{Field: "Age", Message: "Age نامعتبر است"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(ErrorHandler(validation.NewTranslator()))
+			router.POST("/patients", func(c *gin.Context) {
+				_ = c.Error(tt.err)
+			})
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPost, "/patients", nil)
+			require.NoError(t, err)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, apperror.CodeInvalidRequest, response.Code)
+			assert.Equal(t, tt.expectedTitle, response.Title)
+			assert.Equal(t, tt.expectedDetail, response.Detail)
+			assert.Equal(t, "/patients", response.Instance)
+			assert.Equal(t, tt.expectedErrors, response.Errors)
+		})
+	}
+}
+
+func TestErrorHandler_TranslatesProblems(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		err            error
+		acceptLanguage string
+		expectedTitle  string
+		expectedDetail string
+	}{
+		{
+			name:           "domain error in Persian",
+			err:            fmt.Errorf("%w: 42", medical.ErrDoctorNotFound),
+			acceptLanguage: "fa-IR",
+			expectedTitle:  "یافت نشد",
+			expectedDetail: "پزشک یافت نشد",
+		},
+		{
+			name:           "request error in Persian",
+			err:            apperror.Invalid("Invalid doctor id", errors.New("bad uuid")),
+			acceptLanguage: "fa",
+			expectedTitle:  "درخواست نامعتبر",
+			expectedDetail: "شناسه پزشک نامعتبر است",
+		},
+		{
+			name:           "internal error in Persian",
+			err:            errors.New("boom"),
+			acceptLanguage: "fa",
+			expectedTitle:  "خطای داخلی سرور",
+			expectedDetail: "خطای داخلی سرور",
+		},
+		{
+			name:           "message built at runtime stays in English",
+			err:            apperror.Invalid("invalid calendar \"x\"", nil),
+			acceptLanguage: "fa",
+			expectedTitle:  "درخواست نامعتبر",
+			expectedDetail: "invalid calendar \"x\"",
+		},
+		{
+			name:           "English by default",
+			err:            medical.ErrDoctorNotFound,
+			expectedTitle:  "Not Found",
+			expectedDetail: "Doctor not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(ErrorHandler(validation.NewTranslator()))
+			router.GET("/", func(c *gin.Context) {
+				_ = c.Error(tt.err)
+			})
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			router.ServeHTTP(w, req)
+
+			var response Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedTitle, response.Title)
+			assert.Equal(t, tt.expectedDetail, response.Detail)
+		})
+	}
+}
+
+func TestDomainErrors_Translated(t *testing.T) {
+	translator := validation.NewTranslator()
+	for _, de := range domainErrors {
+		assert.NotEqual(t, de.message, translator.Text(de.message, []string{"fa"}), de.code)
+		title := http.StatusText(de.status)
+		assert.NotEqual(t, title, translator.Text(title, []string{"fa"}), de.code)
+	}
+}
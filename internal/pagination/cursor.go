@@ -1,7 +1,7 @@
 package pagination
 
 import (
-	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -9,28 +9,57 @@ import (
 	"strings"
 
 	"github.com/shayesteh1hs/DrAppointment/internal/domain"
+	"github.com/shayesteh1hs/DrAppointment/internal/filter"
 
 	"github.com/huandu/go-sqlbuilder"
 )
 
+const (
+	DirectionNext = "next"
+	DirectionPrev = "prev"
+)
+
 type CursorParams struct {
 	Cursor    string `form:"cursor"`
-	Ordering  string `form:"ordering,default=asc"`
-	Limit     int    `form:"limit,default=10" binding:"min=1,max=100"`
-	BaseURL   string `form:"-"`
+	Direction string `form:"direction,default=next"`
+	// Ordering is the sort field, prefixed with '-' for descending order
+	Ordering string `form:"ordering,default=id"`
+	Limit    int    `form:"limit,default=10" binding:"min=1,max=100"`
+	BaseURL  string `form:"-"`
+	// SortFields whitelists the fields accepted in Ordering besides id
+	SortFields []string `form:"-"`
+	// key is the parsed Ordering; id is always used as the tie-breaker
+	key       filter.OrderField
 	validated bool
 }
 
 func (p *CursorParams) Validate() error {
-	p.Ordering = strings.ToLower(p.Ordering)
-	if p.Ordering != "asc" && p.Ordering != "desc" {
-		return fmt.Errorf("ordering must be either 'asc' or 'desc'")
+	p.Direction = strings.ToLower(p.Direction)
+	if p.Direction != DirectionNext && p.Direction != DirectionPrev {
+		return fmt.Errorf("direction must be either '%s' or '%s'", DirectionNext, DirectionPrev)
+	}
+
+	if p.Ordering == "" {
+		p.Ordering = filter.TieBreaker
+	}
+	ordering, err := filter.ParseOrdering(p.Ordering, p.SortFields)
+	if err != nil {
+		return err
+	}
+	if len(ordering) != 1 {
+		return errors.New("cursor pagination supports ordering by a single field")
 	}
+	p.key = ordering[0]
+	key := p.key
 
 	if p.Cursor != "" {
-		if _, err := decodeCursor(p.Cursor); err != nil {
+		c, err := decodeCursor(p.Cursor)
+		if err != nil {
 			return fmt.Errorf("invalid cursor: %w", err)
 		}
+		if c.Field != key.Name {
+			return errors.New("invalid cursor: cursor does not match ordering")
+		}
 	}
 
 	p.validated = true
@@ -38,11 +67,11 @@ func (p *CursorParams) Validate() error {
 }
 
 func (p *CursorParams) IsForward() bool {
-	return p.Ordering == "asc"
+	return p.Direction == DirectionNext
 }
 
 func (p *CursorParams) IsBackward() bool {
-	return p.Ordering == "desc"
+	return p.Direction == DirectionPrev
 }
 
 func (p *CursorParams) IsValidated() bool {
@@ -57,31 +86,44 @@ func NewCursorPaginator[T domain.ModelEntity](params CursorParams) *CursorPagina
 	return &CursorPaginator[T]{params: params}
 }
 
+func (p *CursorPaginator[T]) IsValidated() bool {
+	return p.params.IsValidated()
+}
+
 func (p *CursorPaginator[T]) Paginate(sb *sqlbuilder.SelectBuilder) error {
 	if !p.params.IsValidated() {
 		return errors.New("params should be validated before paginating")
 	}
 
+	key := p.params.key
+	// Walking backward reverses the scan order; results are reversed again afterwards
+	descending := key.Desc != p.params.IsBackward()
+
 	// Fetch one extra item to determine if there's a next/previous page
 	sb.Limit(p.params.Limit + 1)
 
 	// Apply cursor condition if provided
 	if p.params.Cursor != "" {
-		cursorID, _ := decodeCursor(p.params.Cursor)
+		c, _ := decodeCursor(p.params.Cursor)
+		operator := ">"
+		if descending {
+			operator = "<"
+		}
 
-		if p.params.IsForward() {
-			// Forward pagination: id > cursor
-			sb.Where(sb.GreaterThan("id", cursorID))
+		if key.Name == filter.TieBreaker {
+			sb.Where(fmt.Sprintf("id %s %s", operator, sb.Var(c.ID)))
 		} else {
-			// Backward pagination: id < cursor
-			sb.Where(sb.LessThan("id", cursorID))
+			// Row-value comparison keeps the page stable when sort values repeat
+			sb.Where(fmt.Sprintf("(%s, id) %s (%s, %s)", key.Name, operator, sb.Var(c.Value), sb.Var(c.ID)))
 		}
 	}
 
-	if p.params.IsForward() {
-		sb.OrderByAsc("id")
-	} else {
-		sb.OrderByDesc("id")
+	for _, field := range keysetFields(key) {
+		if descending {
+			sb.OrderByDesc(field)
+		} else {
+			sb.OrderByAsc(field)
+		}
 	}
 
 	return nil
@@ -116,12 +158,9 @@ func (p *CursorPaginator[T]) CreatePaginationResult(items []T, totalCount int) (
 	firstItem := result.Items[0]
 	lastItem := result.Items[len(result.Items)-1]
 
-	firstID := firstItem.GetId()
-	lastID := lastItem.GetId()
-
 	// Generate previous link (backward pagination from first item)
 	if p.params.Cursor != "" || p.params.IsBackward() {
-		prevURL, err := p.buildURL(firstID, "desc")
+		prevURL, err := p.buildURL(firstItem, DirectionPrev)
 		if err != nil {
 			return nil, err
 		}
@@ -130,7 +169,7 @@ func (p *CursorPaginator[T]) CreatePaginationResult(items []T, totalCount int) (
 
 	// Generate next link (forward pagination from last item)
 	if hasMore || p.params.IsBackward() {
-		nextURL, err := p.buildURL(lastID, "asc")
+		nextURL, err := p.buildURL(lastItem, DirectionNext)
 		if err != nil {
 			return nil, err
 		}
@@ -140,11 +179,16 @@ func (p *CursorPaginator[T]) CreatePaginationResult(items []T, totalCount int) (
 	return result, nil
 }
 
-func (p *CursorPaginator[T]) buildURL(id string, ordering string) (string, error) {
+func (p *CursorPaginator[T]) buildURL(item T, direction string) (string, error) {
 	if p.params.BaseURL == "" {
 		return "", errors.New("base url is required")
 	}
 
+	c, err := p.cursorFor(item)
+	if err != nil {
+		return "", err
+	}
+
 	// Parse existing URL to preserve query parameters
 	u, err := url.Parse(p.params.BaseURL)
 	if err != nil {
@@ -152,27 +196,86 @@ func (p *CursorPaginator[T]) buildURL(id string, ordering string) (string, error
 		return "", errors.New("failed to parse base URL")
 	}
 
-	cursor := encodeCursor(id)
+	encoded, err := encodeCursor(c)
+	if err != nil {
+		return "", err
+	}
+
 	params := u.Query()
-	params.Set("cursor", cursor)
-	params.Set("ordering", ordering)
+	params.Set("cursor", encoded)
+	params.Set("direction", direction)
+	params.Set("ordering", p.params.Ordering)
 	params.Set("limit", fmt.Sprintf("%d", p.params.Limit))
 
 	u.RawQuery = params.Encode()
 	return u.String(), nil
 }
 
-func encodeCursor(value interface{}) string {
-	str := fmt.Sprintf("%v", value)
-	return base64.RawURLEncoding.EncodeToString([]byte(str))
+// cursorFor captures the keyset position of item
+func (p *CursorPaginator[T]) cursorFor(item T) (cursor, error) {
+	key := p.params.key
+	c := cursor{Field: key.Name, ID: item.GetId()}
+	if key.Name == filter.TieBreaker {
+		return c, nil
+	}
+
+	sortable, ok := any(item).(domain.SortableEntity)
+	if !ok {
+		return cursor{}, fmt.Errorf("%T does not support ordering by '%s'", item, key.Name)
+	}
+	value, ok := sortable.GetSortValue(key.Name)
+	if !ok {
+		return cursor{}, fmt.Errorf("%T does not support ordering by '%s'", item, key.Name)
+	}
+	c.Value = value
+	return c, nil
+}
+
+func keysetFields(key filter.OrderField) []string {
+	if key.Name == filter.TieBreaker {
+		return []string{filter.TieBreaker}
+	}
+	return []string{key.Name, filter.TieBreaker}
+}
+
+// cursor identifies the position of a row within an ordered result set
+type cursor struct {
+	Field string `json:"f"`
+	Value any    `json:"v,omitempty"`
+	ID    string `json:"id"`
+	// ExpiresAt is a unix timestamp set when the signer has a ttl
+	ExpiresAt int64 `json:"exp,omitempty"`
+}
+
+func encodeCursor(c cursor) (string, error) {
+	c.ExpiresAt = cursorSigner.expiry()
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return cursorSigner.sign(payload), nil
 }
 
-func decodeCursor(cursor string) (string, error) {
-	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+func decodeCursor(encoded string) (cursor, error) {
+	payload, err := cursorSigner.verify(encoded)
 	if err != nil {
-		return "", fmt.Errorf("failed to decode cursor: %w", err)
+		return cursor{}, err
+	}
+
+	var c cursor
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return cursor{}, fmt.Errorf("failed to decode cursor: %w", err)
+	}
+	if cursorSigner.expired(c.ExpiresAt) {
+		return cursor{}, ErrCursorExpired
+	}
+	if c.ID == "" {
+		return cursor{}, errors.New("cursor is missing the row id")
+	}
+	if c.Field != filter.TieBreaker && c.Value == nil {
+		return cursor{}, errors.New("cursor is missing the sort value")
 	}
-	return string(decoded), nil
+	return c, nil
 }
 
 func reverseSlice[T any](s []T) {
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/huandu/go-sqlbuilder"
@@ -12,10 +13,10 @@ import (
 
 func TestCursorParams_Validate_ValidParams(t *testing.T) {
 	params := CursorParams{
-		Cursor:   "",
-		Ordering: "asc",
-		Limit:    10,
-		BaseURL:  "http://example.com/api",
+		Cursor:    "",
+		Direction: "next",
+		Limit:     10,
+		BaseURL:   "http://example.com/api",
 	}
 
 	err := params.Validate()
@@ -25,12 +26,12 @@ func TestCursorParams_Validate_ValidParams(t *testing.T) {
 }
 
 func TestCursorParams_Validate_ValidParamsWithCursor(t *testing.T) {
-	validCursor := encodeCursor("123")
+	validCursor := mustEncodeCursor(t, cursor{Field: "id", ID: "123"})
 	params := CursorParams{
-		Cursor:   validCursor,
-		Ordering: "desc",
-		Limit:    20,
-		BaseURL:  "http://example.com/api",
+		Cursor:    validCursor,
+		Direction: "prev",
+		Limit:     20,
+		BaseURL:   "http://example.com/api",
 	}
 
 	err := params.Validate()
@@ -39,27 +40,82 @@ func TestCursorParams_Validate_ValidParamsWithCursor(t *testing.T) {
 	assert.True(t, params.IsValidated())
 }
 
-func TestCursorParams_Validate_InvalidOrdering(t *testing.T) {
+func TestCursorParams_Validate_InvalidDirection(t *testing.T) {
 	params := CursorParams{
-		Cursor:   "",
-		Ordering: "invalid",
-		Limit:    10,
-		BaseURL:  "http://example.com/api",
+		Cursor:    "",
+		Direction: "invalid",
+		Limit:     10,
+		BaseURL:   "http://example.com/api",
 	}
 
 	err := params.Validate()
 
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "ordering must be either 'asc' or 'desc'")
+	assert.Contains(t, err.Error(), "direction must be either 'next' or 'prev'")
 	assert.False(t, params.IsValidated())
 }
 
+func TestCursorParams_Validate_Ordering(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ordering string
+		cursor   *cursor
+		wantErr  string
+	}{
+		{name: "default id ordering", ordering: ""},
+		{name: "id ordering", ordering: "id"},
+		{name: "descending id ordering", ordering: "-id"},
+		{name: "whitelisted field", ordering: "name"},
+		{name: "descending whitelisted field", ordering: "-created_at"},
+		{name: "unknown field", ordering: "phone_number", wantErr: "ordering by 'phone_number' is not supported"},
+		{name: "injection attempt", ordering: "name; DROP TABLE doctors", wantErr: "is not supported"},
+		{name: "multiple fields", ordering: "name,-created_at", wantErr: "single field"},
+		{
+			name:     "cursor matches ordering",
+			ordering: "-name",
+			cursor:   &cursor{Field: "name", Value: "Dr. Smith", ID: "7"},
+		},
+		{
+			name:     "cursor from another ordering",
+			ordering: "name",
+			cursor:   &cursor{Field: "created_at", Value: "2025-01-01T00:00:00Z", ID: "7"},
+			wantErr:  "cursor does not match ordering",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			params := CursorParams{
+				Direction:  "next",
+				Ordering:   tc.ordering,
+				Limit:      10,
+				BaseURL:    "http://example.com/api",
+				SortFields: []string{"name", "created_at"},
+			}
+			if tc.cursor != nil {
+				params.Cursor = mustEncodeCursor(t, *tc.cursor)
+			}
+
+			err := params.Validate()
+
+			if tc.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				assert.False(t, params.IsValidated())
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, params.IsValidated())
+			}
+		})
+	}
+}
+
 func TestCursorParams_Validate_InvalidCursor(t *testing.T) {
 	params := CursorParams{
-		Cursor:   "invalid-cursor!!!",
-		Ordering: "asc",
-		Limit:    10,
-		BaseURL:  "http://example.com/api",
+		Cursor:    "invalid-cursor!!!",
+		Direction: "next",
+		Limit:     10,
+		BaseURL:   "http://example.com/api",
 	}
 
 	err := params.Validate()
@@ -69,43 +125,43 @@ func TestCursorParams_Validate_InvalidCursor(t *testing.T) {
 	assert.False(t, params.IsValidated())
 }
 
-func TestCursorParams_Validate_CaseInsensitiveOrdering(t *testing.T) {
+func TestCursorParams_Validate_CaseInsensitiveDirection(t *testing.T) {
 	params := CursorParams{
-		Cursor:   "",
-		Ordering: "ASC",
-		Limit:    10,
-		BaseURL:  "http://example.com/api",
+		Cursor:    "",
+		Direction: "NEXT",
+		Limit:     10,
+		BaseURL:   "http://example.com/api",
 	}
 
 	err := params.Validate()
 
 	assert.Nil(t, err)
-	assert.Equal(t, "asc", params.Ordering)
+	assert.Equal(t, "next", params.Direction)
 	assert.True(t, params.IsValidated())
 }
 
 func TestCursorParams_IsForward(t *testing.T) {
-	params := CursorParams{Ordering: "asc"}
+	params := CursorParams{Direction: "next"}
 	assert.True(t, params.IsForward())
 
-	params.Ordering = "desc"
+	params.Direction = "prev"
 	assert.False(t, params.IsForward())
 }
 
 func TestCursorParams_IsBackward(t *testing.T) {
-	params := CursorParams{Ordering: "desc"}
+	params := CursorParams{Direction: "prev"}
 	assert.True(t, params.IsBackward())
 
-	params.Ordering = "asc"
+	params.Direction = "next"
 	assert.False(t, params.IsBackward())
 }
 
 func TestCursorPaginator_Paginate_ForwardWithoutCursor(t *testing.T) {
 	params := CursorParams{
-		Cursor:   "",
-		Ordering: "asc",
-		Limit:    10,
-		BaseURL:  "http://example.com/api",
+		Cursor:    "",
+		Direction: "next",
+		Limit:     10,
+		BaseURL:   "http://example.com/api",
 	}
 
 	// Validate the params
@@ -129,12 +185,12 @@ func TestCursorPaginator_Paginate_ForwardWithoutCursor(t *testing.T) {
 }
 
 func TestCursorPaginator_Paginate_ForwardWithCursor(t *testing.T) {
-	cursor := encodeCursor("123")
+	encoded := mustEncodeCursor(t, cursor{Field: "id", ID: "123"})
 	params := CursorParams{
-		Cursor:   cursor,
-		Ordering: "asc",
-		Limit:    10,
-		BaseURL:  "http://example.com/api",
+		Cursor:    encoded,
+		Direction: "next",
+		Limit:     10,
+		BaseURL:   "http://example.com/api",
 	}
 
 	// Validate the params
@@ -160,12 +216,12 @@ func TestCursorPaginator_Paginate_ForwardWithCursor(t *testing.T) {
 }
 
 func TestCursorPaginator_Paginate_BackwardWithCursor(t *testing.T) {
-	cursor := encodeCursor("123")
+	encoded := mustEncodeCursor(t, cursor{Field: "id", ID: "123"})
 	params := CursorParams{
-		Cursor:   cursor,
-		Ordering: "desc",
-		Limit:    10,
-		BaseURL:  "http://example.com/api",
+		Cursor:    encoded,
+		Direction: "prev",
+		Limit:     10,
+		BaseURL:   "http://example.com/api",
 	}
 
 	// Validate the params
@@ -190,12 +246,78 @@ func TestCursorPaginator_Paginate_BackwardWithCursor(t *testing.T) {
 	assert.Equal(t, params.Limit+1, args[1])
 }
 
+func TestCursorPaginator_Paginate_SortField(t *testing.T) {
+	testCases := []struct {
+		name          string
+		direction     string
+		ordering      string
+		expectedWhere string
+		expectedOrder string
+	}{
+		{
+			name:          "ascending forward",
+			direction:     "next",
+			ordering:      "name",
+			expectedWhere: "WHERE (name, id) > ($1, $2)",
+			expectedOrder: "ORDER BY name ASC, id ASC",
+		},
+		{
+			name:          "ascending backward",
+			direction:     "prev",
+			ordering:      "name",
+			expectedWhere: "WHERE (name, id) < ($1, $2)",
+			expectedOrder: "ORDER BY name DESC, id DESC",
+		},
+		{
+			name:          "descending forward",
+			direction:     "next",
+			ordering:      "-name",
+			expectedWhere: "WHERE (name, id) < ($1, $2)",
+			expectedOrder: "ORDER BY name DESC, id DESC",
+		},
+		{
+			name:          "descending backward",
+			direction:     "prev",
+			ordering:      "-name",
+			expectedWhere: "WHERE (name, id) > ($1, $2)",
+			expectedOrder: "ORDER BY name ASC, id ASC",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			params := CursorParams{
+				Cursor:     mustEncodeCursor(t, cursor{Field: "name", Value: "Dr. Smith", ID: "7"}),
+				Direction:  tc.direction,
+				Ordering:   tc.ordering,
+				Limit:      10,
+				BaseURL:    "http://example.com/api",
+				SortFields: []string{"name"},
+			}
+			assert.NoError(t, params.Validate())
+
+			paginator := NewCursorPaginator[sortableMockEntity](params)
+			sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+			sb.Select("*").From("test_table")
+
+			err := paginator.Paginate(sb)
+			assert.NoError(t, err)
+
+			sql, args := sb.Build()
+			assert.Contains(t, sql, tc.expectedWhere)
+			assert.Contains(t, sql, tc.expectedOrder)
+			assert.Contains(t, sql, "LIMIT $3")
+			assert.Equal(t, []interface{}{"Dr. Smith", "7", 11}, args)
+		})
+	}
+}
+
 func TestCursorPaginator_Paginate_WithoutValidation(t *testing.T) {
 	params := CursorParams{
-		Cursor:   "",
-		Ordering: "asc",
-		Limit:    10,
-		BaseURL:  "http://example.com/api",
+		Cursor:    "",
+		Direction: "next",
+		Limit:     10,
+		BaseURL:   "http://example.com/api",
 	}
 
 	// Do not validate the params
@@ -213,10 +335,10 @@ func TestCursorPaginator_Paginate_WithoutValidation(t *testing.T) {
 func TestCursorPaginator_CreatePaginationResult_ForwardWithMoreItems(t *testing.T) {
 	baseURL := "http://example.com/api"
 	params := CursorParams{
-		Cursor:   "",
-		Ordering: "asc",
-		Limit:    10,
-		BaseURL:  baseURL,
+		Cursor:    "",
+		Direction: "next",
+		Limit:     10,
+		BaseURL:   baseURL,
 	}
 
 	// Generate 11 items (limit + 1 to simulate hasMore)
@@ -245,21 +367,21 @@ func TestCursorPaginator_CreatePaginationResult_ForwardWithMoreItems(t *testing.
 	assert.NoError(t, err)
 
 	// Decode the cursor to verify it's the last item's ID
-	cursor := nextURL.Query().Get("cursor")
-	decodedCursor, err := decodeCursor(cursor)
+	decodedCursor, err := decodeCursor(nextURL.Query().Get("cursor"))
 	assert.NoError(t, err)
-	assert.Equal(t, "9", decodedCursor) // Last item ID (0-indexed)
-	assert.Equal(t, "asc", nextURL.Query().Get("ordering"))
+	assert.Equal(t, "9", decodedCursor.ID) // Last item ID (0-indexed)
+	assert.Equal(t, "next", nextURL.Query().Get("direction"))
+	assert.Equal(t, "id", nextURL.Query().Get("ordering"))
 }
 
 func TestCursorPaginator_CreatePaginationResult_BackwardWithMoreItems(t *testing.T) {
 	baseURL := "http://example.com/api"
-	cursor := encodeCursor("20")
+	encoded := mustEncodeCursor(t, cursor{Field: "id", ID: "20"})
 	params := CursorParams{
-		Cursor:   cursor,
-		Ordering: "desc",
-		Limit:    10,
-		BaseURL:  baseURL,
+		Cursor:    encoded,
+		Direction: "prev",
+		Limit:     10,
+		BaseURL:   baseURL,
 	}
 
 	// Generate 11 items (limit + 1 to simulate hasMore)
@@ -287,22 +409,22 @@ func TestCursorPaginator_CreatePaginationResult_BackwardWithMoreItems(t *testing
 	assert.NotNil(t, result.Previous)
 	prevURL, err := url.Parse(*result.Previous)
 	assert.NoError(t, err)
-	assert.Equal(t, "desc", prevURL.Query().Get("ordering"))
+	assert.Equal(t, "prev", prevURL.Query().Get("direction"))
 
 	// Check next link (should exist for backward pagination)
 	assert.NotNil(t, result.Next)
 	nextURL, err := url.Parse(*result.Next)
 	assert.NoError(t, err)
-	assert.Equal(t, "asc", nextURL.Query().Get("ordering"))
+	assert.Equal(t, "next", nextURL.Query().Get("direction"))
 }
 
 func TestCursorPaginator_CreatePaginationResult_NoMoreItems(t *testing.T) {
 	baseURL := "http://example.com/api"
 	params := CursorParams{
-		Cursor:   "",
-		Ordering: "asc",
-		Limit:    10,
-		BaseURL:  baseURL,
+		Cursor:    "",
+		Direction: "next",
+		Limit:     10,
+		BaseURL:   baseURL,
 	}
 
 	// Generate exactly limit items (no more items)
@@ -332,10 +454,10 @@ func TestCursorPaginator_CreatePaginationResult_NoMoreItems(t *testing.T) {
 func TestCursorPaginator_CreatePaginationResult_EmptyItems(t *testing.T) {
 	baseURL := "http://example.com/api"
 	params := CursorParams{
-		Cursor:   "",
-		Ordering: "asc",
-		Limit:    10,
-		BaseURL:  baseURL,
+		Cursor:    "",
+		Direction: "next",
+		Limit:     10,
+		BaseURL:   baseURL,
 	}
 
 	items := []mockEntity{}
@@ -361,10 +483,10 @@ func TestCursorPaginator_CreatePaginationResult_EmptyItems(t *testing.T) {
 
 func TestCursorPaginator_CreatePaginationResult_WithoutValidation(t *testing.T) {
 	params := CursorParams{
-		Cursor:   "",
-		Ordering: "asc",
-		Limit:    10,
-		BaseURL:  "http://example.com/api",
+		Cursor:    "",
+		Direction: "next",
+		Limit:     10,
+		BaseURL:   "http://example.com/api",
 	}
 
 	// Do not validate the params
@@ -379,90 +501,154 @@ func TestCursorPaginator_CreatePaginationResult_WithoutValidation(t *testing.T)
 	assert.Nil(t, result)
 }
 
+func TestCursorPaginator_CreatePaginationResult_SortField(t *testing.T) {
+	params := CursorParams{
+		Direction:  "next",
+		Ordering:   "-name",
+		Limit:      2,
+		BaseURL:    "http://example.com/api",
+		SortFields: []string{"name"},
+	}
+	assert.NoError(t, params.Validate())
+	paginator := NewCursorPaginator[sortableMockEntity](params)
+
+	items := []sortableMockEntity{
+		{ID: "3", Name: "Zahra"},
+		{ID: "1", Name: "Mina"},
+		{ID: "2", Name: "Ali"},
+	}
+
+	result, err := paginator.CreatePaginationResult(items, 3)
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 2)
+	assert.NotNil(t, result.Next)
+
+	nextURL, err := url.Parse(*result.Next)
+	assert.NoError(t, err)
+	assert.Equal(t, "-name", nextURL.Query().Get("ordering"))
+
+	decodedCursor, err := decodeCursor(nextURL.Query().Get("cursor"))
+	assert.NoError(t, err)
+	assert.Equal(t, cursor{Field: "name", Value: "Mina", ID: "1"}, decodedCursor)
+}
+
+func TestCursorPaginator_CreatePaginationResult_UnsortableEntity(t *testing.T) {
+	params := CursorParams{
+		Direction:  "next",
+		Ordering:   "name",
+		Limit:      1,
+		BaseURL:    "http://example.com/api",
+		SortFields: []string{"name"},
+	}
+	assert.NoError(t, params.Validate())
+	paginator := NewCursorPaginator[mockEntity](params)
+
+	_, err := paginator.CreatePaginationResult([]mockEntity{{ID: "1"}, {ID: "2"}}, 2)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support ordering by 'name'")
+}
+
 func TestCursorPaginator_BuildURL_WithExistingQueryParams(t *testing.T) {
 	params := CursorParams{
-		Cursor:   "",
-		Ordering: "asc",
-		Limit:    10,
-		BaseURL:  "http://example.com/api?filter=test",
+		Cursor:    "",
+		Direction: "next",
+		Limit:     10,
+		BaseURL:   "http://example.com/api?filter=test",
 	}
 
 	_ = params.Validate()
 	paginator := NewCursorPaginator[mockEntity](params)
 
-	result, err := paginator.buildURL("123", "asc")
+	result, err := paginator.buildURL(mockEntity{ID: "123"}, "next")
 	assert.NoError(t, err)
 
 	assert.Contains(t, result, "http://example.com/api?")
-	assert.Contains(t, result, "ordering=asc")
+	assert.Contains(t, result, "direction=next")
+	assert.Contains(t, result, "ordering=id")
 	assert.Contains(t, result, "limit=10")
 	assert.Contains(t, result, "filter=test")
 
 	// Verify cursor is properly encoded
 	u, err := url.Parse(result)
 	assert.NoError(t, err)
-	cursor := u.Query().Get("cursor")
-	decodedCursor, err := decodeCursor(cursor)
+	decodedCursor, err := decodeCursor(u.Query().Get("cursor"))
 	assert.NoError(t, err)
-	assert.Equal(t, "123", decodedCursor)
+	assert.Equal(t, "123", decodedCursor.ID)
 }
 
 func TestCursorPaginator_BuildURL_EmptyBaseURL(t *testing.T) {
 	params := CursorParams{
-		Cursor:   "",
-		Ordering: "asc",
-		Limit:    10,
-		BaseURL:  "",
+		Cursor:    "",
+		Direction: "next",
+		Limit:     10,
+		BaseURL:   "",
 	}
 
 	paginator := NewCursorPaginator[mockEntity](params)
 
-	result, err := paginator.buildURL("123", "asc")
+	result, err := paginator.buildURL(mockEntity{ID: "123"}, "next")
 	assert.Error(t, err)
 	assert.Equal(t, "base url is required", err.Error())
 	assert.Empty(t, result)
 }
 
-func TestEncodeCursor(t *testing.T) {
-	testCases := []struct {
-		input    interface{}
-		expected string
-	}{
-		{"123", base64.RawURLEncoding.EncodeToString([]byte("123"))},
-		{456, base64.RawURLEncoding.EncodeToString([]byte("456"))},
-		{"", base64.RawURLEncoding.EncodeToString([]byte(""))},
+func TestEncodeDecodeCursor_RoundTrip(t *testing.T) {
+	testCases := []cursor{
+		{Field: "id", ID: "123"},
+		{Field: "name", Value: "Dr. Smith", ID: "0193a5e0-7b1c-7cc2-a4b4-5e0b1d8c1f00"},
+		{Field: "created_at", Value: "2025-01-06T09:30:00.123456Z", ID: "42"},
 	}
 
 	for _, tc := range testCases {
-		result := encodeCursor(tc.input)
-		assert.Equal(t, tc.expected, result)
+		encoded := mustEncodeCursor(t, tc)
+		decoded, err := decodeCursor(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, tc, decoded)
 	}
 }
 
-func TestDecodeCursor(t *testing.T) {
+func TestDecodeCursor_Invalid(t *testing.T) {
 	testCases := []struct {
-		input       string
-		expected    string
-		shouldError bool
+		name  string
+		input string
 	}{
-		{base64.RawURLEncoding.EncodeToString([]byte("123")), "123", false},
-		{base64.RawURLEncoding.EncodeToString([]byte("456")), "456", false},
-		{base64.RawURLEncoding.EncodeToString([]byte("")), "", false},
-		{"invalid-base64!!!", "", true},
+		{"not base64", "invalid-base64!!!"},
+		{"unsigned", base64.RawURLEncoding.EncodeToString([]byte(`{"f":"id","id":"1"}`))},
+		{"not json", cursorSigner.sign([]byte("123"))},
+		{"missing id", cursorSigner.sign([]byte(`{"f":"id"}`))},
+		{"missing sort value", cursorSigner.sign([]byte(`{"f":"name","id":"1"}`))},
 	}
 
 	for _, tc := range testCases {
-		result, err := decodeCursor(tc.input)
-		if tc.shouldError {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := decodeCursor(tc.input)
 			assert.Error(t, err)
-			assert.Empty(t, result)
-		} else {
-			assert.NoError(t, err)
-			assert.Equal(t, tc.expected, result)
-		}
+		})
 	}
 }
 
+func TestCursorParams_Validate_TamperedCursor(t *testing.T) {
+	encoded := mustEncodeCursor(t, cursor{Field: "id", ID: "123"})
+	payload, signature, _ := strings.Cut(encoded, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"f":"id","id":"999"}`))
+
+	params := CursorParams{
+		Cursor:    forged + "." + signature,
+		Direction: "next",
+		Limit:     10,
+		BaseURL:   "http://example.com/api",
+	}
+
+	err := params.Validate()
+	assert.ErrorIs(t, err, ErrCursorTampered)
+	assert.Contains(t, err.Error(), "invalid cursor")
+	assert.False(t, params.IsValidated())
+
+	// The untouched cursor is still accepted
+	params.Cursor = payload + "." + signature
+	assert.NoError(t, params.Validate())
+}
+
 func TestReverseSlice(t *testing.T) {
 	testCases := []struct {
 		input    []mockEntity
@@ -504,3 +690,28 @@ func generateMockItemsReverse(count int, startID int) []mockEntity {
 	}
 	return items
 }
+
+type sortableMockEntity struct {
+	ID   string
+	Name string
+}
+
+func (m sortableMockEntity) GetId() string {
+	return m.ID
+}
+
+func (m sortableMockEntity) GetSortValue(field string) (any, bool) {
+	if field == "name" {
+		return m.Name, true
+	}
+	return nil, false
+}
+
+func mustEncodeCursor(t *testing.T, c cursor) string {
+	t.Helper()
+	encoded, err := encodeCursor(c)
+	if err != nil {
+		t.Fatalf("failed to encode cursor: %v", err)
+	}
+	return encoded
+}
@@ -7,15 +7,21 @@ import (
 	"net/url"
 
 	"github.com/shayesteh1hs/DrAppointment/internal/domain"
+	"github.com/shayesteh1hs/DrAppointment/internal/filter"
 
 	"github.com/huandu/go-sqlbuilder"
 )
 
 type LimitOffsetParams struct {
-	Page      int    `form:"page,default=1" binding:"min=1"`
-	Limit     int    `form:"limit,default=10" binding:"min=1,max=100"`
-	BaseURL   string `form:"-"`
-	validated bool
+	Page    int    `form:"page,default=1" binding:"min=1"`
+	Limit   int    `form:"limit,default=10" binding:"min=1,max=100"`
+	BaseURL string `form:"-"`
+	// Ordering is a comma-separated list of sort fields, each optionally prefixed with '-'
+	Ordering string `form:"ordering"`
+	// SortFields whitelists the fields accepted in Ordering besides id
+	SortFields []string `form:"-"`
+	ordering   filter.Ordering
+	validated  bool
 }
 
 func (p *LimitOffsetParams) Validate() error {
@@ -23,6 +29,12 @@ func (p *LimitOffsetParams) Validate() error {
 		return errors.New("base url is required")
 	}
 
+	ordering, err := filter.ParseOrdering(p.Ordering, p.SortFields)
+	if err != nil {
+		return err
+	}
+	p.ordering = ordering
+
 	p.validated = true
 	return nil
 }
@@ -39,6 +51,10 @@ func NewLimitOffsetPaginator[T domain.ModelEntity](params LimitOffsetParams) *Li
 	return &LimitOffsetPaginator[T]{params: params}
 }
 
+func (p *LimitOffsetPaginator[T]) IsValidated() bool {
+	return p.params.IsValidated()
+}
+
 func (p *LimitOffsetPaginator[T]) getOffset() int {
 	return p.params.Limit * (p.params.Page - 1)
 }
@@ -48,6 +64,8 @@ func (p *LimitOffsetPaginator[T]) Paginate(sb *sqlbuilder.SelectBuilder) error {
 		return errors.New("params should be validated before paginating")
 	}
 
+	// Offsets are only stable over a total order, so id always breaks ties
+	p.params.ordering.Apply(sb)
 	sb.Limit(p.params.Limit)
 	sb.Offset(p.getOffset())
 
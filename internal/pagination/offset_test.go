@@ -71,6 +71,42 @@ func TestLimitOffsetPaginator_Paginate_WithValidation(t *testing.T) {
 	assert.Equal(t, paginator.getOffset(), args[1])
 }
 
+func TestLimitOffsetPaginator_Paginate_Ordering(t *testing.T) {
+	params := LimitOffsetParams{
+		Page:       1,
+		Limit:      10,
+		BaseURL:    "http://example.com/api",
+		Ordering:   "-name",
+		SortFields: []string{"name"},
+	}
+	assert.NoError(t, params.Validate())
+
+	paginator := NewLimitOffsetPaginator[mockEntity](params)
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("*").From("test")
+
+	err := paginator.Paginate(sb)
+	assert.NoError(t, err)
+
+	sql, _ := sb.Build()
+	assert.Equal(t, "SELECT * FROM test ORDER BY name DESC, id ASC LIMIT $1 OFFSET $2", sql)
+}
+
+func TestLimitOffsetParams_Validate_UnsupportedOrdering(t *testing.T) {
+	params := LimitOffsetParams{
+		Page:       1,
+		Limit:      10,
+		BaseURL:    "http://example.com/api",
+		Ordering:   "phone_number",
+		SortFields: []string{"name"},
+	}
+
+	err := params.Validate()
+
+	assert.EqualError(t, err, "ordering by 'phone_number' is not supported")
+	assert.False(t, params.IsValidated())
+}
+
 func TestLimitOffsetPaginator_Paginate_WithoutValidation(t *testing.T) {
 	params := LimitOffsetParams{
 		Page:    2,
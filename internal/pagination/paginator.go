@@ -19,6 +19,25 @@ type Paginator[T domain.ModelEntity] interface {
 	IsValidated() bool // to check if the params are validated before paginating
 }
 
+const (
+	ModeOffset = "offset"
+	ModeCursor = "cursor"
+)
+
+// ModeParams selects the pagination strategy requested by the client
+type ModeParams struct {
+	Mode string `form:"pagination,default=offset" binding:"oneof=offset cursor"`
+}
+
+func (p ModeParams) IsCursor() bool {
+	return p.Mode == ModeCursor
+}
+
 type Params interface {
 	Validate() error
 }
+
+var (
+	_ Paginator[domain.ModelEntity] = (*LimitOffsetPaginator[domain.ModelEntity])(nil)
+	_ Paginator[domain.ModelEntity] = (*CursorPaginator[domain.ModelEntity])(nil)
+)
@@ -0,0 +1,84 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/random"
+)
+
+var (
+	ErrCursorTampered = errors.New("cursor signature is invalid")
+	ErrCursorExpired  = errors.New("cursor has expired")
+)
+
+// CursorSigner signs cursor payloads with HMAC-SHA256 so clients cannot forge positions
+type CursorSigner struct {
+	key []byte
+	// ttl bounds how long an issued cursor stays usable; zero disables expiry
+	ttl time.Duration
+	now func() time.Time
+}
+
+func NewCursorSigner(key []byte, ttl time.Duration) *CursorSigner {
+	return &CursorSigner{key: key, ttl: ttl, now: time.Now}
+}
+
+// cursorSigner is used by every CursorPaginator; it defaults to a random key,
+// so cursors do not survive restarts until SetCursorSigner is called at startup
+var cursorSigner = NewCursorSigner(random.Bytes(32), 0)
+
+// SetCursorSigner replaces the signer used for cursors; it must be called before serving requests
+func SetCursorSigner(signer *CursorSigner) {
+	cursorSigner = signer
+}
+
+// expiry returns the unix time a cursor issued now expires at, or zero if cursors never expire
+func (s *CursorSigner) expiry() int64 {
+	if s.ttl <= 0 {
+		return 0
+	}
+	return s.now().Add(s.ttl).Unix()
+}
+
+func (s *CursorSigner) expired(expiresAt int64) bool {
+	return expiresAt != 0 && s.now().Unix() >= expiresAt
+}
+
+// sign returns the payload and its signature joined by a dot
+func (s *CursorSigner) sign(payload []byte) string {
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(s.mac(payload))
+}
+
+// verify checks the signature of a token produced by sign and returns its payload
+func (s *CursorSigner) verify(token string) ([]byte, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrCursorTampered
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode cursor: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode cursor: %w", err)
+	}
+
+	if !hmac.Equal(signature, s.mac(payload)) {
+		return nil, ErrCursorTampered
+	}
+	return payload, nil
+}
+
+func (s *CursorSigner) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write(payload)
+	return h.Sum(nil)
+}
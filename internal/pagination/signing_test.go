@@ -0,0 +1,64 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorSigner_Verify(t *testing.T) {
+	signer := NewCursorSigner([]byte("secret"), 0)
+	token := signer.sign([]byte(`{"f":"id","id":"1"}`))
+
+	testCases := []struct {
+		name    string
+		signer  *CursorSigner
+		token   string
+		wantErr error
+	}{
+		{name: "valid signature", signer: signer, token: token},
+		{name: "different key", signer: NewCursorSigner([]byte("other"), 0), token: token, wantErr: ErrCursorTampered},
+		{name: "missing signature", signer: signer, token: "eyJmIjoiaWQiLCJpZCI6IjEifQ", wantErr: ErrCursorTampered},
+		{name: "truncated signature", signer: signer, token: token[:len(token)-4], wantErr: ErrCursorTampered},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := tc.signer.verify(tc.token)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, `{"f":"id","id":"1"}`, string(payload))
+		})
+	}
+}
+
+func TestCursorSigner_Expiry(t *testing.T) {
+	issuedAt := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	signer := NewCursorSigner([]byte("secret"), 10*time.Minute)
+	signer.now = func() time.Time { return issuedAt }
+
+	previous := cursorSigner
+	SetCursorSigner(signer)
+	t.Cleanup(func() { SetCursorSigner(previous) })
+
+	encoded := mustEncodeCursor(t, cursor{Field: "id", ID: "1"})
+
+	signer.now = func() time.Time { return issuedAt.Add(9 * time.Minute) }
+	decoded, err := decodeCursor(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, issuedAt.Add(10*time.Minute).Unix(), decoded.ExpiresAt)
+
+	signer.now = func() time.Time { return issuedAt.Add(10 * time.Minute) }
+	_, err = decodeCursor(encoded)
+	assert.ErrorIs(t, err, ErrCursorExpired)
+}
+
+func TestCursorSigner_NoExpiry(t *testing.T) {
+	signer := NewCursorSigner([]byte("secret"), 0)
+	assert.Zero(t, signer.expiry())
+	assert.False(t, signer.expired(0))
+}
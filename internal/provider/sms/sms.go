@@ -0,0 +1,49 @@
+// Package sms sends text messages to mobile numbers
+package sms
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Sender delivers a text message to an E.164 formatted phone number
+type Sender interface {
+	Send(ctx context.Context, phoneNumber, message string) error
+}
+
+// writerSender is a development fake that writes messages instead of sending them
+type writerSender struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+func (s *writerSender) Send(_ context.Context, phoneNumber, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := fmt.Fprintf(s.w, "%s\tto=%s\t%q\n", s.now().UTC().Format(time.RFC3339), phoneNumber, message)
+	if err != nil {
+		return fmt.Errorf("failed to write sms: %w", err)
+	}
+	return nil
+}
+
+// NewConsoleSender returns a Sender that prints every message to w, typically os.Stdout
+func NewConsoleSender(w io.Writer) Sender {
+	return &writerSender{w: w, now: time.Now}
+}
+
+// NewFileSender returns a Sender that appends every message to the file at path, creating it if needed.
+// The returned close function must be called on shutdown.
+func NewFileSender(path string) (Sender, func() error, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open sms file: %w", err)
+	}
+	return &writerSender{w: f, now: time.Now}, f.Close, nil
+}
@@ -0,0 +1,42 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsoleSender_Send(t *testing.T) {
+	var buf bytes.Buffer
+	sender := &writerSender{w: &buf, now: func() time.Time {
+		return time.Date(2025, 3, 3, 9, 30, 0, 0, time.UTC)
+	}}
+
+	err := sender.Send(context.Background(), "+989121234567", "کد ورود: 123456")
+
+	require.NoError(t, err)
+	assert.Equal(t, "2025-03-03T09:30:00Z\tto=+989121234567\t\"کد ورود: 123456\"\n", buf.String())
+}
+
+func TestFileSender_Send_Appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sms.log")
+
+	for _, message := range []string{"first", "second"} {
+		sender, closeFile, err := NewFileSender(path)
+		require.NoError(t, err)
+		require.NoError(t, sender.Send(context.Background(), "+989121234567", message))
+		require.NoError(t, closeFile())
+	}
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"first"`)
+	assert.Contains(t, string(content), `"second"`)
+	assert.Equal(t, 2, bytes.Count(content, []byte("\n")))
+}
@@ -0,0 +1,20 @@
+// Package random provides cryptographically secure random keys and identifiers
+package random
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Bytes returns n random bytes from crypto/rand
+func Bytes(n int) []byte {
+	b := make([]byte, n)
+	// crypto/rand.Read never returns an error; it crashes the program irrecoverably instead
+	_, _ = rand.Read(b)
+	return b
+}
+
+// Hex returns n random bytes encoded as a hex string of length 2n
+func Hex(n int) string {
+	return hex.EncodeToString(Bytes(n))
+}
@@ -0,0 +1,18 @@
+package random
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBytes(t *testing.T) {
+	a, b := Bytes(32), Bytes(32)
+
+	assert.Len(t, a, 32)
+	assert.NotEqual(t, a, b)
+}
+
+func TestHex(t *testing.T) {
+	assert.Regexp(t, `^[0-9a-f]{32}$`, Hex(16))
+}
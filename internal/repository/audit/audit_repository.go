@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/audit"
+)
+
+type AuditRepository interface {
+	// Record appends the entry to audit_log; an empty Before or After is stored as NULL
+	Record(ctx context.Context, entry *domain.Entry) error
+}
+
+type auditRepository struct {
+	db database.Querier
+}
+
+func (r *auditRepository) Record(ctx context.Context, entry *domain.Entry) error {
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("audit_log")
+	ib.Cols("actor_id", "actor_role", "action", "entity_type", "entity_id", "before", "after")
+	ib.Values(entry.ActorID, string(entry.ActorRole), string(entry.Action), entry.EntityType, entry.EntityID, jsonb(entry.Before), jsonb(entry.After))
+
+	query, args := ib.Build()
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// jsonb passes the document as a string, since lib/pq sends []byte as bytea which JSONB rejects.
+// An empty document is returned as an untyped nil so it is stored as NULL.
+func jsonb(data []byte) any {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
+
+func NewAuditRepository(db database.Querier) AuditRepository {
+	return &auditRepository{db: db}
+}
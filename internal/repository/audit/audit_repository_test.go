@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/audit"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+)
+
+const auditInsertQuery = `INSERT INTO audit_log \(actor_id, actor_role, action, entity_type, entity_id, before, after\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\)`
+
+func TestAuditRepository_Record(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	entityID := uuid.New()
+
+	tests := []struct {
+		name      string
+		entry     domain.Entry
+		mockSetup func(sqlmock.Sqlmock)
+		wantErr   bool
+	}{
+		{
+			name: "creation is stored without a before document",
+			entry: domain.Entry{
+				ActorID: actorID, ActorRole: auth.RoleAdmin, Action: domain.ActionCreate,
+				EntityType: domain.EntitySpecialty, EntityID: entityID, After: []byte(`{"name":"پوست"}`),
+			},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(auditInsertQuery).
+					WithArgs(actorID, "admin", "create", "specialty", entityID, nil, `{"name":"پوست"}`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "deletion is stored without an after document",
+			entry: domain.Entry{
+				ActorID: actorID, ActorRole: auth.RoleAdmin, Action: domain.ActionDelete,
+				EntityType: domain.EntityDoctor, EntityID: entityID, Before: []byte(`{"name":"Dr. Smith"}`),
+			},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(auditInsertQuery).
+					WithArgs(actorID, "admin", "delete", "doctor", entityID, `{"name":"Dr. Smith"}`, nil).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:  "database error",
+			entry: domain.Entry{ActorID: actorID, ActorRole: auth.RoleAdmin, Action: domain.ActionCreate, EntityType: domain.EntityDoctor, EntityID: entityID},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(auditInsertQuery).WillReturnError(errors.New("connection lost"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+			err = NewAuditRepository(db).Record(ctx, &tt.entry)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
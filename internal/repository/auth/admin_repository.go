@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+)
+
+var adminColumns = []string{"id", "name", "phone_number", "created_at", "updated_at"}
+
+type AdminRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Admin, error)
+	GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Admin, error)
+}
+
+type adminRepository struct {
+	db database.Querier
+}
+
+func (r *adminRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Admin, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(adminColumns...)
+	sb.From("admins")
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+	admin, err := scanAdmin(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrAdminNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to scan admin: %w", err)
+	}
+	return admin, nil
+}
+
+func (r *adminRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Admin, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(adminColumns...)
+	sb.From("admins")
+	sb.Where(sb.Equal("phone_number", phoneNumber))
+
+	query, args := sb.Build()
+	admin, err := scanAdmin(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrAdminNotFound, phoneNumber)
+		}
+		return nil, fmt.Errorf("failed to scan admin: %w", err)
+	}
+	return admin, nil
+}
+
+func scanAdmin(row rowScanner) (*domain.Admin, error) {
+	var admin domain.Admin
+	err := row.Scan(
+		&admin.ID,
+		&admin.Name,
+		&admin.PhoneNumber,
+		&admin.CreatedAt,
+		&admin.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
+
+func NewAdminRepository(db database.Querier) AdminRepository {
+	return &adminRepository{db: db}
+}
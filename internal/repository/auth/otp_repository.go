@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+)
+
+var otpColumns = []string{"phone_number", "code_hash", "attempts", "expires_at", "sent_at"}
+
+type OTPRepository interface {
+	// Save replaces the phone number's code unless the current one was sent after resendAfter.
+	// It reports false, without changing anything, while that cooldown is still running.
+	Save(ctx context.Context, otp *domain.OTP, resendAfter time.Time) (bool, error)
+	GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.OTP, error)
+	// RecordAttempt counts a verification attempt and returns the code as it is after counting
+	RecordAttempt(ctx context.Context, phoneNumber string) (*domain.OTP, error)
+	// Delete removes the phone number's code if it still has codeHash, so each code is removed only once.
+	// It returns ErrOTPNotFound when the code was already used up or replaced.
+	Delete(ctx context.Context, phoneNumber, codeHash string) error
+}
+
+type otpRepository struct {
+	db database.Querier
+}
+
+func (r *otpRepository) Save(ctx context.Context, otp *domain.OTP, resendAfter time.Time) (bool, error) {
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("otp_codes")
+	ib.Cols("phone_number", "code_hash", "attempts", "expires_at", "sent_at")
+	ib.Values(otp.PhoneNumber, otp.CodeHash, otp.Attempts, otp.ExpiresAt, otp.SentAt)
+	// The cooldown is checked in the same statement, so concurrent requests can not both send a code
+	ib.SQL("ON CONFLICT (phone_number) DO UPDATE SET " +
+		"code_hash = EXCLUDED.code_hash, attempts = EXCLUDED.attempts, expires_at = EXCLUDED.expires_at, sent_at = EXCLUDED.sent_at " +
+		"WHERE otp_codes.sent_at <= " + ib.Var(resendAfter))
+	ib.Returning("phone_number")
+
+	query, args := ib.Build()
+	var phoneNumber string
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&phoneNumber)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to save login code: %w", err)
+	}
+	return true, nil
+}
+
+func (r *otpRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.OTP, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(otpColumns...)
+	sb.From("otp_codes")
+	sb.Where(sb.Equal("phone_number", phoneNumber))
+
+	query, args := sb.Build()
+	otp, err := scanOTP(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrOTPNotFound
+		}
+		return nil, fmt.Errorf("failed to scan login code: %w", err)
+	}
+	return otp, nil
+}
+
+func (r *otpRepository) RecordAttempt(ctx context.Context, phoneNumber string) (*domain.OTP, error) {
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("otp_codes")
+	ub.Set(ub.Incr("attempts"))
+	ub.Where(ub.Equal("phone_number", phoneNumber))
+	ub.Returning(otpColumns...)
+
+	query, args := ub.Build()
+	otp, err := scanOTP(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrOTPNotFound
+		}
+		return nil, fmt.Errorf("failed to record login attempt: %w", err)
+	}
+	return otp, nil
+}
+
+func (r *otpRepository) Delete(ctx context.Context, phoneNumber, codeHash string) error {
+	db := sqlbuilder.PostgreSQL.NewDeleteBuilder()
+	db.DeleteFrom("otp_codes")
+	db.Where(db.Equal("phone_number", phoneNumber), db.Equal("code_hash", codeHash))
+
+	query, args := db.Build()
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete login code: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete login code: %w", err)
+	}
+	if deleted == 0 {
+		return domain.ErrOTPNotFound
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanOTP(row rowScanner) (*domain.OTP, error) {
+	var otp domain.OTP
+	err := row.Scan(&otp.PhoneNumber, &otp.CodeHash, &otp.Attempts, &otp.ExpiresAt, &otp.SentAt)
+	if err != nil {
+		return nil, err
+	}
+	return &otp, nil
+}
+
+func NewOTPRepository(db database.Querier) OTPRepository {
+	return &otpRepository{db: db}
+}
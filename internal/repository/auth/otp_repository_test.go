@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+)
+
+// Helper functions
+
+func setupTestDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return db, mock
+}
+
+func newTestOTP() domain.OTP {
+	now := time.Now().Truncate(time.Second)
+	return domain.OTP{
+		PhoneNumber: "+989121234567",
+		CodeHash:    "5d41402abc4b2a76b9719d911017c5925d41402abc4b2a76b9719d911017c592",
+		ExpiresAt:   now.Add(2 * time.Minute),
+		SentAt:      now,
+	}
+}
+
+const otpSaveQuery = `INSERT INTO otp_codes \(phone_number, code_hash, attempts, expires_at, sent_at\) VALUES \(\$1, \$2, \$3, \$4, \$5\) ` +
+	`ON CONFLICT \(phone_number\) DO UPDATE SET code_hash = EXCLUDED.code_hash, attempts = EXCLUDED.attempts, ` +
+	`expires_at = EXCLUDED.expires_at, sent_at = EXCLUDED.sent_at WHERE otp_codes.sent_at <= \$6 RETURNING phone_number`
+
+// Table-driven tests
+
+func TestOTPRepository_Save(t *testing.T) {
+	ctx := context.Background()
+	otp := newTestOTP()
+	resendAfter := otp.SentAt.Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		want      bool
+	}{
+		{
+			name: "saved",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(otpSaveQuery).
+					WithArgs(otp.PhoneNumber, otp.CodeHash, 0, otp.ExpiresAt, otp.SentAt, resendAfter).
+					WillReturnRows(sqlmock.NewRows([]string{"phone_number"}).AddRow(otp.PhoneNumber))
+			},
+			want: true,
+		},
+		{
+			name: "cooldown still running",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(otpSaveQuery).WillReturnRows(sqlmock.NewRows([]string{"phone_number"}))
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			got, err := NewOTPRepository(db).Save(ctx, &otp, resendAfter)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOTPRepository_RecordAttempt(t *testing.T) {
+	ctx := context.Background()
+	otp := newTestOTP()
+	query := `UPDATE otp_codes SET attempts = attempts \+ 1 WHERE phone_number = \$1 RETURNING phone_number, code_hash, attempts, expires_at, sent_at`
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "attempt counted",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).WithArgs(otp.PhoneNumber).WillReturnRows(
+					sqlmock.NewRows(otpColumns).AddRow(otp.PhoneNumber, otp.CodeHash, 1, otp.ExpiresAt, otp.SentAt))
+			},
+		},
+		{
+			name: "no code requested",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).WithArgs(otp.PhoneNumber).WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: domain.ErrOTPNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			got, err := NewOTPRepository(db).RecordAttempt(ctx, otp.PhoneNumber)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 1, got.Attempts)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOTPRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	otp := newTestOTP()
+	query := `DELETE FROM otp_codes WHERE phone_number = \$1 AND code_hash = \$2`
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "code deleted",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).WithArgs(otp.PhoneNumber, otp.CodeHash).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "code already used or replaced",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).WithArgs(otp.PhoneNumber, otp.CodeHash).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: domain.ErrOTPNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			err := NewOTPRepository(db).Delete(ctx, otp.PhoneNumber, otp.CodeHash)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
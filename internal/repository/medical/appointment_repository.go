@@ -0,0 +1,370 @@
+package medical
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/lib/pq"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	filter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+)
+
+const (
+	pqForeignKeyViolation = "23503"
+	pqUniqueViolation     = "23505"
+	pqExclusionViolation  = "23P01"
+)
+
+var appointmentColumns = []string{"id", "doctor_id", "patient_id", "start_time", "end_time", "status", "created_at", "updated_at"}
+
+var appointmentTransitionColumns = []string{"id", "appointment_id", "from_status", "to_status", "start_time", "end_time", "actor_id", "actor_role", "created_at"}
+
+// AppointmentRepository stores appointments and their history. Which changes are allowed is decided
+// by the caller, which also records each change with RecordTransition in the same transaction.
+type AppointmentRepository interface {
+	Create(ctx context.Context, appointment *domain.Appointment) error
+	GetAllPaginated(ctx context.Context, filters filter.AppointmentQueryParam, paginator *pagination.LimitOffsetPaginator[domain.Appointment]) ([]domain.Appointment, error)
+	Count(ctx context.Context, filters filter.AppointmentQueryParam) (int, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Appointment, error)
+	// UpdateStatus moves the appointment from its current status to the given one
+	UpdateStatus(ctx context.Context, appointment *domain.Appointment, to domain.AppointmentStatus) error
+	// UpdateTimeRange moves the appointment to a new time range, releasing the old one in the same update
+	UpdateTimeRange(ctx context.Context, appointment *domain.Appointment, startTime, endTime time.Time) error
+	// RecordTransition adds the appointment's current status and time range to its history;
+	// an empty from marks the booking
+	RecordTransition(ctx context.Context, actor auth.Principal, from domain.AppointmentStatus, appointment *domain.Appointment) error
+	GetTransitions(ctx context.Context, appointmentID uuid.UUID) ([]domain.AppointmentTransition, error)
+	GetActiveByDoctorBetween(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]domain.Appointment, error)
+}
+
+type appointmentRepository struct {
+	db database.Querier
+}
+
+// Create inserts the appointment and fills in the database generated fields
+func (r *appointmentRepository) Create(ctx context.Context, appointment *domain.Appointment) error {
+	if appointment.Status == "" {
+		appointment.Status = domain.AppointmentStatusPending
+	}
+
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("appointments")
+	ib.Cols("doctor_id", "patient_id", "start_time", "end_time", "status")
+	ib.Values(appointment.DoctorID, appointment.PatientID, appointment.StartTime, appointment.EndTime, appointment.Status)
+	ib.Returning("id", "created_at", "updated_at")
+
+	query, args := ib.Build()
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&appointment.ID, &appointment.CreatedAt, &appointment.UpdatedAt)
+	if err != nil {
+		return translateAppointmentError(err)
+	}
+	return nil
+}
+
+func (r *appointmentRepository) GetAllPaginated(ctx context.Context, filters filter.AppointmentQueryParam, paginator *pagination.LimitOffsetPaginator[domain.Appointment]) ([]domain.Appointment, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(appointmentColumns...)
+	sb.From("appointments")
+	sb = filters.Apply(sb)
+	if filters.Chronological() {
+		sb.OrderByAsc("start_time")
+	} else {
+		sb.OrderByDesc("start_time")
+	}
+
+	if err := paginator.Paginate(sb); err != nil {
+		return nil, err
+	}
+
+	query, args := sb.Build()
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %v", err)
+		}
+	}(rows)
+
+	return r.scanAppointments(rows)
+}
+
+func (r *appointmentRepository) Count(ctx context.Context, filters filter.AppointmentQueryParam) (int, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("count(*)")
+	sb.From("appointments")
+	filters.Apply(sb)
+
+	query, args := sb.Build()
+	var totalCount int
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&totalCount)
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan total count: %w", err)
+	}
+
+	return totalCount, nil
+}
+
+func (r *appointmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Appointment, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(appointmentColumns...)
+	sb.From("appointments")
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+	appointment, err := scanAppointment(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrAppointmentNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to scan appointment: %w", err)
+	}
+	return appointment, nil
+}
+
+// UpdateStatus changes the status only while it is still appointment.Status, so a concurrent change
+// fails with ErrInvalidTransition instead of being overwritten
+func (r *appointmentRepository) UpdateStatus(ctx context.Context, appointment *domain.Appointment, to domain.AppointmentStatus) error {
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("appointments")
+	ub.Set(ub.Assign("status", to))
+	ub.Where(
+		ub.Equal("id", appointment.ID),
+		ub.Equal("status", appointment.Status),
+	)
+	ub.Returning(appointmentColumns...)
+
+	query, args := ub.Build()
+	updated, err := scanAppointment(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.unchanged(ctx, appointment.ID)
+		}
+		return fmt.Errorf("failed to update appointment status: %w", err)
+	}
+
+	*appointment = *updated
+	return nil
+}
+
+// UpdateTimeRange changes the time range in a single update, so the exclusion constraint checks the new
+// range against the doctor's other appointments only and the old range is never held twice
+func (r *appointmentRepository) UpdateTimeRange(ctx context.Context, appointment *domain.Appointment, startTime, endTime time.Time) error {
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("appointments")
+	ub.Set(
+		ub.Assign("start_time", startTime),
+		ub.Assign("end_time", endTime),
+	)
+	ub.Where(
+		ub.Equal("id", appointment.ID),
+		ub.Equal("status", appointment.Status),
+	)
+	ub.Returning(appointmentColumns...)
+
+	query, args := ub.Build()
+	updated, err := scanAppointment(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return r.unchanged(ctx, appointment.ID)
+		case isConstraintViolation(err, pqExclusionViolation, "excl_appointments_doctor_time_range"):
+			return domain.ErrSlotTaken
+		}
+		return fmt.Errorf("failed to reschedule appointment: %w", err)
+	}
+
+	*appointment = *updated
+	return nil
+}
+
+// unchanged explains why a conditional update matched no row: the appointment is missing or its status changed
+func (r *appointmentRepository) unchanged(ctx context.Context, id uuid.UUID) error {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("status")
+	sb.From("appointments")
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+	var status domain.AppointmentStatus
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: %s", domain.ErrAppointmentNotFound, id)
+		}
+		return fmt.Errorf("failed to fetch appointment status: %w", err)
+	}
+	return fmt.Errorf("%w: %s is now %s", domain.ErrInvalidTransition, id, status)
+}
+
+// GetTransitions returns the history of the appointment, oldest first
+func (r *appointmentRepository) GetTransitions(ctx context.Context, appointmentID uuid.UUID) ([]domain.AppointmentTransition, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(appointmentTransitionColumns...)
+	sb.From("appointment_transitions")
+	sb.Where(sb.Equal("appointment_id", appointmentID))
+	sb.OrderByAsc("created_at")
+	sb.OrderByAsc("id")
+
+	query, args := sb.Build()
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch appointment history: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %v", err)
+		}
+	}(rows)
+
+	var transitions []domain.AppointmentTransition
+	for rows.Next() {
+		var transition domain.AppointmentTransition
+		var from sql.NullString
+		err := rows.Scan(
+			&transition.ID,
+			&transition.AppointmentID,
+			&from,
+			&transition.ToStatus,
+			&transition.StartTime,
+			&transition.EndTime,
+			&transition.ActorID,
+			&transition.ActorRole,
+			&transition.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan appointment transition: %w", err)
+		}
+		transition.FromStatus = domain.AppointmentStatus(from.String)
+		transitions = append(transitions, transition)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return transitions, nil
+}
+
+// GetActiveByDoctorBetween returns the doctor's appointments that still occupy a slot overlapping [from, to)
+func (r *appointmentRepository) GetActiveByDoctorBetween(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]domain.Appointment, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(appointmentColumns...)
+	sb.From("appointments")
+	sb.Where(
+		sb.Equal("doctor_id", doctorID),
+		sb.In("status", domain.AppointmentStatusPending, domain.AppointmentStatusConfirmed),
+		sb.LessThan("start_time", to),
+		sb.GreaterThan("end_time", from),
+	)
+	sb.OrderByAsc("start_time")
+
+	query, args := sb.Build()
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch doctor appointments: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %v", err)
+		}
+	}(rows)
+
+	return r.scanAppointments(rows)
+}
+
+func (r *appointmentRepository) scanAppointments(rows *sql.Rows) ([]domain.Appointment, error) {
+	var appointments []domain.Appointment
+	for rows.Next() {
+		appointment, err := scanAppointment(rows)
+		if err != nil {
+			return nil, err
+		}
+		appointments = append(appointments, *appointment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return appointments, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAppointment(row rowScanner) (*domain.Appointment, error) {
+	var appointment domain.Appointment
+	err := row.Scan(
+		&appointment.ID,
+		&appointment.DoctorID,
+		&appointment.PatientID,
+		&appointment.StartTime,
+		&appointment.EndTime,
+		&appointment.Status,
+		&appointment.CreatedAt,
+		&appointment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &appointment, nil
+}
+
+// RecordTransition stores an empty from as NULL
+func (r *appointmentRepository) RecordTransition(ctx context.Context, actor auth.Principal, from domain.AppointmentStatus, appointment *domain.Appointment) error {
+	var fromStatus any
+	if from != "" {
+		fromStatus = from
+	}
+
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("appointment_transitions")
+	ib.Cols("appointment_id", "from_status", "to_status", "start_time", "end_time", "actor_id", "actor_role")
+	ib.Values(appointment.ID, fromStatus, appointment.Status, appointment.StartTime, appointment.EndTime, actor.ID, actor.Role)
+
+	query, args := ib.Build()
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to record appointment transition: %w", err)
+	}
+	return nil
+}
+
+// translateAppointmentError maps known constraint violations to domain errors
+func translateAppointmentError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code == pqForeignKeyViolation && pqErr.Constraint == "fk_appointments_doctor_id":
+			return domain.ErrDoctorNotFound
+		case pqErr.Code == pqForeignKeyViolation && pqErr.Constraint == "fk_appointments_patient_id":
+			return domain.ErrPatientNotFound
+		case pqErr.Code == pqExclusionViolation && pqErr.Constraint == "excl_appointments_doctor_time_range":
+			return domain.ErrSlotTaken
+		}
+	}
+	return fmt.Errorf("failed to create appointment: %w", err)
+}
+
+func isConstraintViolation(err error, code pq.ErrorCode, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code && pqErr.Constraint == constraint
+}
+
+func NewAppointmentRepository(db database.Querier) AppointmentRepository {
+	return &appointmentRepository{db: db}
+}
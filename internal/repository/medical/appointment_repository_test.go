@@ -0,0 +1,448 @@
+package medical
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	filter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+)
+
+// Helper functions
+
+func newTestAppointment() medical.Appointment {
+	now := time.Now().Truncate(time.Second)
+	return medical.Appointment{
+		ID:        uuid.New(),
+		DoctorID:  uuid.New(),
+		PatientID: uuid.New(),
+		StartTime: now.Add(24 * time.Hour),
+		EndTime:   now.Add(24*time.Hour + 30*time.Minute),
+		Status:    medical.AppointmentStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func mockAppointmentRows(appointments ...medical.Appointment) *sqlmock.Rows {
+	rows := sqlmock.NewRows(appointmentColumns)
+	for _, a := range appointments {
+		rows.AddRow(a.ID, a.DoctorID, a.PatientID, a.StartTime,
+			a.EndTime, a.Status, a.CreatedAt, a.UpdatedAt)
+	}
+	return rows
+}
+
+const appointmentSelectQuery = `SELECT id, doctor_id, patient_id, start_time, end_time, status, created_at, updated_at FROM appointments`
+
+// Table-driven tests
+
+const transitionInsertQuery = `INSERT INTO appointment_transitions \(appointment_id, from_status, to_status, start_time, end_time, actor_id, actor_role\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\)`
+
+func TestAppointmentRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	appointment := newTestAppointment()
+	insertQuery := `INSERT INTO appointments \(doctor_id, patient_id, start_time, end_time, status\) VALUES \(\$1, \$2, \$3, \$4, \$5\) RETURNING id, created_at, updated_at`
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "created as pending",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(insertQuery).
+					WithArgs(appointment.DoctorID, appointment.PatientID, appointment.StartTime, appointment.EndTime, medical.AppointmentStatusPending).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
+						AddRow(appointment.ID, appointment.CreatedAt, appointment.UpdatedAt))
+			},
+		},
+		{
+			name: "unknown doctor",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(insertQuery).
+					WillReturnError(&pq.Error{Code: pqForeignKeyViolation, Constraint: "fk_appointments_doctor_id"})
+			},
+			wantErr: medical.ErrDoctorNotFound,
+		},
+		{
+			name: "unknown patient",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(insertQuery).
+					WillReturnError(&pq.Error{Code: pqForeignKeyViolation, Constraint: "fk_appointments_patient_id"})
+			},
+			wantErr: medical.ErrPatientNotFound,
+		},
+		{
+			name: "overlapping appointment",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(insertQuery).
+					WillReturnError(&pq.Error{Code: pqExclusionViolation, Constraint: "excl_appointments_doctor_time_range"})
+			},
+			wantErr: medical.ErrSlotTaken,
+		},
+		{
+			name: "database error",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(insertQuery).WillReturnError(errors.New("connection lost"))
+			},
+			wantErr: errors.New("connection lost"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			repo := NewAppointmentRepository(db)
+			tt.mockSetup(mock)
+
+			got := medical.Appointment{
+				DoctorID:  appointment.DoctorID,
+				PatientID: appointment.PatientID,
+				StartTime: appointment.StartTime,
+				EndTime:   appointment.EndTime,
+			}
+			err := repo.Create(ctx, &got)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, appointment.ID, got.ID)
+				assert.Equal(t, medical.AppointmentStatusPending, got.Status)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAppointmentRepository_Create_OnTransaction(t *testing.T) {
+	ctx := context.Background()
+	appointment := newTestAppointment()
+	insertQuery := `INSERT INTO appointments \(doctor_id, patient_id, start_time, end_time, status\) VALUES \(\$1, \$2, \$3, \$4, \$5\) RETURNING id, created_at, updated_at`
+
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	// The repository joins the caller's transaction instead of opening and committing its own
+	mock.ExpectBegin()
+	mock.ExpectQuery(insertQuery).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
+			AddRow(appointment.ID, appointment.CreatedAt, appointment.UpdatedAt))
+	mock.ExpectRollback()
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+
+	got := appointment
+	require.NoError(t, NewAppointmentRepository(tx).Create(ctx, &got))
+	require.NoError(t, tx.Rollback())
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAppointmentRepository_GetAllPaginated(t *testing.T) {
+	ctx := context.Background()
+	appointment := newTestAppointment()
+
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	params := pagination.LimitOffsetParams{Page: 1, Limit: 10, BaseURL: "http://localhost:8080/api/appointments"}
+	require.NoError(t, params.Validate())
+	paginator := pagination.NewLimitOffsetPaginator[medical.Appointment](params)
+
+	mock.ExpectQuery(
+		appointmentSelectQuery+` WHERE patient_id = \$1 AND status = \$2 ORDER BY start_time DESC, id ASC LIMIT \$3 OFFSET \$4`,
+	).WithArgs(appointment.PatientID.String(), "pending", 10, 0).WillReturnRows(mockAppointmentRows(appointment))
+
+	repo := NewAppointmentRepository(db)
+	got, err := repo.GetAllPaginated(ctx, filter.AppointmentQueryParam{PatientID: appointment.PatientID.String(), Status: "pending"}, paginator)
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, appointment.ID, got[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAppointmentRepository_GetByID(t *testing.T) {
+	ctx := context.Background()
+	appointment := newTestAppointment()
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "found",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(appointmentSelectQuery + ` WHERE id = \$1`).
+					WithArgs(appointment.ID).WillReturnRows(mockAppointmentRows(appointment))
+			},
+		},
+		{
+			name: "not found",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(appointmentSelectQuery + ` WHERE id = \$1`).
+					WithArgs(appointment.ID).WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: medical.ErrAppointmentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			repo := NewAppointmentRepository(db)
+			tt.mockSetup(mock)
+
+			got, err := repo.GetByID(ctx, appointment.ID)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, appointment.ID, got.ID)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAppointmentRepository_UpdateStatus(t *testing.T) {
+	ctx := context.Background()
+	appointment := newTestAppointment()
+	confirmed := appointment
+	confirmed.Status = medical.AppointmentStatusConfirmed
+
+	updateQuery := `UPDATE appointments SET status = \$1 WHERE id = \$2 AND status = \$3 RETURNING id, doctor_id, patient_id, start_time, end_time, status, created_at, updated_at`
+	statusQuery := `SELECT status FROM appointments WHERE id = \$1`
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "status is changed",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(updateQuery).
+					WithArgs(medical.AppointmentStatusConfirmed, appointment.ID, medical.AppointmentStatusPending).
+					WillReturnRows(mockAppointmentRows(confirmed))
+			},
+		},
+		{
+			name: "status changed concurrently",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(updateQuery).WillReturnError(sql.ErrNoRows)
+				m.ExpectQuery(statusQuery).WithArgs(appointment.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(medical.AppointmentStatusCancelledByPatient))
+			},
+			wantErr: medical.ErrInvalidTransition,
+		},
+		{
+			name: "missing appointment",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(updateQuery).WillReturnError(sql.ErrNoRows)
+				m.ExpectQuery(statusQuery).WithArgs(appointment.ID).WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: medical.ErrAppointmentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			repo := NewAppointmentRepository(db)
+			tt.mockSetup(mock)
+
+			got := appointment
+			err := repo.UpdateStatus(ctx, &got, medical.AppointmentStatusConfirmed)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, medical.AppointmentStatusPending, got.Status)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, medical.AppointmentStatusConfirmed, got.Status)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAppointmentRepository_UpdateTimeRange(t *testing.T) {
+	ctx := context.Background()
+	appointment := newTestAppointment()
+	newStart := appointment.StartTime.Add(2 * time.Hour)
+	newEnd := newStart.Add(30 * time.Minute)
+	moved := appointment
+	moved.StartTime, moved.EndTime = newStart, newEnd
+
+	updateQuery := `UPDATE appointments SET start_time = \$1, end_time = \$2 WHERE id = \$3 AND status = \$4 RETURNING id, doctor_id, patient_id, start_time, end_time, status, created_at, updated_at`
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "time range is moved",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(updateQuery).
+					WithArgs(newStart, newEnd, appointment.ID, medical.AppointmentStatusPending).
+					WillReturnRows(mockAppointmentRows(moved))
+			},
+		},
+		{
+			name: "new time overlaps another appointment",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(updateQuery).
+					WillReturnError(&pq.Error{Code: pqExclusionViolation, Constraint: "excl_appointments_doctor_time_range"})
+			},
+			wantErr: medical.ErrSlotTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			repo := NewAppointmentRepository(db)
+			tt.mockSetup(mock)
+
+			got := appointment
+			err := repo.UpdateTimeRange(ctx, &got, newStart, newEnd)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, appointment.StartTime, got.StartTime)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, newStart, got.StartTime)
+				assert.Equal(t, newEnd, got.EndTime)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAppointmentRepository_RecordTransition(t *testing.T) {
+	ctx := context.Background()
+	appointment := newTestAppointment()
+	confirmed := appointment
+	confirmed.Status = medical.AppointmentStatusConfirmed
+
+	tests := []struct {
+		name        string
+		actor       auth.Principal
+		from        medical.AppointmentStatus
+		appointment medical.Appointment
+		wantFrom    any
+	}{
+		{
+			name:        "booking is stored without a previous status",
+			actor:       auth.Principal{ID: appointment.PatientID, Role: auth.RolePatient},
+			appointment: appointment,
+			wantFrom:    nil,
+		},
+		{
+			name:        "status change",
+			actor:       auth.Principal{ID: appointment.DoctorID, Role: auth.RoleDoctor},
+			from:        medical.AppointmentStatusPending,
+			appointment: confirmed,
+			wantFrom:    medical.AppointmentStatusPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			mock.ExpectExec(transitionInsertQuery).
+				WithArgs(appointment.ID, tt.wantFrom, tt.appointment.Status, appointment.StartTime, appointment.EndTime, tt.actor.ID, tt.actor.Role).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			err := NewAppointmentRepository(db).RecordTransition(ctx, tt.actor, tt.from, &tt.appointment)
+
+			require.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAppointmentRepository_GetTransitions(t *testing.T) {
+	ctx := context.Background()
+	appointment := newTestAppointment()
+
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(
+		`SELECT id, appointment_id, from_status, to_status, start_time, end_time, actor_id, actor_role, created_at FROM appointment_transitions WHERE appointment_id = \$1 ORDER BY created_at ASC, id ASC`,
+	).WithArgs(appointment.ID).WillReturnRows(sqlmock.NewRows(appointmentTransitionColumns).
+		AddRow(uuid.New(), appointment.ID, nil, "pending", appointment.StartTime, appointment.EndTime, appointment.PatientID, "patient", appointment.CreatedAt).
+		AddRow(uuid.New(), appointment.ID, "pending", "confirmed", appointment.StartTime, appointment.EndTime, appointment.DoctorID, "doctor", appointment.CreatedAt))
+
+	got, err := NewAppointmentRepository(db).GetTransitions(ctx, appointment.ID)
+
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Empty(t, got[0].FromStatus)
+	assert.Equal(t, medical.AppointmentStatusPending, got[0].ToStatus)
+	assert.Equal(t, medical.AppointmentStatusPending, got[1].FromStatus)
+	assert.Equal(t, auth.RoleDoctor, got[1].ActorRole)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAppointmentRepository_GetAllPaginated_Upcoming(t *testing.T) {
+	ctx := context.Background()
+	appointment := newTestAppointment()
+
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	params := pagination.LimitOffsetParams{Page: 1, Limit: 10, BaseURL: "http://localhost:8080/api/doctor/appointments"}
+	require.NoError(t, params.Validate())
+	paginator := pagination.NewLimitOffsetPaginator[medical.Appointment](params)
+
+	from := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(
+		appointmentSelectQuery+` WHERE doctor_id = \$1 AND start_time >= \$2 ORDER BY start_time ASC, id ASC LIMIT \$3 OFFSET \$4`,
+	).WithArgs(appointment.DoctorID.String(), from, 10, 0).WillReturnRows(mockAppointmentRows(appointment))
+
+	repo := NewAppointmentRepository(db)
+	got, err := repo.GetAllPaginated(ctx, filter.AppointmentQueryParam{DoctorID: appointment.DoctorID.String(), From: "2025-03-03"}, paginator)
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
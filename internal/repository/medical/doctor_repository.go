@@ -9,27 +9,44 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/huandu/go-sqlbuilder"
+	"github.com/lib/pq"
 
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
 	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
 	filter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
 	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
 )
 
+var doctorColumns = []string{"id", "name", "specialty_id", "phone_number", "avatar_url", "description", "time_zone", "created_at", "updated_at"}
+
 type DoctorRepository interface {
-	GetAllPaginated(ctx context.Context, filters filter.DoctorQueryParam, paginator *pagination.LimitOffsetPaginator[domain.Doctor]) ([]domain.Doctor, error)
+	GetAllPaginated(ctx context.Context, filters filter.DoctorQueryParam, paginator pagination.Paginator[domain.Doctor]) ([]domain.Doctor, error)
 	Count(ctx context.Context, filters filter.DoctorQueryParam) (int, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Doctor, error)
+	GetDetailByID(ctx context.Context, id uuid.UUID) (*domain.DoctorDetail, error)
+	GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Doctor, error)
+	// GetByIDForUpdate returns the doctor and locks it until the surrounding transaction ends
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Doctor, error)
+	Create(ctx context.Context, doctor *domain.Doctor) error
+	// Update overwrites the editable fields of the doctor with the same id
+	Update(ctx context.Context, doctor *domain.Doctor) error
+	UpdateProfile(ctx context.Context, id uuid.UUID, update domain.DoctorProfileUpdate) (*domain.Doctor, error)
+	// Delete removes a doctor without appointments together with their schedules and time off
+	// and returns the doctor as it was
+	Delete(ctx context.Context, id uuid.UUID) (*domain.Doctor, error)
 }
 
 type doctorRepository struct {
-	db *sql.DB
+	db database.Querier
 }
 
-func (r *doctorRepository) GetAllPaginated(ctx context.Context, filters filter.DoctorQueryParam, paginator *pagination.LimitOffsetPaginator[domain.Doctor]) ([]domain.Doctor, error) {
+func (r *doctorRepository) GetAllPaginated(ctx context.Context, filters filter.DoctorQueryParam, paginator pagination.Paginator[domain.Doctor]) ([]domain.Doctor, error) {
 	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
-	sb.Select("id", "name", "specialty_id", "phone_number", "avatar_url", "description", "created_at", "updated_at")
+	sb.Select(doctorColumns...)
 	sb.From("doctors")
 	sb = filters.Apply(sb)
+	// Ranking goes first so the paginator's ordering only breaks ties between equally relevant doctors
+	sb = filters.Rank(sb)
 
 	if err := paginator.Paginate(sb); err != nil {
 		return nil, err
@@ -72,52 +89,209 @@ func (r *doctorRepository) Count(ctx context.Context, filters filter.DoctorQuery
 
 func (r *doctorRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Doctor, error) {
 	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
-	sb.Select("id", "name", "specialty_id", "phone_number", "avatar_url", "description", "created_at", "updated_at")
+	sb.Select(doctorColumns...)
+	sb.From("doctors")
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+	doc, err := scanDoctor(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrDoctorNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to scan doctor: %w", err)
+	}
+
+	return doc, nil
+}
+
+func (r *doctorRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Doctor, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(doctorColumns...)
+	sb.From("doctors")
+	sb.Where(sb.Equal("phone_number", phoneNumber))
+
+	query, args := sb.Build()
+	doc, err := scanDoctor(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrDoctorNotFound, phoneNumber)
+		}
+		return nil, fmt.Errorf("failed to scan doctor: %w", err)
+	}
+
+	return doc, nil
+}
+
+func (r *doctorRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Doctor, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(doctorColumns...)
 	sb.From("doctors")
 	sb.Where(sb.Equal("id", id))
+	sb.ForUpdate()
+
+	query, args := sb.Build()
+	doc, err := scanDoctor(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrDoctorNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to lock doctor: %w", err)
+	}
+
+	return doc, nil
+}
+
+// Create inserts the doctor and fills in the database generated fields
+func (r *doctorRepository) Create(ctx context.Context, doctor *domain.Doctor) error {
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("doctors")
+	ib.Cols("name", "specialty_id", "phone_number", "avatar_url", "description", "time_zone")
+	ib.Values(doctor.Name, doctor.SpecialtyID, doctor.PhoneNumber, doctor.AvatarURL, doctor.Description, doctor.TimeZone)
+	ib.Returning(doctorColumns...)
+
+	query, args := ib.Build()
+	created, err := scanDoctor(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return translateDoctorWriteError(err, doctor, "failed to create doctor")
+	}
+
+	*doctor = *created
+	return nil
+}
+
+func (r *doctorRepository) Update(ctx context.Context, doctor *domain.Doctor) error {
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("doctors")
+	ub.Set(
+		ub.Assign("name", doctor.Name),
+		ub.Assign("specialty_id", doctor.SpecialtyID),
+		ub.Assign("phone_number", doctor.PhoneNumber),
+		ub.Assign("avatar_url", doctor.AvatarURL),
+		ub.Assign("description", doctor.Description),
+		ub.Assign("time_zone", doctor.TimeZone),
+	)
+	ub.Where(ub.Equal("id", doctor.ID))
+	ub.Returning(doctorColumns...)
+
+	query, args := ub.Build()
+	updated, err := scanDoctor(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return translateDoctorWriteError(err, doctor, "failed to update doctor")
+	}
+
+	*doctor = *updated
+	return nil
+}
+
+// UpdateProfile changes the non-nil fields of update and returns the updated doctor
+func (r *doctorRepository) UpdateProfile(ctx context.Context, id uuid.UUID, update domain.DoctorProfileUpdate) (*domain.Doctor, error) {
+	if update == (domain.DoctorProfileUpdate{}) {
+		return r.GetByID(ctx, id)
+	}
+
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("doctors")
+	if update.Description != nil {
+		ub.SetMore(ub.Assign("description", *update.Description))
+	}
+	if update.AvatarURL != nil {
+		ub.SetMore(ub.Assign("avatar_url", *update.AvatarURL))
+	}
+	if update.PhoneNumber != nil {
+		ub.SetMore(ub.Assign("phone_number", *update.PhoneNumber))
+	}
+	if update.TimeZone != nil {
+		ub.SetMore(ub.Assign("time_zone", *update.TimeZone))
+	}
+	ub.Where(ub.Equal("id", id))
+	ub.Returning(doctorColumns...)
+
+	query, args := ub.Build()
+	doc, err := scanDoctor(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		var pqErr *pq.Error
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, fmt.Errorf("%w: %s", domain.ErrDoctorNotFound, id)
+		case errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation && pqErr.Constraint == "uq_doctors_phone_number":
+			return nil, domain.ErrPhoneNumberTaken
+		}
+		return nil, fmt.Errorf("failed to update doctor profile: %w", err)
+	}
+
+	return doc, nil
+}
+
+func (r *doctorRepository) Delete(ctx context.Context, id uuid.UUID) (*domain.Doctor, error) {
+	del := sqlbuilder.PostgreSQL.NewDeleteBuilder()
+	del.DeleteFrom("doctors")
+	del.Where(del.Equal("id", id))
+	del.Returning(doctorColumns...)
+
+	query, args := del.Build()
+	deleted, err := scanDoctor(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, fmt.Errorf("%w: %s", domain.ErrDoctorNotFound, id)
+		case isConstraintViolation(err, pqForeignKeyViolation, "fk_appointments_doctor_id"):
+			return nil, domain.ErrDoctorHasAppointments
+		}
+		return nil, fmt.Errorf("failed to delete doctor: %w", err)
+	}
+	return deleted, nil
+}
+
+// GetDetailByID returns the doctor joined with its specialty
+func (r *doctorRepository) GetDetailByID(ctx context.Context, id uuid.UUID) (*domain.DoctorDetail, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(
+		"doctors.id", "doctors.name", "doctors.specialty_id", "doctors.phone_number", "doctors.avatar_url",
+		"doctors.description", "doctors.time_zone", "doctors.created_at", "doctors.updated_at",
+		"specialties.id", "specialties.name", "specialties.created_at", "specialties.updated_at",
+	)
+	sb.From("doctors")
+	sb.Join("specialties", "specialties.id = doctors.specialty_id")
+	sb.Where(sb.Equal("doctors.id", id))
 
 	query, args := sb.Build()
 	row := r.db.QueryRowContext(ctx, query, args...)
 
-	var doc domain.Doctor
+	var detail domain.DoctorDetail
 	err := row.Scan(
-		&doc.ID,
-		&doc.Name,
-		&doc.SpecialtyID,
-		&doc.PhoneNumber,
-		&doc.AvatarURL,
-		&doc.Description,
-		&doc.CreatedAt,
-		&doc.UpdatedAt,
+		&detail.ID,
+		&detail.Name,
+		&detail.SpecialtyID,
+		&detail.PhoneNumber,
+		&detail.AvatarURL,
+		&detail.Description,
+		&detail.TimeZone,
+		&detail.CreatedAt,
+		&detail.UpdatedAt,
+		&detail.Specialty.ID,
+		&detail.Specialty.Name,
+		&detail.Specialty.CreatedAt,
+		&detail.Specialty.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("doctor not found: %s", id)
+			return nil, fmt.Errorf("%w: %s", domain.ErrDoctorNotFound, id)
 		}
 		return nil, fmt.Errorf("failed to scan doctor: %w", err)
 	}
 
-	return &doc, nil
+	return &detail, nil
 }
 
 func (r *doctorRepository) scanDoctors(rows *sql.Rows) ([]domain.Doctor, error) {
 	var doctors []domain.Doctor
 	for rows.Next() {
-		var doc domain.Doctor
-		err := rows.Scan(
-			&doc.ID,
-			&doc.Name,
-			&doc.SpecialtyID,
-			&doc.PhoneNumber,
-			&doc.AvatarURL,
-			&doc.Description,
-			&doc.CreatedAt,
-			&doc.UpdatedAt,
-		)
+		doc, err := scanDoctor(rows)
 		if err != nil {
 			return nil, err
 		}
-		doctors = append(doctors, doc)
+		doctors = append(doctors, *doc)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -127,6 +301,38 @@ func (r *doctorRepository) scanDoctors(rows *sql.Rows) ([]domain.Doctor, error)
 	return doctors, nil
 }
 
-func NewDoctorRepository(db *sql.DB) DoctorRepository {
+func scanDoctor(row rowScanner) (*domain.Doctor, error) {
+	var doc domain.Doctor
+	err := row.Scan(
+		&doc.ID,
+		&doc.Name,
+		&doc.SpecialtyID,
+		&doc.PhoneNumber,
+		&doc.AvatarURL,
+		&doc.Description,
+		&doc.TimeZone,
+		&doc.CreatedAt,
+		&doc.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// translateDoctorWriteError maps constraint violations of a doctor insert or update to domain errors
+func translateDoctorWriteError(err error, doctor *domain.Doctor, msg string) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("%w: %s", domain.ErrDoctorNotFound, doctor.ID)
+	case isConstraintViolation(err, pqForeignKeyViolation, "fk_doctors_specialty_id"):
+		return fmt.Errorf("%w: %s", domain.ErrSpecialtyNotFound, doctor.SpecialtyID)
+	case isConstraintViolation(err, pqUniqueViolation, "uq_doctors_phone_number"):
+		return domain.ErrPhoneNumberTaken
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func NewDoctorRepository(db database.Querier) DoctorRepository {
 	return &doctorRepository{db: db}
 }
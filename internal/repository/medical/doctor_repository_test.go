@@ -9,9 +9,11 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/shayesteh1hs/DrAppointment/internal/domain"
 	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
 	filter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
 	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
@@ -35,6 +37,7 @@ func newTestDoctor(name string) medical.Doctor {
 		PhoneNumber: "1234567890",
 		AvatarURL:   "avatar.jpg",
 		Description: "Test description",
+		TimeZone:    medical.DefaultTimeZone,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -54,11 +57,11 @@ func newTestPaginator(t *testing.T, page, limit int) *pagination.LimitOffsetPagi
 func mockDoctorRows(doctors ...medical.Doctor) *sqlmock.Rows {
 	rows := sqlmock.NewRows([]string{
 		"id", "name", "specialty_id", "phone_number",
-		"avatar_url", "description", "created_at", "updated_at",
+		"avatar_url", "description", "time_zone", "created_at", "updated_at",
 	})
 	for _, d := range doctors {
 		rows.AddRow(d.ID, d.Name, d.SpecialtyID, d.PhoneNumber,
-			d.AvatarURL, d.Description, d.CreatedAt, d.UpdatedAt)
+			d.AvatarURL, d.Description, d.TimeZone, d.CreatedAt, d.UpdatedAt)
 	}
 	return rows
 }
@@ -71,6 +74,7 @@ func assertDoctorEqual(t *testing.T, expected, actual medical.Doctor) {
 	assert.Equal(t, expected.PhoneNumber, actual.PhoneNumber)
 	assert.Equal(t, expected.AvatarURL, actual.AvatarURL)
 	assert.Equal(t, expected.Description, actual.Description)
+	assert.Equal(t, expected.TimeZone, actual.TimeZone)
 	assert.Equal(t, expected.CreatedAt.Unix(), actual.CreatedAt.Unix())
 	assert.Equal(t, expected.UpdatedAt.Unix(), actual.UpdatedAt.Unix())
 }
@@ -83,7 +87,7 @@ func TestDoctorRepository_GetAllPaginated(t *testing.T) {
 	doctor2 := newTestDoctor("Dr. Jane Johnson")
 
 	// Base query for selecting doctor fields
-	baseSelectQuery := `SELECT id, name, specialty_id, phone_number, avatar_url, description, created_at, updated_at FROM doctors`
+	baseSelectQuery := `SELECT id, name, specialty_id, phone_number, avatar_url, description, time_zone, created_at, updated_at FROM doctors`
 
 	tests := []struct {
 		name      string
@@ -101,7 +105,7 @@ func TestDoctorRepository_GetAllPaginated(t *testing.T) {
 			limit:  10,
 			mockSetup: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(
-					baseSelectQuery+` LIMIT \$1 OFFSET \$2`,
+					baseSelectQuery+` ORDER BY id ASC LIMIT \$1 OFFSET \$2`,
 				).WithArgs(10, 0).WillReturnRows(mockDoctorRows(doctor1, doctor2))
 			},
 			want: []medical.Doctor{doctor1, doctor2},
@@ -113,32 +117,32 @@ func TestDoctorRepository_GetAllPaginated(t *testing.T) {
 			limit:  10,
 			mockSetup: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(
-					baseSelectQuery+` WHERE name LIKE \$1 LIMIT \$2 OFFSET \$3`,
-				).WithArgs("%John%", 10, 0).WillReturnRows(mockDoctorRows(doctor1))
+					baseSelectQuery+` WHERE name_search LIKE \$1 ORDER BY id ASC LIMIT \$2 OFFSET \$3`,
+				).WithArgs("%john%", 10, 0).WillReturnRows(mockDoctorRows(doctor1))
 			},
 			want: []medical.Doctor{doctor1},
 		},
 		{
 			name:   "specialty filter",
-			filter: filter.DoctorQueryParam{SpecialtyID: doctor1.SpecialtyID},
+			filter: filter.DoctorQueryParam{SpecialtyID: doctor1.SpecialtyID.String()},
 			page:   1,
 			limit:  10,
 			mockSetup: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(
-					baseSelectQuery+` WHERE specialty_id = \$1 LIMIT \$2 OFFSET \$3`,
+					baseSelectQuery+` WHERE specialty_id = \$1 ORDER BY id ASC LIMIT \$2 OFFSET \$3`,
 				).WithArgs(doctor1.SpecialtyID.String(), 10, 0).WillReturnRows(mockDoctorRows(doctor1))
 			},
 			want: []medical.Doctor{doctor1},
 		},
 		{
 			name:   "multiple filters",
-			filter: filter.DoctorQueryParam{Name: "John", SpecialtyID: doctor1.SpecialtyID},
+			filter: filter.DoctorQueryParam{Name: "John", SpecialtyID: doctor1.SpecialtyID.String()},
 			page:   1,
 			limit:  10,
 			mockSetup: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(
-					baseSelectQuery+` WHERE name LIKE \$1 AND specialty_id = \$2 LIMIT \$3 OFFSET \$4`,
-				).WithArgs("%John%", doctor1.SpecialtyID.String(), 10, 0).WillReturnRows(mockDoctorRows(doctor1))
+					baseSelectQuery+` WHERE name_search LIKE \$1 AND specialty_id = \$2 ORDER BY id ASC LIMIT \$3 OFFSET \$4`,
+				).WithArgs("%john%", doctor1.SpecialtyID.String(), 10, 0).WillReturnRows(mockDoctorRows(doctor1))
 			},
 			want: []medical.Doctor{doctor1},
 		},
@@ -149,8 +153,8 @@ func TestDoctorRepository_GetAllPaginated(t *testing.T) {
 			limit:  10,
 			mockSetup: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(
-					baseSelectQuery+` WHERE name LIKE \$1 LIMIT \$2 OFFSET \$3`,
-				).WithArgs("%NonExistent%", 10, 0).WillReturnRows(mockDoctorRows())
+					baseSelectQuery+` WHERE name_search LIKE \$1 ORDER BY id ASC LIMIT \$2 OFFSET \$3`,
+				).WithArgs("%nonexistent%", 10, 0).WillReturnRows(mockDoctorRows())
 			},
 			want: []medical.Doctor{},
 		},
@@ -161,7 +165,7 @@ func TestDoctorRepository_GetAllPaginated(t *testing.T) {
 			limit:  10,
 			mockSetup: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(
-					baseSelectQuery+` LIMIT \$1 OFFSET \$2`,
+					baseSelectQuery+` ORDER BY id ASC LIMIT \$1 OFFSET \$2`,
 				).WithArgs(10, 0).WillReturnError(errors.New("connection lost"))
 			},
 			wantErr: "connection lost",
@@ -173,7 +177,7 @@ func TestDoctorRepository_GetAllPaginated(t *testing.T) {
 			limit:  10,
 			mockSetup: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(
-					baseSelectQuery+` LIMIT \$1 OFFSET \$2`,
+					baseSelectQuery+` ORDER BY id ASC LIMIT \$1 OFFSET \$2`,
 				).WithArgs(10, 0).WillReturnError(context.Canceled)
 			},
 			wantErr: "context canceled",
@@ -185,7 +189,7 @@ func TestDoctorRepository_GetAllPaginated(t *testing.T) {
 			limit:  5,
 			mockSetup: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(
-					baseSelectQuery+` LIMIT \$1 OFFSET \$2`,
+					baseSelectQuery+` ORDER BY id ASC LIMIT \$1 OFFSET \$2`,
 				).WithArgs(5, 5).WillReturnRows(mockDoctorRows(doctor2))
 			},
 			want: []medical.Doctor{doctor2},
@@ -221,6 +225,79 @@ func TestDoctorRepository_GetAllPaginated(t *testing.T) {
 	}
 }
 
+func TestDoctorRepository_GetAllPaginated_Cursor(t *testing.T) {
+	ctx := context.Background()
+	doctor := newTestDoctor("Dr. John Smith")
+
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	params := pagination.CursorParams{
+		Direction: "next",
+		Limit:     10,
+		BaseURL:   "http://localhost:8080/api/doctors?pagination=cursor",
+	}
+	require.NoError(t, params.Validate())
+	paginator := pagination.NewCursorPaginator[medical.Doctor](params)
+
+	mock.ExpectQuery(
+		`SELECT id, name, specialty_id, phone_number, avatar_url, description, time_zone, created_at, updated_at FROM doctors WHERE name_search LIKE \$1 ORDER BY id ASC LIMIT \$2`,
+	).WithArgs("%john%", 11).WillReturnRows(mockDoctorRows(doctor))
+
+	got, err := NewDoctorRepository(db).GetAllPaginated(ctx, filter.DoctorQueryParam{Name: "John"}, paginator)
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assertDoctorEqual(t, doctor, got[0])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDoctorRepository_GetAllPaginated_Ordering(t *testing.T) {
+	ctx := context.Background()
+	doctor := newTestDoctor("Dr. John Smith")
+
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	params := pagination.LimitOffsetParams{
+		Page:       1,
+		Limit:      10,
+		BaseURL:    "http://localhost:8080/api/doctors",
+		Ordering:   "name,-created_at",
+		SortFields: medical.DoctorSortFields,
+	}
+	require.NoError(t, params.Validate())
+	paginator := pagination.NewLimitOffsetPaginator[medical.Doctor](params)
+
+	mock.ExpectQuery(
+		`SELECT id, name, specialty_id, phone_number, avatar_url, description, time_zone, created_at, updated_at FROM doctors ORDER BY name ASC, created_at DESC, id ASC LIMIT \$1 OFFSET \$2`,
+	).WithArgs(10, 0).WillReturnRows(mockDoctorRows(doctor))
+
+	got, err := NewDoctorRepository(db).GetAllPaginated(ctx, filter.DoctorQueryParam{}, paginator)
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDoctorRepository_GetAllPaginated_Search(t *testing.T) {
+	ctx := context.Background()
+	doctor := newTestDoctor("Dr. John Smith")
+
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(
+		`SELECT id, name, specialty_id, phone_number, avatar_url, description, time_zone, created_at, updated_at FROM doctors WHERE \(name_search LIKE \$1 OR \$2 <% name_search\) ORDER BY word_similarity\(\$3, name_search\) DESC, id ASC LIMIT \$4 OFFSET \$5`,
+	).WithArgs("%jon smith%", "jon smith", "jon smith", 10, 0).WillReturnRows(mockDoctorRows(doctor))
+
+	got, err := NewDoctorRepository(db).GetAllPaginated(ctx, filter.DoctorQueryParam{Search: "Jon  Smith"}, newTestPaginator(t, 1, 10))
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDoctorRepository_Count(t *testing.T) {
 	ctx := context.Background()
 	specialtyID := uuid.New()
@@ -245,15 +322,15 @@ func TestDoctorRepository_Count(t *testing.T) {
 			name:   "name filter",
 			filter: filter.DoctorQueryParam{Name: "John"},
 			mockSetup: func(m sqlmock.Sqlmock) {
-				m.ExpectQuery(`SELECT count\(\*\) FROM doctors WHERE name LIKE \$1`).
-					WithArgs("%John%").
+				m.ExpectQuery(`SELECT count\(\*\) FROM doctors WHERE name_search LIKE \$1`).
+					WithArgs("%john%").
 					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
 			},
 			want: 3,
 		},
 		{
 			name:   "specialty filter",
-			filter: filter.DoctorQueryParam{SpecialtyID: specialtyID},
+			filter: filter.DoctorQueryParam{SpecialtyID: specialtyID.String()},
 			mockSetup: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(`SELECT count\(\*\) FROM doctors WHERE specialty_id = \$1`).
 					WithArgs(specialtyID.String()).
@@ -265,8 +342,8 @@ func TestDoctorRepository_Count(t *testing.T) {
 			name:   "zero result",
 			filter: filter.DoctorQueryParam{Name: "NonExistent"},
 			mockSetup: func(m sqlmock.Sqlmock) {
-				m.ExpectQuery(`SELECT count\(\*\) FROM doctors WHERE name LIKE \$1`).
-					WithArgs("%NonExistent%").
+				m.ExpectQuery(`SELECT count\(\*\) FROM doctors WHERE name_search LIKE \$1`).
+					WithArgs("%nonexistent%").
 					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 			},
 			want: 0,
@@ -311,7 +388,7 @@ func TestDoctorRepository_GetByID(t *testing.T) {
 	doctor := newTestDoctor("Dr. John Smith")
 
 	// Base query for selecting doctor fields
-	baseSelectQuery := `SELECT id, name, specialty_id, phone_number, avatar_url, description, created_at, updated_at FROM doctors`
+	baseSelectQuery := `SELECT id, name, specialty_id, phone_number, avatar_url, description, time_zone, created_at, updated_at FROM doctors`
 
 	tests := []struct {
 		name      string
@@ -386,3 +463,261 @@ func TestDoctorRepository_GetByID(t *testing.T) {
 		})
 	}
 }
+
+func TestDoctorRepository_GetDetailByID(t *testing.T) {
+	ctx := context.Background()
+	doctor := newTestDoctor("Dr. John Smith")
+	now := time.Now().Truncate(time.Second)
+
+	detailQuery := `SELECT doctors.id, doctors.name, doctors.specialty_id, doctors.phone_number, doctors.avatar_url, doctors.description, doctors.time_zone, doctors.created_at, doctors.updated_at, specialties.id, specialties.name, specialties.created_at, specialties.updated_at FROM doctors JOIN specialties ON specialties.id = doctors.specialty_id WHERE doctors.id = \$1`
+	columns := []string{
+		"id", "name", "specialty_id", "phone_number", "avatar_url", "description", "time_zone", "created_at", "updated_at",
+		"id", "name", "created_at", "updated_at",
+	}
+
+	t.Run("found", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		defer db.Close()
+
+		mock.ExpectQuery(detailQuery).WithArgs(doctor.ID).WillReturnRows(sqlmock.NewRows(columns).AddRow(
+			doctor.ID, doctor.Name, doctor.SpecialtyID, doctor.PhoneNumber, doctor.AvatarURL, doctor.Description, doctor.TimeZone, doctor.CreatedAt, doctor.UpdatedAt,
+			doctor.SpecialtyID, "Cardiology", now, now,
+		))
+
+		got, err := NewDoctorRepository(db).GetDetailByID(ctx, doctor.ID)
+
+		require.NoError(t, err)
+		assertDoctorEqual(t, doctor, got.Doctor)
+		assert.Equal(t, doctor.SpecialtyID, got.Specialty.ID)
+		assert.Equal(t, "Cardiology", got.Specialty.Name)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		defer db.Close()
+
+		mock.ExpectQuery(detailQuery).WithArgs(doctor.ID).WillReturnError(sql.ErrNoRows)
+
+		got, err := NewDoctorRepository(db).GetDetailByID(ctx, doctor.ID)
+
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, medical.ErrDoctorNotFound)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		defer db.Close()
+
+		mock.ExpectQuery(detailQuery).WithArgs(doctor.ID).WillReturnError(errors.New("connection lost"))
+
+		got, err := NewDoctorRepository(db).GetDetailByID(ctx, doctor.ID)
+
+		assert.Nil(t, got)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, domain.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDoctorRepository_UpdateProfile(t *testing.T) {
+	ctx := context.Background()
+	doctor := newTestDoctor("Dr. John Smith")
+	description := "Pediatrician"
+	phone := "+989121234567"
+	timeZone := "Asia/Dubai"
+
+	tests := []struct {
+		name      string
+		update    medical.DoctorProfileUpdate
+		mockSetup func(sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name:   "only given fields are updated",
+			update: medical.DoctorProfileUpdate{Description: &description, PhoneNumber: &phone},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(
+					`UPDATE doctors SET description = \$1, phone_number = \$2 WHERE id = \$3 RETURNING id, name, specialty_id, phone_number, avatar_url, description, time_zone, created_at, updated_at`,
+				).WithArgs(description, phone, doctor.ID).WillReturnRows(mockDoctorRows(doctor))
+			},
+		},
+		{
+			name:   "time zone is updated",
+			update: medical.DoctorProfileUpdate{TimeZone: &timeZone},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`UPDATE doctors SET time_zone = \$1 WHERE id = \$2`).
+					WithArgs(timeZone, doctor.ID).WillReturnRows(mockDoctorRows(doctor))
+			},
+		},
+		{
+			name:   "empty update reads the doctor",
+			update: medical.DoctorProfileUpdate{},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`SELECT .* FROM doctors WHERE id = \$1`).WithArgs(doctor.ID).WillReturnRows(mockDoctorRows(doctor))
+			},
+		},
+		{
+			name:   "phone number of another doctor",
+			update: medical.DoctorProfileUpdate{PhoneNumber: &phone},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`UPDATE doctors SET phone_number = \$1 WHERE id = \$2`).
+					WillReturnError(&pq.Error{Code: pqUniqueViolation, Constraint: "uq_doctors_phone_number"})
+			},
+			wantErr: medical.ErrPhoneNumberTaken,
+		},
+		{
+			name:   "missing doctor",
+			update: medical.DoctorProfileUpdate{Description: &description},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`UPDATE doctors SET description = \$1 WHERE id = \$2`).WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: medical.ErrDoctorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			repo := NewDoctorRepository(db)
+			tt.mockSetup(mock)
+
+			got, err := repo.UpdateProfile(ctx, doctor.ID, tt.update)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assertDoctorEqual(t, doctor, *got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDoctorRepository_GetByPhoneNumber(t *testing.T) {
+	ctx := context.Background()
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT .* FROM doctors WHERE phone_number = \$1`).
+		WithArgs("+989121234567").WillReturnError(sql.ErrNoRows)
+
+	_, err := NewDoctorRepository(db).GetByPhoneNumber(ctx, "+989121234567")
+
+	assert.ErrorIs(t, err, medical.ErrDoctorNotFound)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDoctorRepository_Update(t *testing.T) {
+	ctx := context.Background()
+	before := newTestDoctor("Dr. John Smith")
+	after := before
+	after.Name = "Dr. John A. Smith"
+	updateQuery := `UPDATE doctors SET name = \$1, specialty_id = \$2, phone_number = \$3, avatar_url = \$4, description = \$5, time_zone = \$6 WHERE id = \$7 RETURNING id, name, specialty_id, phone_number, avatar_url, description, time_zone, created_at, updated_at`
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "doctor is updated",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(updateQuery).
+					WithArgs(after.Name, after.SpecialtyID, after.PhoneNumber, after.AvatarURL, after.Description, after.TimeZone, after.ID).
+					WillReturnRows(mockDoctorRows(after))
+			},
+		},
+		{
+			name: "unknown specialty",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(updateQuery).
+					WithArgs(after.Name, after.SpecialtyID, after.PhoneNumber, after.AvatarURL, after.Description, after.TimeZone, after.ID).
+					WillReturnError(&pq.Error{Code: pqForeignKeyViolation, Constraint: "fk_doctors_specialty_id"})
+			},
+			wantErr: medical.ErrSpecialtyNotFound,
+		},
+		{
+			name: "missing doctor",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(updateQuery).WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: medical.ErrDoctorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			repo := NewDoctorRepository(db)
+			tt.mockSetup(mock)
+
+			doctor := after
+			err := repo.Update(ctx, &doctor)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assertDoctorEqual(t, after, doctor)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDoctorRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	doctorID := uuid.New()
+	deleteQuery := `DELETE FROM doctors WHERE id = \$1 RETURNING id, name, specialty_id, phone_number, avatar_url, description, time_zone, created_at, updated_at`
+
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	mock.ExpectQuery(deleteQuery).WithArgs(doctorID).
+		WillReturnError(&pq.Error{Code: pqForeignKeyViolation, Constraint: "fk_appointments_doctor_id"})
+
+	_, err := NewDoctorRepository(db).Delete(ctx, doctorID)
+
+	assert.ErrorIs(t, err, medical.ErrDoctorHasAppointments)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDoctorRepository_GetByIDForUpdate(t *testing.T) {
+	ctx := context.Background()
+	doctor := newTestDoctor("Dr. John Smith")
+	lockQuery := `SELECT id, name, specialty_id, phone_number, avatar_url, description, time_zone, created_at, updated_at FROM doctors WHERE id = \$1 FOR UPDATE`
+
+	t.Run("doctor is locked", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		defer db.Close()
+		mock.ExpectQuery(lockQuery).WithArgs(doctor.ID).WillReturnRows(mockDoctorRows(doctor))
+
+		got, err := NewDoctorRepository(db).GetByIDForUpdate(ctx, doctor.ID)
+
+		require.NoError(t, err)
+		assertDoctorEqual(t, doctor, *got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing doctor", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		defer db.Close()
+		mock.ExpectQuery(lockQuery).WithArgs(doctor.ID).WillReturnError(sql.ErrNoRows)
+
+		_, err := NewDoctorRepository(db).GetByIDForUpdate(ctx, doctor.ID)
+
+		assert.ErrorIs(t, err, medical.ErrDoctorNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
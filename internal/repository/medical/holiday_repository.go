@@ -0,0 +1,95 @@
+package medical
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+var holidayColumns = []string{"id", "date", "name", "created_at", "updated_at"}
+
+type HolidayRepository interface {
+	GetBetween(ctx context.Context, from, to time.Time) ([]domain.Holiday, error)
+	Upsert(ctx context.Context, holidays []domain.Holiday) (int64, error)
+}
+
+type holidayRepository struct {
+	db database.Querier
+}
+
+// GetBetween returns the holidays whose date lies within the inclusive [from, to] day range
+func (r *holidayRepository) GetBetween(ctx context.Context, from, to time.Time) ([]domain.Holiday, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(holidayColumns...)
+	sb.From("holidays")
+	sb.Where(sb.Between("date", from.Format(time.DateOnly), to.Format(time.DateOnly)))
+	sb.OrderByAsc("date")
+
+	query, args := sb.Build()
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch holidays: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %v", err)
+		}
+	}(rows)
+
+	var holidays []domain.Holiday
+	for rows.Next() {
+		var holiday domain.Holiday
+		err := rows.Scan(
+			&holiday.ID,
+			&holiday.Date,
+			&holiday.Name,
+			&holiday.CreatedAt,
+			&holiday.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, holiday)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return holidays, nil
+}
+
+// Upsert inserts the holidays, renaming any that already exist for the same date
+func (r *holidayRepository) Upsert(ctx context.Context, holidays []domain.Holiday) (int64, error) {
+	if len(holidays) == 0 {
+		return 0, nil
+	}
+
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("holidays")
+	ib.Cols("date", "name")
+	for _, holiday := range holidays {
+		ib.Values(holiday.Date.Format(time.DateOnly), holiday.Name)
+	}
+	ib.SQL("ON CONFLICT (date) DO UPDATE SET name = EXCLUDED.name")
+
+	query, args := ib.Build()
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to upsert holidays: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func NewHolidayRepository(db database.Querier) HolidayRepository {
+	return &holidayRepository{db: db}
+}
@@ -0,0 +1,101 @@
+package medical
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+func TestHolidayRepository_GetBetween(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	from := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)
+	nowruz := time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC)
+
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT id, date, name, created_at, updated_at FROM holidays WHERE date BETWEEN \$1 AND \$2 ORDER BY date ASC`).
+		WithArgs("2025-03-01", "2025-03-31").
+		WillReturnRows(sqlmock.NewRows(holidayColumns).AddRow(uuid.New(), nowruz, "Nowruz", now, now))
+
+	got, err := NewHolidayRepository(db).GetBetween(ctx, from, to)
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "Nowruz", got[0].Name)
+	assert.True(t, nowruz.Equal(got[0].Date))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHolidayRepository_Upsert(t *testing.T) {
+	ctx := context.Background()
+	holidays := []medical.Holiday{
+		{Date: time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC), Name: "Nowruz"},
+		{Date: time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC), Name: "Islamic Republic Day"},
+	}
+	upsertQuery := `INSERT INTO holidays \(date, name\) VALUES \(\$1, \$2\), \(\$3, \$4\) ON CONFLICT \(date\) DO UPDATE SET name = EXCLUDED.name`
+
+	tests := []struct {
+		name      string
+		holidays  []medical.Holiday
+		mockSetup func(sqlmock.Sqlmock)
+		want      int64
+		wantErr   string
+	}{
+		{
+			name:     "holidays are upserted",
+			holidays: holidays,
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(upsertQuery).
+					WithArgs("2025-03-20", "Nowruz", "2025-04-01", "Islamic Republic Day").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			want: 2,
+		},
+		{
+			name:      "empty list is a no-op",
+			holidays:  nil,
+			mockSetup: func(m sqlmock.Sqlmock) {},
+			want:      0,
+		},
+		{
+			name:     "database error",
+			holidays: holidays,
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(upsertQuery).WillReturnError(errors.New("connection lost"))
+			},
+			wantErr: "connection lost",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			got, err := NewHolidayRepository(db).Upsert(ctx, tt.holidays)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
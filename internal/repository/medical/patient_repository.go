@@ -0,0 +1,107 @@
+package medical
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+var patientColumns = []string{"id", "phone_number", "name", "created_at", "updated_at"}
+
+type PatientRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Patient, error)
+	GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Patient, error)
+	// GetOrCreateByPhoneNumber returns the patient registered with the phone number, creating it on first login
+	GetOrCreateByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Patient, error)
+}
+
+type patientRepository struct {
+	db database.Querier
+}
+
+func (r *patientRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Patient, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(patientColumns...)
+	sb.From("patients")
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+	patient, err := scanPatient(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrPatientNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to scan patient: %w", err)
+	}
+	return patient, nil
+}
+
+func (r *patientRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Patient, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(patientColumns...)
+	sb.From("patients")
+	sb.Where(sb.Equal("phone_number", phoneNumber))
+
+	query, args := sb.Build()
+	patient, err := scanPatient(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrPatientNotFound, phoneNumber)
+		}
+		return nil, fmt.Errorf("failed to scan patient: %w", err)
+	}
+	return patient, nil
+}
+
+func (r *patientRepository) GetOrCreateByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Patient, error) {
+	patient, err := r.GetByPhoneNumber(ctx, phoneNumber)
+	if err == nil || !errors.Is(err, domain.ErrPatientNotFound) {
+		return patient, err
+	}
+
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("patients")
+	ib.Cols("phone_number")
+	ib.Values(phoneNumber)
+	// DO NOTHING rather than a no-op update, so existing rows keep their updated_at
+	ib.SQL("ON CONFLICT (phone_number) DO NOTHING")
+	ib.Returning(patientColumns...)
+
+	query, args := ib.Build()
+	patient, err = scanPatient(r.db.QueryRowContext(ctx, query, args...))
+	if err == nil {
+		return patient, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to create patient: %w", err)
+	}
+
+	// A concurrent login registered the same number between the lookup and the insert
+	return r.GetByPhoneNumber(ctx, phoneNumber)
+}
+
+func scanPatient(row rowScanner) (*domain.Patient, error) {
+	var patient domain.Patient
+	err := row.Scan(
+		&patient.ID,
+		&patient.PhoneNumber,
+		&patient.Name,
+		&patient.CreatedAt,
+		&patient.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &patient, nil
+}
+
+func NewPatientRepository(db database.Querier) PatientRepository {
+	return &patientRepository{db: db}
+}
@@ -0,0 +1,121 @@
+package medical
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+// Helper functions
+
+func newTestPatient() medical.Patient {
+	now := time.Now().Truncate(time.Second)
+	return medical.Patient{
+		ID:          uuid.New(),
+		PhoneNumber: "+989121234567",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func mockPatientRows(patients ...medical.Patient) *sqlmock.Rows {
+	rows := sqlmock.NewRows(patientColumns)
+	for _, p := range patients {
+		rows.AddRow(p.ID, p.PhoneNumber, p.Name, p.CreatedAt, p.UpdatedAt)
+	}
+	return rows
+}
+
+const (
+	patientSelectByPhoneQuery = `SELECT id, phone_number, name, created_at, updated_at FROM patients WHERE phone_number = \$1`
+	patientInsertQuery        = `INSERT INTO patients \(phone_number\) VALUES \(\$1\) ON CONFLICT \(phone_number\) DO NOTHING RETURNING id, phone_number, name, created_at, updated_at`
+)
+
+// Table-driven tests
+
+func TestPatientRepository_GetOrCreateByPhoneNumber(t *testing.T) {
+	ctx := context.Background()
+	patient := newTestPatient()
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "existing patient",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(patientSelectByPhoneQuery).WithArgs(patient.PhoneNumber).WillReturnRows(mockPatientRows(patient))
+			},
+		},
+		{
+			name: "first login creates the patient",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(patientSelectByPhoneQuery).WithArgs(patient.PhoneNumber).WillReturnError(sql.ErrNoRows)
+				m.ExpectQuery(patientInsertQuery).WithArgs(patient.PhoneNumber).WillReturnRows(mockPatientRows(patient))
+			},
+		},
+		{
+			name: "concurrent registration is read back",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(patientSelectByPhoneQuery).WithArgs(patient.PhoneNumber).WillReturnError(sql.ErrNoRows)
+				m.ExpectQuery(patientInsertQuery).WithArgs(patient.PhoneNumber).WillReturnError(sql.ErrNoRows)
+				m.ExpectQuery(patientSelectByPhoneQuery).WithArgs(patient.PhoneNumber).WillReturnRows(mockPatientRows(patient))
+			},
+		},
+		{
+			name: "database error",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(patientSelectByPhoneQuery).WithArgs(patient.PhoneNumber).WillReturnError(errors.New("connection lost"))
+			},
+			wantErr: errors.New("connection lost"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			repo := NewPatientRepository(db)
+			tt.mockSetup(mock)
+
+			got, err := repo.GetOrCreateByPhoneNumber(ctx, patient.PhoneNumber)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr.Error())
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, patient.ID, got.ID)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPatientRepository_GetByID_NotFound(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	id := uuid.New()
+	mock.ExpectQuery(`SELECT id, phone_number, name, created_at, updated_at FROM patients WHERE id = \$1`).
+		WithArgs(id).WillReturnError(sql.ErrNoRows)
+
+	got, err := NewPatientRepository(db).GetByID(context.Background(), id)
+
+	assert.ErrorIs(t, err, medical.ErrPatientNotFound)
+	assert.Nil(t, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
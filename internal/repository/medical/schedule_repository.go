@@ -0,0 +1,125 @@
+package medical
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+var scheduleColumns = []string{"id", "doctor_id", "day_of_week", "start_time", "end_time", "slot_duration_minutes", "created_at", "updated_at"}
+
+type ScheduleRepository interface {
+	GetByDoctorID(ctx context.Context, doctorID uuid.UUID) ([]domain.Schedule, error)
+	DeleteByDoctorID(ctx context.Context, doctorID uuid.UUID) error
+	// Create inserts schedules for the doctor and returns them with their database generated fields
+	Create(ctx context.Context, doctorID uuid.UUID, schedules []domain.Schedule) ([]domain.Schedule, error)
+}
+
+type scheduleRepository struct {
+	db database.Querier
+}
+
+func (r *scheduleRepository) GetByDoctorID(ctx context.Context, doctorID uuid.UUID) ([]domain.Schedule, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(scheduleColumns...)
+	sb.From("doctor_schedules")
+	sb.Where(sb.Equal("doctor_id", doctorID))
+	sb.OrderByAsc("day_of_week")
+	sb.OrderByAsc("start_time")
+
+	query, args := sb.Build()
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch schedules: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %v", err)
+		}
+	}(rows)
+
+	return scanSchedules(rows)
+}
+
+func (r *scheduleRepository) DeleteByDoctorID(ctx context.Context, doctorID uuid.UUID) error {
+	del := sqlbuilder.PostgreSQL.NewDeleteBuilder()
+	del.DeleteFrom("doctor_schedules")
+	del.Where(del.Equal("doctor_id", doctorID))
+
+	query, args := del.Build()
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete schedules: %w", err)
+	}
+	return nil
+}
+
+func (r *scheduleRepository) Create(ctx context.Context, doctorID uuid.UUID, schedules []domain.Schedule) ([]domain.Schedule, error) {
+	if len(schedules) == 0 {
+		return nil, nil
+	}
+
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("doctor_schedules")
+	ib.Cols("doctor_id", "day_of_week", "start_time", "end_time", "slot_duration_minutes")
+	for _, schedule := range schedules {
+		ib.Values(doctorID, int(schedule.DayOfWeek), schedule.StartTime, schedule.EndTime, schedule.SlotDuration)
+	}
+	ib.Returning(scheduleColumns...)
+
+	query, args := ib.Build()
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert schedules: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %v", err)
+		}
+	}(rows)
+
+	saved, err := scanSchedules(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert schedules: %w", err)
+	}
+	return saved, nil
+}
+
+func scanSchedules(rows *sql.Rows) ([]domain.Schedule, error) {
+	var schedules []domain.Schedule
+	for rows.Next() {
+		var schedule domain.Schedule
+		err := rows.Scan(
+			&schedule.ID,
+			&schedule.DoctorID,
+			&schedule.DayOfWeek,
+			&schedule.StartTime,
+			&schedule.EndTime,
+			&schedule.SlotDuration,
+			&schedule.CreatedAt,
+			&schedule.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+func NewScheduleRepository(db database.Querier) ScheduleRepository {
+	return &scheduleRepository{db: db}
+}
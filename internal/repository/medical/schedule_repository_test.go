@@ -0,0 +1,168 @@
+package medical
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+func TestScheduleRepository_GetByDoctorID(t *testing.T) {
+	ctx := context.Background()
+	doctorID := uuid.New()
+	now := time.Now().Truncate(time.Second)
+	selectQuery := `SELECT id, doctor_id, day_of_week, start_time, end_time, slot_duration_minutes, created_at, updated_at FROM doctor_schedules WHERE doctor_id = \$1 ORDER BY day_of_week ASC, start_time ASC`
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		want      []medical.Schedule
+		wantErr   string
+	}{
+		{
+			name: "schedules are scanned",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(selectQuery).WithArgs(doctorID).WillReturnRows(
+					sqlmock.NewRows(scheduleColumns).
+						AddRow(uuid.New(), doctorID, 1, "09:00:00", "12:30:00", 20, now, now).
+						AddRow(uuid.New(), doctorID, 3, "16:00:00", "18:00:00", 30, now, now),
+				)
+			},
+			want: []medical.Schedule{
+				{DoctorID: doctorID, DayOfWeek: time.Monday, StartTime: 9 * 60, EndTime: 12*60 + 30, SlotDuration: 20},
+				{DoctorID: doctorID, DayOfWeek: time.Wednesday, StartTime: 16 * 60, EndTime: 18 * 60, SlotDuration: 30},
+			},
+		},
+		{
+			name: "no schedules",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(selectQuery).WithArgs(doctorID).WillReturnRows(sqlmock.NewRows(scheduleColumns))
+			},
+			want: nil,
+		},
+		{
+			name: "database error",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(selectQuery).WithArgs(doctorID).WillReturnError(errors.New("connection lost"))
+			},
+			wantErr: "connection lost",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			repo := NewScheduleRepository(db)
+			tt.mockSetup(mock)
+
+			got, err := repo.GetByDoctorID(ctx, doctorID)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, got, len(tt.want))
+				for i := range tt.want {
+					assert.Equal(t, tt.want[i].DayOfWeek, got[i].DayOfWeek)
+					assert.Equal(t, tt.want[i].StartTime, got[i].StartTime)
+					assert.Equal(t, tt.want[i].EndTime, got[i].EndTime)
+					assert.Equal(t, tt.want[i].SlotDuration, got[i].SlotDuration)
+				}
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestScheduleRepository_DeleteByDoctorID(t *testing.T) {
+	ctx := context.Background()
+	doctorID := uuid.New()
+
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	mock.ExpectExec(`DELETE FROM doctor_schedules WHERE doctor_id = \$1`).WithArgs(doctorID).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	require.NoError(t, NewScheduleRepository(db).DeleteByDoctorID(ctx, doctorID))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScheduleRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	doctorID := uuid.New()
+	now := time.Now().Truncate(time.Second)
+	insertQuery := `INSERT INTO doctor_schedules \(doctor_id, day_of_week, start_time, end_time, slot_duration_minutes\) VALUES \(\$1, \$2, \$3, \$4, \$5\), \(\$6, \$7, \$8, \$9, \$10\) RETURNING id, doctor_id, day_of_week, start_time, end_time, slot_duration_minutes, created_at, updated_at`
+
+	schedules := []medical.Schedule{
+		{DayOfWeek: time.Wednesday, StartTime: 16 * 60, EndTime: 18 * 60, SlotDuration: 30},
+		{DayOfWeek: time.Monday, StartTime: 9 * 60, EndTime: 12 * 60, SlotDuration: 20},
+	}
+
+	tests := []struct {
+		name      string
+		schedules []medical.Schedule
+		mockSetup func(sqlmock.Sqlmock)
+		wantDays  []time.Weekday
+		wantErr   bool
+	}{
+		{
+			name:      "schedules are inserted in one statement",
+			schedules: schedules,
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(insertQuery).
+					WithArgs(doctorID, 3, medical.ClockTime(16*60), medical.ClockTime(18*60), 30, doctorID, 1, medical.ClockTime(9*60), medical.ClockTime(12*60), 20).
+					WillReturnRows(sqlmock.NewRows(scheduleColumns).
+						AddRow(uuid.New(), doctorID, 3, "16:00:00", "18:00:00", 30, now, now).
+						AddRow(uuid.New(), doctorID, 1, "09:00:00", "12:00:00", 20, now, now))
+			},
+			wantDays: []time.Weekday{time.Wednesday, time.Monday},
+		},
+		{
+			name:      "empty schedule runs no query",
+			schedules: nil,
+			mockSetup: func(m sqlmock.Sqlmock) {},
+		},
+		{
+			name:      "database error",
+			schedules: schedules,
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(insertQuery).WillReturnError(errors.New("connection lost"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			repo := NewScheduleRepository(db)
+			tt.mockSetup(mock)
+
+			got, err := repo.Create(ctx, doctorID, tt.schedules)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, got, len(tt.wantDays))
+				for i, day := range tt.wantDays {
+					assert.Equal(t, day, got[i].DayOfWeek)
+				}
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package medical
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	filter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+)
+
+var specialtyColumns = []string{"id", "name", "created_at", "updated_at"}
+
+type SpecialtyRepository interface {
+	GetAllWithDoctorCount(ctx context.Context, filters filter.SpecialtyQueryParam) ([]domain.SpecialtySummary, error)
+	// GetByIDForUpdate returns the specialty and locks it until the surrounding transaction ends
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Specialty, error)
+	Create(ctx context.Context, specialty *domain.Specialty) error
+	Update(ctx context.Context, specialty *domain.Specialty) error
+	// Delete removes a specialty that no doctor belongs to anymore and returns it as it was
+	Delete(ctx context.Context, id uuid.UUID) (*domain.Specialty, error)
+}
+
+type specialtyRepository struct {
+	db database.Querier
+}
+
+// GetAllWithDoctorCount lists specialties alphabetically with the number of doctors in each
+func (r *specialtyRepository) GetAllWithDoctorCount(ctx context.Context, filters filter.SpecialtyQueryParam) ([]domain.SpecialtySummary, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(
+		"specialties.id",
+		"specialties.name",
+		"specialties.created_at",
+		"specialties.updated_at",
+		sb.As("COUNT(doctors.id)", "doctor_count"),
+	)
+	sb.From("specialties")
+	sb.JoinWithOption(sqlbuilder.LeftJoin, "doctors", "doctors.specialty_id = specialties.id")
+	sb = filters.Apply(sb)
+	sb.GroupBy("specialties.id")
+	sb.OrderByAsc("specialties.name")
+
+	query, args := sb.Build()
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch specialties: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %v", err)
+		}
+	}(rows)
+
+	var specialties []domain.SpecialtySummary
+	for rows.Next() {
+		var specialty domain.SpecialtySummary
+		err := rows.Scan(
+			&specialty.ID,
+			&specialty.Name,
+			&specialty.CreatedAt,
+			&specialty.UpdatedAt,
+			&specialty.DoctorCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		specialties = append(specialties, specialty)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return specialties, nil
+}
+
+func (r *specialtyRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Specialty, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(specialtyColumns...)
+	sb.From("specialties")
+	sb.Where(sb.Equal("id", id))
+	sb.ForUpdate()
+
+	query, args := sb.Build()
+	specialty, err := scanSpecialty(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrSpecialtyNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to lock specialty: %w", err)
+	}
+	return specialty, nil
+}
+
+// Create inserts the specialty and fills in the database generated fields
+func (r *specialtyRepository) Create(ctx context.Context, specialty *domain.Specialty) error {
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("specialties")
+	ib.Cols("name")
+	ib.Values(specialty.Name)
+	ib.Returning(specialtyColumns...)
+
+	query, args := ib.Build()
+	created, err := scanSpecialty(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if isConstraintViolation(err, pqUniqueViolation, "specialties_name_key") {
+			return domain.ErrSpecialtyNameTaken
+		}
+		return fmt.Errorf("failed to create specialty: %w", err)
+	}
+
+	*specialty = *created
+	return nil
+}
+
+// Update renames the specialty with the same id and refreshes the remaining fields
+func (r *specialtyRepository) Update(ctx context.Context, specialty *domain.Specialty) error {
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("specialties")
+	ub.Set(ub.Assign("name", specialty.Name))
+	ub.Where(ub.Equal("id", specialty.ID))
+	ub.Returning(specialtyColumns...)
+
+	query, args := ub.Build()
+	updated, err := scanSpecialty(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return fmt.Errorf("%w: %s", domain.ErrSpecialtyNotFound, specialty.ID)
+		case isConstraintViolation(err, pqUniqueViolation, "specialties_name_key"):
+			return domain.ErrSpecialtyNameTaken
+		}
+		return fmt.Errorf("failed to update specialty: %w", err)
+	}
+
+	*specialty = *updated
+	return nil
+}
+
+func (r *specialtyRepository) Delete(ctx context.Context, id uuid.UUID) (*domain.Specialty, error) {
+	del := sqlbuilder.PostgreSQL.NewDeleteBuilder()
+	del.DeleteFrom("specialties")
+	del.Where(del.Equal("id", id))
+	del.Returning(specialtyColumns...)
+
+	query, args := del.Build()
+	deleted, err := scanSpecialty(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, fmt.Errorf("%w: %s", domain.ErrSpecialtyNotFound, id)
+		case isConstraintViolation(err, pqForeignKeyViolation, "fk_doctors_specialty_id"):
+			return nil, domain.ErrSpecialtyInUse
+		}
+		return nil, fmt.Errorf("failed to delete specialty: %w", err)
+	}
+	return deleted, nil
+}
+
+func scanSpecialty(row rowScanner) (*domain.Specialty, error) {
+	var specialty domain.Specialty
+	err := row.Scan(
+		&specialty.ID,
+		&specialty.Name,
+		&specialty.CreatedAt,
+		&specialty.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &specialty, nil
+}
+
+func NewSpecialtyRepository(db database.Querier) SpecialtyRepository {
+	return &specialtyRepository{db: db}
+}
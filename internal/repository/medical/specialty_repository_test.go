@@ -0,0 +1,199 @@
+package medical
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	filter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+)
+
+func TestSpecialtyRepository_GetAllWithDoctorCount(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	cardiology := uuid.New()
+	dermatology := uuid.New()
+
+	selectQuery := `SELECT specialties.id, specialties.name, specialties.created_at, specialties.updated_at, COUNT\(doctors.id\) AS doctor_count FROM specialties LEFT JOIN doctors ON doctors.specialty_id = specialties.id`
+	groupQuery := ` GROUP BY specialties.id ORDER BY specialties.name ASC`
+	columns := []string{"id", "name", "created_at", "updated_at", "doctor_count"}
+
+	tests := []struct {
+		name      string
+		filter    filter.SpecialtyQueryParam
+		mockSetup func(sqlmock.Sqlmock)
+		wantCount []int
+		wantErr   string
+	}{
+		{
+			name:   "all specialties with counts",
+			filter: filter.SpecialtyQueryParam{},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(selectQuery + groupQuery).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(cardiology, "Cardiology", now, now, 4).
+						AddRow(dermatology, "Dermatology", now, now, 0))
+			},
+			wantCount: []int{4, 0},
+		},
+		{
+			name:   "name filter",
+			filter: filter.SpecialtyQueryParam{Name: "card"},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(selectQuery + ` WHERE specialties.name ILIKE \$1` + groupQuery).
+					WithArgs("%card%").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(cardiology, "Cardiology", now, now, 4))
+			},
+			wantCount: []int{4},
+		},
+		{
+			name:   "database error",
+			filter: filter.SpecialtyQueryParam{},
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(selectQuery + groupQuery).WillReturnError(errors.New("connection lost"))
+			},
+			wantErr: "failed to fetch specialties",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			got, err := NewSpecialtyRepository(db).GetAllWithDoctorCount(ctx, tt.filter)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, got, len(tt.wantCount))
+				for i, count := range tt.wantCount {
+					assert.Equal(t, count, got[i].DoctorCount)
+				}
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+func TestSpecialtyRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	specialtyID := uuid.New()
+	now := time.Now().Truncate(time.Second)
+	insertQuery := `INSERT INTO specialties \(name\) VALUES \(\$1\) RETURNING id, name, created_at, updated_at`
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "specialty is created",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(insertQuery).WithArgs("قلب و عروق").
+					WillReturnRows(sqlmock.NewRows(specialtyColumns).AddRow(specialtyID, "قلب و عروق", now, now))
+			},
+		},
+		{
+			name: "duplicate name",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(insertQuery).WithArgs("قلب و عروق").
+					WillReturnError(&pq.Error{Code: pqUniqueViolation, Constraint: "specialties_name_key"})
+			},
+			wantErr: medical.ErrSpecialtyNameTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			repo := NewSpecialtyRepository(db)
+			tt.mockSetup(mock)
+
+			specialty := medical.Specialty{Name: "قلب و عروق"}
+			err := repo.Create(ctx, &specialty)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, specialtyID, specialty.ID)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSpecialtyRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	specialtyID := uuid.New()
+	now := time.Now().Truncate(time.Second)
+	deleteQuery := `DELETE FROM specialties WHERE id = \$1 RETURNING id, name, created_at, updated_at`
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "specialty is deleted",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(deleteQuery).WithArgs(specialtyID).
+					WillReturnRows(sqlmock.NewRows(specialtyColumns).AddRow(specialtyID, "پوست", now, now))
+			},
+		},
+		{
+			name: "doctors still belong to the specialty",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(deleteQuery).WithArgs(specialtyID).
+					WillReturnError(&pq.Error{Code: pqForeignKeyViolation, Constraint: "fk_doctors_specialty_id"})
+			},
+			wantErr: medical.ErrSpecialtyInUse,
+		},
+		{
+			name: "missing specialty",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(deleteQuery).WithArgs(specialtyID).WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: medical.ErrSpecialtyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+
+			repo := NewSpecialtyRepository(db)
+			tt.mockSetup(mock)
+
+			deleted, err := repo.Delete(ctx, specialtyID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "پوست", deleted.Name)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package medical
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/huandu/go-sqlbuilder"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	domain "github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+var timeOffColumns = []string{"id", "doctor_id", "start_time", "end_time", "reason", "created_at", "updated_at"}
+
+type TimeOffRepository interface {
+	GetByDoctorBetween(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]domain.TimeOff, error)
+	// GetByDoctorEndingAfter returns the doctor's time off periods that have not ended by after
+	GetByDoctorEndingAfter(ctx context.Context, doctorID uuid.UUID, after time.Time) ([]domain.TimeOff, error)
+	Create(ctx context.Context, timeOff *domain.TimeOff) error
+	// Delete removes the doctor's time off period; periods of other doctors are reported as not found
+	Delete(ctx context.Context, doctorID, id uuid.UUID) error
+}
+
+type timeOffRepository struct {
+	db database.Querier
+}
+
+// GetByDoctorBetween returns the doctor's time off periods overlapping [from, to)
+func (r *timeOffRepository) GetByDoctorBetween(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]domain.TimeOff, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(timeOffColumns...)
+	sb.From("doctor_time_off")
+	sb.Where(
+		sb.Equal("doctor_id", doctorID),
+		sb.LessThan("start_time", to),
+		sb.GreaterThan("end_time", from),
+	)
+	sb.OrderByAsc("start_time")
+
+	return r.query(ctx, sb)
+}
+
+func (r *timeOffRepository) GetByDoctorEndingAfter(ctx context.Context, doctorID uuid.UUID, after time.Time) ([]domain.TimeOff, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(timeOffColumns...)
+	sb.From("doctor_time_off")
+	sb.Where(
+		sb.Equal("doctor_id", doctorID),
+		sb.GreaterThan("end_time", after),
+	)
+	sb.OrderByAsc("start_time")
+
+	return r.query(ctx, sb)
+}
+
+func (r *timeOffRepository) Create(ctx context.Context, timeOff *domain.TimeOff) error {
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("doctor_time_off")
+	ib.Cols("doctor_id", "start_time", "end_time", "reason")
+	ib.Values(timeOff.DoctorID, timeOff.StartTime, timeOff.EndTime, timeOff.Reason)
+	ib.Returning(timeOffColumns...)
+
+	query, args := ib.Build()
+	created, err := scanTimeOff(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if isConstraintViolation(err, pqForeignKeyViolation, "fk_doctor_time_off_doctor_id") {
+			return fmt.Errorf("%w: %s", domain.ErrDoctorNotFound, timeOff.DoctorID)
+		}
+		return fmt.Errorf("failed to create time off: %w", err)
+	}
+
+	*timeOff = *created
+	return nil
+}
+
+func (r *timeOffRepository) Delete(ctx context.Context, doctorID, id uuid.UUID) error {
+	del := sqlbuilder.PostgreSQL.NewDeleteBuilder()
+	del.DeleteFrom("doctor_time_off")
+	del.Where(
+		del.Equal("id", id),
+		del.Equal("doctor_id", doctorID),
+	)
+
+	query, args := del.Build()
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete time off: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete time off: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrTimeOffNotFound, id)
+	}
+	return nil
+}
+
+func (r *timeOffRepository) query(ctx context.Context, sb *sqlbuilder.SelectBuilder) ([]domain.TimeOff, error) {
+	query, args := sb.Build()
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch time off: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("failed to close rows: %v", err)
+		}
+	}(rows)
+
+	var timeOffs []domain.TimeOff
+	for rows.Next() {
+		timeOff, err := scanTimeOff(rows)
+		if err != nil {
+			return nil, err
+		}
+		timeOffs = append(timeOffs, *timeOff)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return timeOffs, nil
+}
+
+func scanTimeOff(row rowScanner) (*domain.TimeOff, error) {
+	var timeOff domain.TimeOff
+	err := row.Scan(
+		&timeOff.ID,
+		&timeOff.DoctorID,
+		&timeOff.StartTime,
+		&timeOff.EndTime,
+		&timeOff.Reason,
+		&timeOff.CreatedAt,
+		&timeOff.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &timeOff, nil
+}
+
+func NewTimeOffRepository(db database.Querier) TimeOffRepository {
+	return &timeOffRepository{db: db}
+}
@@ -0,0 +1,141 @@
+package medical
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+func TestTimeOffRepository_GetByDoctorBetween(t *testing.T) {
+	ctx := context.Background()
+	doctorID := uuid.New()
+	now := time.Now().Truncate(time.Second)
+	from := now
+	to := now.Add(7 * 24 * time.Hour)
+
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT id, doctor_id, start_time, end_time, reason, created_at, updated_at FROM doctor_time_off WHERE doctor_id = \$1 AND start_time < \$2 AND end_time > \$3 ORDER BY start_time ASC`).
+		WithArgs(doctorID, to, from).
+		WillReturnRows(sqlmock.NewRows(timeOffColumns).AddRow(uuid.New(), doctorID, now.Add(time.Hour), now.Add(5*time.Hour), "Conference", now, now))
+
+	got, err := NewTimeOffRepository(db).GetByDoctorBetween(ctx, doctorID, from, to)
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "Conference", got[0].Reason)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTimeOffRepository_GetByDoctorEndingAfter(t *testing.T) {
+	ctx := context.Background()
+	doctorID := uuid.New()
+	now := time.Now().Truncate(time.Second)
+
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT id, doctor_id, start_time, end_time, reason, created_at, updated_at FROM doctor_time_off WHERE doctor_id = \$1 AND end_time > \$2 ORDER BY start_time ASC`).
+		WithArgs(doctorID, now).
+		WillReturnRows(sqlmock.NewRows(timeOffColumns).AddRow(uuid.New(), doctorID, now.Add(-time.Hour), now.Add(time.Hour), "Surgery", now, now))
+
+	got, err := NewTimeOffRepository(db).GetByDoctorEndingAfter(ctx, doctorID, now)
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "Surgery", got[0].Reason)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTimeOffRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	doctorID := uuid.New()
+	now := time.Now().Truncate(time.Second)
+	start, end := now.Add(24*time.Hour), now.Add(48*time.Hour)
+	const insertQuery = `INSERT INTO doctor_time_off \(doctor_id, start_time, end_time, reason\) VALUES \(\$1, \$2, \$3, \$4\) RETURNING id, doctor_id, start_time, end_time, reason, created_at, updated_at`
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "created",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(insertQuery).
+					WithArgs(doctorID, start, end, "Vacation").
+					WillReturnRows(sqlmock.NewRows(timeOffColumns).AddRow(uuid.New(), doctorID, start, end, "Vacation", now, now))
+			},
+		},
+		{
+			name: "deleted doctor",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(insertQuery).
+					WillReturnError(&pq.Error{Code: pqForeignKeyViolation, Constraint: "fk_doctor_time_off_doctor_id"})
+			},
+			wantErr: medical.ErrDoctorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+			tt.mockSetup(mock)
+
+			timeOff := &medical.TimeOff{DoctorID: doctorID, StartTime: start, EndTime: end, Reason: "Vacation"}
+			err := NewTimeOffRepository(db).Create(ctx, timeOff)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, timeOff.ID)
+				assert.Equal(t, now, timeOff.CreatedAt)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTimeOffRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	doctorID := uuid.New()
+	id := uuid.New()
+	const deleteQuery = `DELETE FROM doctor_time_off WHERE id = \$1 AND doctor_id = \$2`
+
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "deleted", affected: 1},
+		{name: "other doctor's time off", affected: 0, wantErr: medical.ErrTimeOffNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupTestDB(t)
+			defer db.Close()
+			mock.ExpectExec(deleteQuery).WithArgs(id, doctorID).WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			err := NewTimeOffRepository(db).Delete(ctx, doctorID, id)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
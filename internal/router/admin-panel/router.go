@@ -0,0 +1,20 @@
+package admin_panel
+
+import (
+	"github.com/gin-gonic/gin"
+
+	admin_api "github.com/shayesteh1hs/DrAppointment/internal/api/admin-panel/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	"github.com/shayesteh1hs/DrAppointment/internal/service"
+)
+
+// SetupAdminPanelRoutes registers the catalog management routes; rg must only admit admins
+func SetupAdminPanelRoutes(rg *gin.RouterGroup, uow *database.UnitOfWork) {
+	catalogService := service.NewCatalogService(uow)
+
+	specialtyHandler := admin_api.NewSpecialtyHandler(catalogService)
+	specialtyHandler.RegisterRoutes(rg)
+
+	doctorHandler := admin_api.NewDoctorHandler(catalogService)
+	doctorHandler.RegisterRoutes(rg)
+}
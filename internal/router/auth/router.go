@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+
+	authApi "github.com/shayesteh1hs/DrAppointment/internal/api/auth"
+	authService "github.com/shayesteh1hs/DrAppointment/internal/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/config"
+	"github.com/shayesteh1hs/DrAppointment/internal/provider/sms"
+	authRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+)
+
+func SetupAuthRoutes(rg *gin.RouterGroup, db *sql.DB, cfg config.AuthConfig, sender sms.Sender, tokens *authService.TokenIssuer) {
+	key := authService.OTPKey([]byte(cfg.JWTSecret))
+	service := authService.NewService(
+		authRepo.NewOTPRepository(db),
+		medical.NewPatientRepository(db),
+		medical.NewDoctorRepository(db),
+		authRepo.NewAdminRepository(db),
+		sender,
+		tokens,
+		key,
+		authService.OTPConfig{
+			TTL:            cfg.OTPTTL,
+			MaxAttempts:    cfg.OTPMaxAttempts,
+			ResendCooldown: cfg.OTPResendCooldown,
+		},
+	)
+
+	authHandler := authApi.NewHandler(service)
+	authHandler.RegisterRoutes(rg)
+}
@@ -0,0 +1,42 @@
+package doctor_panel
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+
+	doctor_api "github.com/shayesteh1hs/DrAppointment/internal/api/doctor-panel/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/config"
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	"github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/service"
+)
+
+// SetupDoctorPanelRoutes registers the routes of the signed-in doctor; rg must authenticate the caller
+func SetupDoctorPanelRoutes(rg *gin.RouterGroup, db *sql.DB, uow *database.UnitOfWork, cfg config.AppointmentConfig) {
+	doctorService := service.NewDoctorService(
+		medical.NewDoctorRepository(db),
+		medical.NewScheduleRepository(db),
+		medical.NewAppointmentRepository(db),
+		medical.NewTimeOffRepository(db),
+		medical.NewHolidayRepository(db),
+	)
+	profileHandler := doctor_api.NewProfileHandler(doctorService)
+	profileHandler.RegisterRoutes(rg)
+
+	scheduleService := service.NewScheduleService(db, uow)
+	scheduleHandler := doctor_api.NewScheduleHandler(scheduleService)
+	scheduleHandler.RegisterRoutes(rg)
+
+	timeOffService := service.NewTimeOffService(medical.NewTimeOffRepository(db))
+	timeOffHandler := doctor_api.NewTimeOffHandler(timeOffService)
+	timeOffHandler.RegisterRoutes(rg)
+
+	appointmentService := service.NewAppointmentService(db, uow, service.AppointmentPolicy{
+		PatientCancelCutoff: cfg.PatientCancelCutoff,
+		DoctorCancelCutoff:  cfg.DoctorCancelCutoff,
+		RescheduleCutoff:    cfg.RescheduleCutoff,
+	})
+	appointmentHandler := doctor_api.NewAppointmentHandler(appointmentService)
+	appointmentHandler.RegisterRoutes(rg)
+}
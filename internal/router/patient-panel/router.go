@@ -6,11 +6,38 @@ import (
 	"github.com/gin-gonic/gin"
 
 	medical_api "github.com/shayesteh1hs/DrAppointment/internal/api/patient-panel/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/config"
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
 	"github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/service"
 )
 
 func SetupPatientPanelRoutes(rg *gin.RouterGroup, db *sql.DB) {
-	doctorRepo := medical.NewDoctorRepository(db)
-	doctorHandler := medical_api.NewHandler(doctorRepo)
+	doctorService := service.NewDoctorService(
+		medical.NewDoctorRepository(db),
+		medical.NewScheduleRepository(db),
+		medical.NewAppointmentRepository(db),
+		medical.NewTimeOffRepository(db),
+		medical.NewHolidayRepository(db),
+	)
+	doctorHandler := medical_api.NewHandler(doctorService)
 	doctorHandler.RegisterRoutes(rg)
+
+	specialtyService := service.NewSpecialtyService(medical.NewSpecialtyRepository(db))
+	specialtyHandler := medical_api.NewSpecialtyHandler(specialtyService)
+	specialtyHandler.RegisterRoutes(rg)
+
+	slotHandler := medical_api.NewSlotHandler(doctorService)
+	slotHandler.RegisterRoutes(rg)
+}
+
+// SetupPatientRoutes registers the routes of the signed-in patient; rg must authenticate the caller
+func SetupPatientRoutes(rg *gin.RouterGroup, db *sql.DB, uow *database.UnitOfWork, cfg config.AppointmentConfig) {
+	appointmentService := service.NewAppointmentService(db, uow, service.AppointmentPolicy{
+		PatientCancelCutoff: cfg.PatientCancelCutoff,
+		DoctorCancelCutoff:  cfg.DoctorCancelCutoff,
+		RescheduleCutoff:    cfg.RescheduleCutoff,
+	})
+	appointmentHandler := medical_api.NewAppointmentHandler(appointmentService)
+	appointmentHandler.RegisterRoutes(rg)
 }
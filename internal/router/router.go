@@ -2,16 +2,31 @@ package router
 
 import (
 	"database/sql"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/shayesteh1hs/DrAppointment/internal/apperror"
+	authService "github.com/shayesteh1hs/DrAppointment/internal/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/config"
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
 	"github.com/shayesteh1hs/DrAppointment/internal/middleware"
+	"github.com/shayesteh1hs/DrAppointment/internal/provider/sms"
+	admin_router "github.com/shayesteh1hs/DrAppointment/internal/router/admin-panel"
+	auth_router "github.com/shayesteh1hs/DrAppointment/internal/router/auth"
+	doctor_router "github.com/shayesteh1hs/DrAppointment/internal/router/doctor-panel"
 	medical_router "github.com/shayesteh1hs/DrAppointment/internal/router/patient-panel"
+	"github.com/shayesteh1hs/DrAppointment/internal/validation"
 )
 
-func SetupRouter(db *sql.DB) *gin.Engine {
+func SetupRouter(db *sql.DB, cfg *config.Config, sender sms.Sender, translator *validation.Translator) *gin.Engine {
 	r := gin.Default()
-	r.Use(middleware.ErrorHandler())
+	r.Use(middleware.ErrorHandler(translator))
+	r.Use(middleware.Calendar())
+	r.NoRoute(func(c *gin.Context) {
+		_ = c.Error(apperror.New(http.StatusNotFound, "not_found", "No route matches the request"))
+	})
 
 	api := r.Group("/api")
 
@@ -27,8 +42,40 @@ func SetupRouter(db *sql.DB) *gin.Engine {
 		})
 	})
 
+	// Services that change several tables run those changes in one transaction of this unit of work.
+	// Serializable transactions fail instead of interleaving, and the unit of work retries them.
+	uow := database.NewUnitOfWork(db, &sql.TxOptions{Isolation: sql.LevelSerializable})
+
+	tokens := authService.NewTokenIssuer([]byte(cfg.Auth.JWTSecret), cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL)
+	auth_router.SetupAuthRoutes(api, db, cfg.Auth, sender, tokens)
+
 	publicRoutes := api.Group("/public")
 	medical_router.SetupPatientPanelRoutes(publicRoutes, db)
 
+	patientRoutes := protectedGroup(api, "/patient", tokens, auth.RolePatient)
+	medical_router.SetupPatientRoutes(patientRoutes, db, uow, cfg.Appointments)
+
+	doctorRoutes := protectedGroup(api, "/doctor", tokens, auth.RoleDoctor)
+	doctor_router.SetupDoctorPanelRoutes(doctorRoutes, db, uow, cfg.Appointments)
+
+	adminRoutes := protectedGroup(api, "/admin", tokens, auth.RoleAdmin)
+	admin_router.SetupAdminPanelRoutes(adminRoutes, uow)
+
 	return r
 }
+
+// protectedGroup mounts a route group that only accepts access tokens of the given roles.
+// Every such group exposes GET /me with the authenticated principal.
+func protectedGroup(api *gin.RouterGroup, path string, tokens middleware.TokenParser, roles ...auth.Role) *gin.RouterGroup {
+	group := api.Group(path, middleware.Authenticate(tokens), middleware.RequireRole(roles...))
+
+	group.GET("/me", func(c *gin.Context) {
+		principal, _ := middleware.PrincipalFrom(c)
+		c.JSON(200, gin.H{
+			"id":   principal.ID,
+			"role": principal.Role,
+		})
+	})
+
+	return group
+}
@@ -0,0 +1,69 @@
+package scheduling
+
+import (
+	"time"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+// Date is a calendar day without a time zone; it is placed in one by In
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// ParseDate reads a date in time.DateOnly format
+func ParseDate(value string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// DateOf returns the date of t in its own location
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// AddDays returns the date n days later
+func (d Date) AddDays(n int) Date {
+	return DateOf(time.Date(d.Year, d.Month, d.Day+n, 0, 0, 0, 0, time.UTC))
+}
+
+// Before reports whether d is an earlier day than other
+func (d Date) Before(other Date) bool {
+	return d.utc().Before(other.utc())
+}
+
+// DaysUntil returns the number of days from d to other
+func (d Date) DaysUntil(other Date) int {
+	return int(other.utc().Sub(d.utc()).Hours() / 24)
+}
+
+// In returns the first instant of the date in loc, which is later than midnight when a
+// daylight saving change skips it
+func (d Date) In(loc *time.Location) time.Time {
+	return medical.ClockTime(0).On(d.Year, d.Month, d.Day, loc)
+}
+
+func (d Date) String() string {
+	return d.utc().Format(time.DateOnly)
+}
+
+func (d Date) utc() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// DateRange is the days From to To, both included
+type DateRange struct {
+	From Date
+	To   Date
+}
+
+// In returns the days of the range as observed in loc
+func (r DateRange) In(loc *time.Location) Interval {
+	return Interval{Start: r.From.In(loc), End: r.To.AddDays(1).In(loc)}
+}
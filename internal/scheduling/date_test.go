@@ -0,0 +1,29 @@
+package scheduling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDate(t *testing.T) {
+	date, err := ParseDate("2025-03-09")
+	require.NoError(t, err)
+	assert.Equal(t, Date{Year: 2025, Month: time.March, Day: 9}, date)
+	assert.Equal(t, "2025-03-09", date.String())
+
+	_, err = ParseDate("09/03/2025")
+	assert.Error(t, err)
+}
+
+func TestDate_Arithmetic(t *testing.T) {
+	last := Date{Year: 2024, Month: time.February, Day: 28}
+
+	assert.Equal(t, Date{Year: 2024, Month: time.February, Day: 29}, last.AddDays(1))
+	assert.Equal(t, Date{Year: 2024, Month: time.March, Day: 1}, last.AddDays(2))
+	assert.Equal(t, 2, last.DaysUntil(last.AddDays(2)))
+	assert.True(t, last.Before(last.AddDays(1)))
+	assert.False(t, last.Before(last))
+}
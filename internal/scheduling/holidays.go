@@ -0,0 +1,62 @@
+package scheduling
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+// DayInterval returns the whole calendar day of date as observed in loc
+func DayInterval(date time.Time, loc *time.Location) Interval {
+	day := DateOf(date)
+	return DateRange{From: day, To: day}.In(loc)
+}
+
+// ParseHolidaysCSV reads "date,name" records with dates in YYYY-MM-DD format.
+// A leading header row, blank lines and lines starting with '#' are ignored.
+func ParseHolidaysCSV(r io.Reader) ([]medical.Holiday, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var holidays []medical.Holiday
+	seen := make(map[string]int)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read holidays csv: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		rawDate := strings.TrimSpace(record[0])
+		name := strings.TrimSpace(record[1])
+		if first && strings.EqualFold(rawDate, "date") {
+			continue
+		}
+
+		date, err := time.Parse(time.DateOnly, rawDate)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q", line, rawDate)
+		}
+		if name == "" {
+			return nil, fmt.Errorf("line %d: holiday name is required", line)
+		}
+		if previous, ok := seen[rawDate]; ok {
+			return nil, fmt.Errorf("line %d: duplicate date %s (first seen on line %d)", line, rawDate, previous)
+		}
+		seen[rawDate] = line
+
+		holidays = append(holidays, medical.Holiday{Date: date, Name: name})
+	}
+
+	return holidays, nil
+}
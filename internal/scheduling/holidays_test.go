@@ -0,0 +1,87 @@
+package scheduling
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHolidaysCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantDates []string
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			name:      "header and records",
+			input:     "date,name\n2025-03-20,Nowruz\n2025-04-01,Islamic Republic Day\n",
+			wantDates: []string{"2025-03-20", "2025-04-01"},
+			wantNames: []string{"Nowruz", "Islamic Republic Day"},
+		},
+		{
+			name:      "no header, comments and spacing",
+			input:     "# national holidays\n2025-03-21, Nowruz\n",
+			wantDates: []string{"2025-03-21"},
+			wantNames: []string{"Nowruz"},
+		},
+		{
+			name:    "errors report the source line",
+			input:   "date,name\n# comment\n2025-13-01,Invalid\n",
+			wantErr: `line 3: invalid date "2025-13-01"`,
+		},
+		{
+			name:    "invalid date",
+			input:   "2025/03/20,Nowruz\n",
+			wantErr: `line 1: invalid date "2025/03/20"`,
+		},
+		{
+			name:    "missing name",
+			input:   "2025-03-20, \n",
+			wantErr: "line 1: holiday name is required",
+		},
+		{
+			name:    "duplicate date",
+			input:   "2025-03-20,Nowruz\n2025-03-20,Nowruz again\n",
+			wantErr: "line 2: duplicate date 2025-03-20",
+		},
+		{
+			name:    "wrong column count",
+			input:   "2025-03-20,Nowruz,extra\n",
+			wantErr: "failed to read holidays csv",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHolidaysCSV(strings.NewReader(tt.input))
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.wantDates))
+			for i := range got {
+				assert.Equal(t, tt.wantDates[i], got[i].Date.Format("2006-01-02"))
+				assert.Equal(t, tt.wantNames[i], got[i].Name)
+			}
+		})
+	}
+}
+
+func TestDayInterval(t *testing.T) {
+	tehran := time.FixedZone("IRST", 3*60*60+30*60)
+	date := time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC)
+
+	got := DayInterval(date, tehran)
+
+	assert.Equal(t, time.Date(2025, time.March, 20, 0, 0, 0, 0, tehran), got.Start)
+	assert.Equal(t, time.Date(2025, time.March, 21, 0, 0, 0, 0, tehran), got.End)
+}
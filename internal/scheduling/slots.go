@@ -0,0 +1,95 @@
+package scheduling
+
+import (
+	"slices"
+	"time"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+// Interval is a half-open time range [Start, End)
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Overlaps reports whether the two intervals share any instant
+func (i Interval) Overlaps(other Interval) bool {
+	return i.Start.Before(other.End) && other.Start.Before(i.End)
+}
+
+// Contains reports whether other lies entirely within the interval
+func (i Interval) Contains(other Interval) bool {
+	return !other.Start.Before(i.Start) && !other.End.After(i.End)
+}
+
+// dayStart returns the first instant of t's date in loc
+func dayStart(t time.Time, loc *time.Location) time.Time {
+	return DateOf(t).In(loc)
+}
+
+// GenerateSlots expands the weekly schedules into concrete slots inside window,
+// leaving out every slot that overlaps a busy interval. Days and schedule hours are
+// wall-clock times in the location of window.Start, and the result is ordered by start time.
+// On days a daylight saving change skips or repeats an hour, slots follow elapsed time, so
+// a skipped hour has no slots and a repeated one has slots at both offsets.
+func GenerateSlots(schedules []medical.Schedule, window Interval, busy []Interval) []medical.Slot {
+	slots := make([]medical.Slot, 0)
+	if !window.Start.Before(window.End) {
+		return slots
+	}
+
+	loc := window.Start.Location()
+	for date := dayStart(window.Start, loc); date.Before(window.End); date = nextDay(date) {
+		for _, schedule := range schedules {
+			if schedule.DayOfWeek != date.Weekday() || schedule.SlotDuration <= 0 {
+				continue
+			}
+			slots = append(slots, expandDay(schedule, date, window, busy)...)
+		}
+	}
+
+	slices.SortFunc(slots, func(a, b medical.Slot) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+	return slots
+}
+
+func expandDay(schedule medical.Schedule, date time.Time, window Interval, busy []Interval) []medical.Slot {
+	var slots []medical.Slot
+
+	year, month, day := date.Date()
+	dayEnd := schedule.EndTime.On(year, month, day, date.Location())
+	step := time.Duration(schedule.SlotDuration) * time.Minute
+
+	for start := schedule.StartTime.On(year, month, day, date.Location()); !start.Add(step).After(dayEnd); start = start.Add(step) {
+		candidate := Interval{Start: start, End: start.Add(step)}
+		if !window.Contains(candidate) || overlapsAny(candidate, busy) {
+			continue
+		}
+		slots = append(slots, medical.Slot{
+			StartTime:      candidate.Start.UTC(),
+			EndTime:        candidate.End.UTC(),
+			LocalStartTime: candidate.Start,
+			LocalEndTime:   candidate.End,
+		})
+	}
+
+	return slots
+}
+
+// nextDay returns the start of the day after date; adding 24 hours is wrong on days that
+// daylight saving makes longer or shorter
+func nextDay(date time.Time) time.Time {
+	year, month, day := date.Date()
+	return dayStart(time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC), date.Location())
+}
+
+func overlapsAny(candidate Interval, busy []Interval) bool {
+	for _, b := range busy {
+		if candidate.Overlaps(b) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package scheduling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+// Helper functions
+
+func newTestSchedule(day time.Weekday, start, end string, slotMinutes int) medical.Schedule {
+	startTime, _ := medical.ParseClockTime(start)
+	endTime, _ := medical.ParseClockTime(end)
+	return medical.Schedule{
+		DayOfWeek:    day,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		SlotDuration: slotMinutes,
+	}
+}
+
+func at(day int, hour, minute int) time.Time {
+	// 2025-01-06 is a Monday
+	return time.Date(2025, time.January, day, hour, minute, 0, 0, time.UTC)
+}
+
+func slotStarts(slots []medical.Slot) []time.Time {
+	starts := make([]time.Time, len(slots))
+	for i, slot := range slots {
+		starts[i] = slot.StartTime
+	}
+	return starts
+}
+
+// Table-driven tests
+
+func TestGenerateSlots(t *testing.T) {
+	monday := newTestSchedule(time.Monday, "09:00", "10:00", 20)
+	tuesday := newTestSchedule(time.Tuesday, "14:00", "15:00", 30)
+
+	tests := []struct {
+		name      string
+		schedules []medical.Schedule
+		window    Interval
+		busy      []Interval
+		want      []time.Time
+	}{
+		{
+			name:      "single day expands into slots",
+			schedules: []medical.Schedule{monday},
+			window:    Interval{Start: at(6, 0, 0), End: at(7, 0, 0)},
+			want:      []time.Time{at(6, 9, 0), at(6, 9, 20), at(6, 9, 40)},
+		},
+		{
+			name:      "multiple days are ordered by start time",
+			schedules: []medical.Schedule{tuesday, monday},
+			window:    Interval{Start: at(6, 0, 0), End: at(8, 0, 0)},
+			want:      []time.Time{at(6, 9, 0), at(6, 9, 20), at(6, 9, 40), at(7, 14, 0), at(7, 14, 30)},
+		},
+		{
+			name:      "busy intervals remove overlapping slots",
+			schedules: []medical.Schedule{monday},
+			window:    Interval{Start: at(6, 0, 0), End: at(7, 0, 0)},
+			busy:      []Interval{{Start: at(6, 9, 10), End: at(6, 9, 30)}},
+			want:      []time.Time{at(6, 9, 40)},
+		},
+		{
+			name:      "adjacent busy interval does not block slot",
+			schedules: []medical.Schedule{monday},
+			window:    Interval{Start: at(6, 0, 0), End: at(7, 0, 0)},
+			busy:      []Interval{{Start: at(6, 8, 0), End: at(6, 9, 0)}},
+			want:      []time.Time{at(6, 9, 0), at(6, 9, 20), at(6, 9, 40)},
+		},
+		{
+			name:      "window start cuts off earlier slots",
+			schedules: []medical.Schedule{monday},
+			window:    Interval{Start: at(6, 9, 15), End: at(7, 0, 0)},
+			want:      []time.Time{at(6, 9, 20), at(6, 9, 40)},
+		},
+		{
+			name:      "trailing partial slot is dropped",
+			schedules: []medical.Schedule{newTestSchedule(time.Monday, "09:00", "09:50", 20)},
+			window:    Interval{Start: at(6, 0, 0), End: at(7, 0, 0)},
+			want:      []time.Time{at(6, 9, 0), at(6, 9, 20)},
+		},
+		{
+			name:      "day without schedule yields nothing",
+			schedules: []medical.Schedule{tuesday},
+			window:    Interval{Start: at(6, 0, 0), End: at(7, 0, 0)},
+			want:      []time.Time{},
+		},
+		{
+			name:      "empty window yields nothing",
+			schedules: []medical.Schedule{monday},
+			window:    Interval{Start: at(7, 0, 0), End: at(6, 0, 0)},
+			want:      []time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GenerateSlots(tt.schedules, tt.window, tt.busy)
+			assert.Equal(t, tt.want, slotStarts(got))
+		})
+	}
+}
+
+func TestInterval_Overlaps(t *testing.T) {
+	base := Interval{Start: at(6, 9, 0), End: at(6, 10, 0)}
+
+	assert.True(t, base.Overlaps(Interval{Start: at(6, 9, 30), End: at(6, 10, 30)}))
+	assert.True(t, base.Overlaps(Interval{Start: at(6, 8, 0), End: at(6, 11, 0)}))
+	assert.False(t, base.Overlaps(Interval{Start: at(6, 10, 0), End: at(6, 11, 0)}))
+	assert.False(t, base.Overlaps(Interval{Start: at(6, 8, 0), End: at(6, 9, 0)}))
+}
+
+func TestGenerateSlots_DaylightSaving(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	// Tehran observed daylight saving until 2022, starting it by skipping midnight
+	tehran := mustLoadLocation(t, "Asia/Tehran")
+
+	tests := []struct {
+		name      string
+		schedules []medical.Schedule
+		window    Interval
+		wantUTC   []string
+		wantLocal []string
+	}{
+		{
+			name:      "spring forward skips the missing hour",
+			schedules: []medical.Schedule{newTestSchedule(time.Sunday, "01:00", "04:00", 30)},
+			window:    days(2025, time.March, 9, 9).In(newYork),
+			wantUTC:   []string{"2025-03-09T06:00:00Z", "2025-03-09T06:30:00Z", "2025-03-09T07:00:00Z", "2025-03-09T07:30:00Z"},
+			wantLocal: []string{"2025-03-09T01:00:00-05:00", "2025-03-09T01:30:00-05:00", "2025-03-09T03:00:00-04:00", "2025-03-09T03:30:00-04:00"},
+		},
+		{
+			name:      "schedule starting in the missing hour moves past it",
+			schedules: []medical.Schedule{newTestSchedule(time.Sunday, "02:30", "04:00", 30)},
+			window:    days(2025, time.March, 9, 9).In(newYork),
+			wantUTC:   []string{"2025-03-09T07:30:00Z"},
+			wantLocal: []string{"2025-03-09T03:30:00-04:00"},
+		},
+		{
+			name:      "fall back repeats the extra hour",
+			schedules: []medical.Schedule{newTestSchedule(time.Sunday, "00:00", "03:00", 60)},
+			window:    days(2025, time.November, 2, 2).In(newYork),
+			wantUTC:   []string{"2025-11-02T04:00:00Z", "2025-11-02T05:00:00Z", "2025-11-02T06:00:00Z", "2025-11-02T07:00:00Z"},
+			wantLocal: []string{"2025-11-02T00:00:00-04:00", "2025-11-02T01:00:00-04:00", "2025-11-02T01:00:00-05:00", "2025-11-02T02:00:00-05:00"},
+		},
+		{
+			name:      "skipped midnight starts the day an hour late",
+			schedules: []medical.Schedule{newTestSchedule(time.Tuesday, "00:00", "02:00", 60)},
+			window:    days(2022, time.March, 21, 22).In(tehran),
+			wantUTC:   []string{"2022-03-21T20:30:00Z"},
+			wantLocal: []string{"2022-03-22T01:00:00+04:30"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GenerateSlots(tt.schedules, tt.window, nil)
+			gotUTC := make([]string, len(got))
+			gotLocal := make([]string, len(got))
+			for i, slot := range got {
+				gotUTC[i] = slot.StartTime.Format(time.RFC3339)
+				gotLocal[i] = slot.LocalStartTime.Format(time.RFC3339)
+				assert.Equal(t, slot.EndTime, slot.LocalEndTime.UTC())
+			}
+			assert.Equal(t, tt.wantUTC, gotUTC)
+			assert.Equal(t, tt.wantLocal, gotLocal)
+		})
+	}
+}
+
+func TestDateRange_In(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	tehran := mustLoadLocation(t, "Asia/Tehran")
+
+	// The day of the spring change in New York is 23 hours long
+	window := days(2025, time.March, 9, 9).In(newYork)
+	assert.Equal(t, "2025-03-09T00:00:00-05:00", window.Start.Format(time.RFC3339))
+	assert.Equal(t, "2025-03-10T00:00:00-04:00", window.End.Format(time.RFC3339))
+	assert.Equal(t, 23*time.Hour, window.End.Sub(window.Start))
+
+	// Midnight did not exist in Tehran on 2022-03-22
+	window = days(2022, time.March, 22, 22).In(tehran)
+	assert.Equal(t, "2022-03-22T01:00:00+04:30", window.Start.Format(time.RFC3339))
+	assert.Equal(t, "2022-03-23T00:00:00+04:30", window.End.Format(time.RFC3339))
+}
+
+// days returns the days from day to lastDay of the same month
+func days(year int, month time.Month, day, lastDay int) DateRange {
+	return DateRange{From: Date{Year: year, Month: month, Day: day}, To: Date{Year: year, Month: month, Day: lastDay}}
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
@@ -0,0 +1,246 @@
+// Package seed generates a reproducible development dataset and writes it to the database
+package seed
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/scheduling"
+)
+
+// namespace scopes the name-based UUIDs of seeded rows, so the same seed always yields the same ids
+var namespace = uuid.MustParse("5f0c1a52-8d3e-4c1b-9f57-2b6a4e9d7c10")
+
+var specialtyNames = []string{
+	"قلب و عروق",
+	"پوست و مو",
+	"اطفال",
+	"زنان و زایمان",
+	"ارتوپدی",
+	"چشم پزشکی",
+	"گوش، حلق و بینی",
+	"مغز و اعصاب",
+	"روانپزشکی",
+	"داخلی",
+	"اورولوژی",
+	"دندانپزشکی",
+}
+
+var firstNames = []string{
+	"علی", "محمد", "حسین", "رضا", "مهدی", "امیر", "سعید", "حمید",
+	"مریم", "زهرا", "فاطمه", "سارا", "نرگس", "لیلا", "شیما", "نازنین",
+}
+
+var lastNames = []string{
+	"احمدی", "محمدی", "حسینی", "رضایی", "کریمی", "موسوی", "جعفری", "صادقی",
+	"رحیمی", "کاظمی", "هاشمی", "قاسمی", "نوری", "شریفی", "اکبری", "یزدانی",
+}
+
+// shifts are the working hours a seeded doctor may keep on their working days
+var shifts = [][2]medical.ClockTime{
+	{9 * 60, 13 * 60},
+	{14 * 60, 18 * 60},
+	{16 * 60, 20 * 60},
+}
+
+var slotDurations = []int{15, 20, 30}
+
+// workingDays is the clinic week, Saturday through Wednesday
+var workingDays = []time.Weekday{time.Saturday, time.Sunday, time.Monday, time.Tuesday, time.Wednesday}
+
+// Config controls the size and shape of the generated dataset
+type Config struct {
+	Seed                  uint64
+	Specialties           int
+	Doctors               int
+	Patients              int
+	AppointmentsPerDoctor int
+	// From anchors appointment dates; appointments fall within Days days before and after it
+	From time.Time
+	Days int
+	// Now splits past appointments from upcoming ones. It is part of the configuration rather than
+	// the clock, so the dataset does not change with the day it is generated on.
+	Now time.Time
+}
+
+// Dataset is the generated rows, ordered so that parents come before children
+type Dataset struct {
+	Specialties  []medical.Specialty
+	Patients     []medical.Patient
+	Doctors      []medical.Doctor
+	Schedules    []medical.Schedule
+	Appointments []medical.Appointment
+}
+
+// Generate builds the dataset described by cfg. The result depends only on cfg,
+// so the same configuration always produces identical rows, ids included.
+func Generate(cfg Config) (*Dataset, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		cfg: cfg,
+		rng: rand.New(rand.NewPCG(cfg.Seed, cfg.Seed^0x9e3779b97f4a7c15)),
+	}
+
+	data := &Dataset{}
+	data.Specialties = g.specialties()
+	data.Patients = g.patients()
+	for i := range cfg.Doctors {
+		doctor := g.doctor(i, data.Specialties)
+		schedules := g.schedules(doctor)
+		data.Doctors = append(data.Doctors, doctor)
+		data.Schedules = append(data.Schedules, schedules...)
+		appointments, err := g.appointments(doctor, schedules)
+		if err != nil {
+			return nil, err
+		}
+		data.Appointments = append(data.Appointments, appointments...)
+	}
+
+	return data, nil
+}
+
+func (cfg Config) validate() error {
+	switch {
+	case cfg.Specialties < 1 || cfg.Specialties > len(specialtyNames):
+		return fmt.Errorf("specialties must be between 1 and %d", len(specialtyNames))
+	case cfg.Doctors < 0:
+		return fmt.Errorf("doctors must not be negative")
+	case cfg.Patients < 0:
+		return fmt.Errorf("patients must not be negative")
+	case cfg.AppointmentsPerDoctor < 0:
+		return fmt.Errorf("appointments per doctor must not be negative")
+	case cfg.AppointmentsPerDoctor > 0 && cfg.Patients < 1:
+		return fmt.Errorf("patients must be at least 1 when seeding appointments")
+	case cfg.Days < 1:
+		return fmt.Errorf("days must be at least 1")
+	case cfg.Now.IsZero():
+		return fmt.Errorf("now is required")
+	}
+	return nil
+}
+
+type generator struct {
+	cfg Config
+	rng *rand.Rand
+}
+
+// id derives a stable UUID for the n-th row of kind
+func (g *generator) id(kind string, n int) uuid.UUID {
+	return uuid.NewSHA1(namespace, fmt.Appendf(nil, "%d/%s/%d", g.cfg.Seed, kind, n))
+}
+
+func (g *generator) specialties() []medical.Specialty {
+	names := append([]string(nil), specialtyNames...)
+	g.rng.Shuffle(len(names), func(i, j int) {
+		names[i], names[j] = names[j], names[i]
+	})
+
+	specialties := make([]medical.Specialty, g.cfg.Specialties)
+	for i := range specialties {
+		specialties[i] = medical.Specialty{ID: g.id("specialty", i), Name: names[i]}
+	}
+	return specialties
+}
+
+// patients get numbers from an unassigned block, so seeded accounts never collide with real ones
+func (g *generator) patients() []medical.Patient {
+	patients := make([]medical.Patient, g.cfg.Patients)
+	for i := range patients {
+		patients[i] = medical.Patient{ID: g.id("patient", i), PhoneNumber: fmt.Sprintf("+9899900%05d", i)}
+	}
+	return patients
+}
+
+func (g *generator) doctor(n int, specialties []medical.Specialty) medical.Doctor {
+	specialty := specialties[g.rng.IntN(len(specialties))]
+	name := firstNames[g.rng.IntN(len(firstNames))] + " " + lastNames[g.rng.IntN(len(lastNames))]
+
+	return medical.Doctor{
+		ID:          g.id("doctor", n),
+		Name:        name,
+		SpecialtyID: specialty.ID,
+		PhoneNumber: fmt.Sprintf("+989%09d", g.rng.IntN(1_000_000_000)),
+		AvatarURL:   fmt.Sprintf("https://i.pravatar.cc/300?u=%d-%d", g.cfg.Seed, n),
+		Description: fmt.Sprintf("متخصص %s با %d سال سابقه", specialty.Name, 2+g.rng.IntN(25)),
+		TimeZone:    medical.DefaultTimeZone,
+	}
+}
+
+func (g *generator) schedules(doctor medical.Doctor) []medical.Schedule {
+	shift := shifts[g.rng.IntN(len(shifts))]
+	duration := slotDurations[g.rng.IntN(len(slotDurations))]
+
+	var schedules []medical.Schedule
+	for _, day := range workingDays {
+		// Most doctors take one extra day off during the week
+		if g.rng.IntN(4) == 0 {
+			continue
+		}
+		schedules = append(schedules, medical.Schedule{
+			DoctorID:     doctor.ID,
+			DayOfWeek:    day,
+			StartTime:    shift[0],
+			EndTime:      shift[1],
+			SlotDuration: duration,
+		})
+	}
+	for i := range schedules {
+		schedules[i].ID = g.id("schedule/"+doctor.ID.String(), i)
+	}
+	return schedules
+}
+
+// appointments books distinct slots of the doctor's schedule around cfg.From.
+// Appointments starting before cfg.Now are completed or cancelled, later ones pending or confirmed.
+func (g *generator) appointments(doctor medical.Doctor, schedules []medical.Schedule) ([]medical.Appointment, error) {
+	loc, err := doctor.Location()
+	if err != nil {
+		return nil, err
+	}
+	// Slots are generated in the doctor's time zone, as the API offers them
+	window := scheduling.Interval{
+		Start: g.cfg.From.AddDate(0, 0, -g.cfg.Days).In(loc),
+		End:   g.cfg.From.AddDate(0, 0, g.cfg.Days).In(loc),
+	}
+	slots := scheduling.GenerateSlots(schedules, window, nil)
+	g.rng.Shuffle(len(slots), func(i, j int) {
+		slots[i], slots[j] = slots[j], slots[i]
+	})
+
+	count := min(g.cfg.AppointmentsPerDoctor, len(slots))
+	appointments := make([]medical.Appointment, count)
+	for i, slot := range slots[:count] {
+		appointments[i] = medical.Appointment{
+			ID:        g.id("appointment/"+doctor.ID.String(), i),
+			DoctorID:  doctor.ID,
+			PatientID: g.id("patient", g.rng.IntN(g.cfg.Patients)),
+			StartTime: slot.StartTime,
+			EndTime:   slot.EndTime,
+			Status:    g.status(slot.StartTime.Before(g.cfg.Now)),
+		}
+	}
+	return appointments, nil
+}
+
+func (g *generator) status(past bool) medical.AppointmentStatus {
+	cancelled := g.rng.IntN(5) == 0
+	switch {
+	case past && cancelled:
+		return medical.AppointmentStatusCancelledByPatient
+	case past:
+		return medical.AppointmentStatusCompleted
+	case cancelled:
+		return medical.AppointmentStatusCancelledByPatient
+	case g.rng.IntN(2) == 0:
+		return medical.AppointmentStatusConfirmed
+	default:
+		return medical.AppointmentStatusPending
+	}
+}
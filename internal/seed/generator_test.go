@@ -0,0 +1,142 @@
+package seed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/scheduling"
+)
+
+func testConfig() Config {
+	return Config{
+		Seed:                  42,
+		Specialties:           5,
+		Doctors:               20,
+		Patients:              10,
+		AppointmentsPerDoctor: 6,
+		From:                  time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC),
+		Days:                  14,
+		// Midweek, so the appointments earlier in the week are already past
+		Now: time.Date(2025, 3, 6, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	first, err := Generate(testConfig())
+	require.NoError(t, err)
+	second, err := Generate(testConfig())
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+
+	cfg := testConfig()
+	cfg.Seed = 7
+	other, err := Generate(cfg)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.Doctors[0].ID, other.Doctors[0].ID)
+}
+
+func TestGenerate_Shape(t *testing.T) {
+	cfg := testConfig()
+	data, err := Generate(cfg)
+	require.NoError(t, err)
+
+	require.Len(t, data.Specialties, cfg.Specialties)
+	require.Len(t, data.Doctors, cfg.Doctors)
+	require.Len(t, data.Patients, cfg.Patients)
+
+	patients := make(map[uuid.UUID]bool)
+	for _, p := range data.Patients {
+		patients[p.ID] = true
+		assert.Regexp(t, `^\+98999\d{7}$`, p.PhoneNumber)
+	}
+
+	specialties := make(map[uuid.UUID]bool)
+	for _, s := range data.Specialties {
+		specialties[s.ID] = true
+	}
+	locations := make(map[uuid.UUID]*time.Location)
+	for _, d := range data.Doctors {
+		assert.True(t, specialties[d.SpecialtyID], "doctor %s has an unknown specialty", d.Name)
+		assert.Regexp(t, `^\+989\d{9}$`, d.PhoneNumber)
+		loc, err := d.Location()
+		require.NoError(t, err)
+		locations[d.ID] = loc
+	}
+
+	schedulesByDoctor := make(map[uuid.UUID][]medical.Schedule)
+	for _, s := range data.Schedules {
+		assert.Less(t, s.StartTime, s.EndTime)
+		schedulesByDoctor[s.DoctorID] = append(schedulesByDoctor[s.DoctorID], s)
+	}
+
+	busy := make(map[uuid.UUID][]scheduling.Interval)
+	for _, a := range data.Appointments {
+		assert.True(t, a.Status.IsValid())
+		assert.True(t, patients[a.PatientID], "appointment references an unknown patient")
+		if !a.StartTime.Before(cfg.Now) {
+			assert.NotEqual(t, medical.AppointmentStatusCompleted, a.Status, "upcoming appointment is completed")
+		} else {
+			assert.False(t, a.Status.IsActive(), "past appointment is still active")
+		}
+
+		slot := scheduling.Interval{Start: a.StartTime, End: a.EndTime}
+		for _, other := range busy[a.DoctorID] {
+			assert.False(t, slot.Overlaps(other), "appointments of a doctor overlap")
+		}
+		busy[a.DoctorID] = append(busy[a.DoctorID], slot)
+
+		window := scheduling.Interval{Start: a.StartTime.In(locations[a.DoctorID]), End: a.EndTime}
+		slots := scheduling.GenerateSlots(schedulesByDoctor[a.DoctorID], window, nil)
+		assert.Len(t, slots, 1, "appointment does not match a schedule slot")
+	}
+	for doctorID, appointments := range busy {
+		assert.LessOrEqual(t, len(appointments), cfg.AppointmentsPerDoctor, "doctor %s", doctorID)
+	}
+}
+
+func TestGenerate_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{
+			name:    "too many specialties",
+			modify:  func(c *Config) { c.Specialties = len(specialtyNames) + 1 },
+			wantErr: "specialties must be between 1 and 12",
+		},
+		{
+			name:    "appointments without patients",
+			modify:  func(c *Config) { c.Patients = 0 },
+			wantErr: "patients must be at least 1 when seeding appointments",
+		},
+		{
+			name:    "no current time",
+			modify:  func(c *Config) { c.Now = time.Time{} },
+			wantErr: "now is required",
+		},
+		{
+			name:    "empty window",
+			modify:  func(c *Config) { c.Days = 0 },
+			wantErr: "days must be at least 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			tt.modify(&cfg)
+
+			_, err := Generate(cfg)
+
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
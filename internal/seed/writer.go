@@ -0,0 +1,173 @@
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// batchSize keeps each INSERT well below PostgreSQL's limit of 65535 bind parameters
+const batchSize = 1000
+
+// seededTables are emptied by a reset; CASCADE also clears rows that reference them
+var seededTables = []string{"appointments", "doctor_time_off", "doctor_schedules", "doctors", "specialties", "patients"}
+
+// Result counts the rows inserted into each table
+type Result struct {
+	// Specialties also counts seeded specialties that already existed
+	Specialties  int64
+	Patients     int64
+	Doctors      int64
+	Schedules    int64
+	Appointments int64
+}
+
+// Write inserts the dataset in a single transaction. Rows that already exist are
+// left untouched, so writing the same dataset twice is a no-op. With reset the
+// seeded tables are truncated first, which makes the database match the dataset exactly.
+func Write(ctx context.Context, db *sql.DB, data *Dataset, reset bool) (*Result, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("Failed to roll back seed transaction: %v", err)
+		}
+	}(tx)
+
+	if reset {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("TRUNCATE %s CASCADE", strings.Join(seededTables, ", "))); err != nil {
+			return nil, fmt.Errorf("failed to reset seeded tables: %w", err)
+		}
+	}
+
+	result := &Result{}
+
+	specialtyIDs, err := writeSpecialties(ctx, tx, data)
+	if err != nil {
+		return nil, err
+	}
+	result.Specialties = int64(len(specialtyIDs))
+
+	doctors := make([][]any, 0, len(data.Doctors))
+	for _, d := range data.Doctors {
+		doctors = append(doctors, []any{d.ID, d.Name, specialtyIDs[d.SpecialtyID], d.PhoneNumber, d.AvatarURL, d.Description, d.TimeZone})
+	}
+	result.Doctors, err = insertBatches(ctx, tx, "doctors", []string{"id", "name", "specialty_id", "phone_number", "avatar_url", "description", "time_zone"}, doctors)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([][]any, 0, len(data.Schedules))
+	for _, s := range data.Schedules {
+		schedules = append(schedules, []any{s.ID, s.DoctorID, int(s.DayOfWeek), s.StartTime, s.EndTime, s.SlotDuration})
+	}
+	result.Schedules, err = insertBatches(ctx, tx, "doctor_schedules", []string{"id", "doctor_id", "day_of_week", "start_time", "end_time", "slot_duration_minutes"}, schedules)
+	if err != nil {
+		return nil, err
+	}
+
+	patients := make([][]any, 0, len(data.Patients))
+	for _, p := range data.Patients {
+		patients = append(patients, []any{p.ID, p.PhoneNumber})
+	}
+	result.Patients, err = insertBatches(ctx, tx, "patients", []string{"id", "phone_number"}, patients)
+	if err != nil {
+		return nil, err
+	}
+
+	appointments := make([][]any, 0, len(data.Appointments))
+	for _, a := range data.Appointments {
+		appointments = append(appointments, []any{a.ID, a.DoctorID, a.PatientID, a.StartTime, a.EndTime, a.Status})
+	}
+	result.Appointments, err = insertBatches(ctx, tx, "appointments", []string{"id", "doctor_id", "patient_id", "start_time", "end_time", "status"}, appointments)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit seed data: %w", err)
+	}
+
+	return result, nil
+}
+
+// writeSpecialties upserts the specialties by name and maps each generated id to the
+// id stored in the database, which differs when a specialty was created by hand
+func writeSpecialties(ctx context.Context, tx *sql.Tx, data *Dataset) (map[uuid.UUID]uuid.UUID, error) {
+	byName := make(map[string]uuid.UUID, len(data.Specialties))
+
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("specialties")
+	ib.Cols("id", "name")
+	for _, s := range data.Specialties {
+		ib.Values(s.ID, s.Name)
+		byName[s.Name] = s.ID
+	}
+	// The no-op update makes RETURNING report rows that already existed as well
+	ib.SQL("ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name")
+	ib.Returning("id", "name")
+
+	query, args := ib.Build()
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert specialties: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}(rows)
+
+	ids := make(map[uuid.UUID]uuid.UUID, len(data.Specialties))
+	for rows.Next() {
+		var (
+			id   uuid.UUID
+			name string
+		)
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan specialty: %w", err)
+		}
+		ids[byName[name]] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// insertBatches inserts rows into table, skipping any that conflict with existing rows
+func insertBatches(ctx context.Context, tx *sql.Tx, table string, cols []string, rows [][]any) (int64, error) {
+	var inserted int64
+	for start := 0; start < len(rows); start += batchSize {
+		ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+		ib.InsertInto(table)
+		ib.Cols(cols...)
+		for _, row := range rows[start:min(start+batchSize, len(rows))] {
+			ib.Values(row...)
+		}
+		ib.SQL("ON CONFLICT DO NOTHING")
+
+		query, args := ib.Build()
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return inserted, fmt.Errorf("failed to insert %s: %w", table, err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return inserted, err
+		}
+		inserted += affected
+	}
+	return inserted, nil
+}
@@ -0,0 +1,91 @@
+package seed
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+func TestWrite(t *testing.T) {
+	generatedID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	storedID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	doctorID := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+
+	data := &Dataset{
+		Specialties: []medical.Specialty{{ID: generatedID, Name: "اطفال"}},
+		Doctors:     []medical.Doctor{{ID: doctorID, Name: "علی رضایی", SpecialtyID: generatedID, PhoneNumber: "09120000000", TimeZone: medical.DefaultTimeZone}},
+		Patients:    []medical.Patient{{ID: storedID, PhoneNumber: "+989990000000"}},
+		Appointments: []medical.Appointment{{
+			ID: generatedID, DoctorID: doctorID, PatientID: storedID,
+			StartTime: start, EndTime: start.Add(30 * time.Minute), Status: medical.AppointmentStatusPending,
+		}},
+	}
+
+	tests := []struct {
+		name      string
+		reset     bool
+		mockSetup func(sqlmock.Sqlmock)
+	}{
+		{
+			name: "existing specialty id is reused for doctors",
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(regexp.QuoteMeta("INSERT INTO specialties (id, name) VALUES ($1, $2) ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id, name")).
+					WithArgs(generatedID, "اطفال").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(storedID, "اطفال"))
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO doctors (id, name, specialty_id, phone_number, avatar_url, description, time_zone) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT DO NOTHING")).
+					WithArgs(doctorID, "علی رضایی", storedID, "09120000000", "", "", medical.DefaultTimeZone).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO patients (id, phone_number) VALUES ($1, $2) ON CONFLICT DO NOTHING")).
+					WithArgs(storedID, "+989990000000").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO appointments (id, doctor_id, patient_id, start_time, end_time, status) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING")).
+					WithArgs(generatedID, doctorID, storedID, start, start.Add(30*time.Minute), medical.AppointmentStatusPending).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name:  "reset truncates seeded tables first",
+			reset: true,
+			mockSetup: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta("TRUNCATE appointments, doctor_time_off, doctor_schedules, doctors, specialties, patients CASCADE")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery("INSERT INTO specialties").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(generatedID, "اطفال"))
+				m.ExpectExec("INSERT INTO doctors").WithArgs(doctorID, "علی رضایی", generatedID, "09120000000", "", "", medical.DefaultTimeZone).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("INSERT INTO patients").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("INSERT INTO appointments").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			result, err := Write(context.Background(), db, data, tt.reset)
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), result.Specialties)
+			assert.Equal(t, int64(1), result.Appointments)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,372 @@
+// Package service holds the business rules that sit between HTTP handlers and repositories
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+	medicalRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/scheduling"
+)
+
+// AppointmentPolicy holds how long before an appointment starts it can still be changed; zero means until the start
+type AppointmentPolicy struct {
+	PatientCancelCutoff time.Duration
+	DoctorCancelCutoff  time.Duration
+	RescheduleCutoff    time.Duration
+}
+
+// AppointmentService moves appointments through their lifecycle on behalf of the patient or doctor
+// they belong to. Appointments of anyone else are reported as not found so their ids are not revealed.
+// Every change is recorded in the appointment's history in the same transaction.
+// Appointments are returned with their local times in the doctor's time zone.
+// Only one of the doctor's slots can be booked or rescheduled to.
+type AppointmentService struct {
+	db           database.Querier
+	tx           Transactor
+	appointments func(database.Querier) medicalRepo.AppointmentRepository
+	doctors      func(database.Querier) medicalRepo.DoctorRepository
+	schedules    func(database.Querier) medicalRepo.ScheduleRepository
+	timeOffs     func(database.Querier) medicalRepo.TimeOffRepository
+	holidays     func(database.Querier) medicalRepo.HolidayRepository
+	policy       AppointmentPolicy
+	now          func() time.Time
+}
+
+func NewAppointmentService(db database.Querier, tx Transactor, policy AppointmentPolicy) *AppointmentService {
+	return &AppointmentService{
+		db:           db,
+		tx:           tx,
+		appointments: medicalRepo.NewAppointmentRepository,
+		doctors:      medicalRepo.NewDoctorRepository,
+		schedules:    medicalRepo.NewScheduleRepository,
+		timeOffs:     medicalRepo.NewTimeOffRepository,
+		holidays:     medicalRepo.NewHolidayRepository,
+		policy:       policy,
+		now:          time.Now,
+	}
+}
+
+// Book creates a pending appointment for the patient in one of the doctor's slots
+func (s *AppointmentService) Book(ctx context.Context, actor auth.Principal, appointment *medical.Appointment) error {
+	if actor.Role != auth.RolePatient {
+		return auth.ErrForbidden
+	}
+
+	appointment.PatientID = actor.ID
+	appointment.Status = medical.AppointmentStatusPending
+	loc, err := s.doctorLocation(ctx, appointment.DoctorID)
+	if err != nil {
+		return err
+	}
+
+	var booked medical.Appointment
+	err = s.tx.Do(ctx, func(tx *sql.Tx) error {
+		booked = *appointment
+		if err := s.checkSlot(ctx, tx, booked.DoctorID, loc, booked.StartTime, booked.EndTime); err != nil {
+			return err
+		}
+		repo := s.appointments(tx)
+		if err := repo.Create(ctx, &booked); err != nil {
+			return err
+		}
+		return repo.RecordTransition(ctx, actor, "", &booked)
+	})
+	if err != nil {
+		return err
+	}
+	booked.Localize(loc)
+	*appointment = booked
+	return nil
+}
+
+// List returns one page of the actor's own appointments; filters on another patient or doctor are overridden.
+// Doctors filter by days in their own time zone and, when they give no dates, see their upcoming
+// appointments from today on. Patients filter by days in the time zone of the doctor they filter on,
+// or in medical.DefaultTimeZone.
+func (s *AppointmentService) List(ctx context.Context, actor auth.Principal, filters medicalFilter.AppointmentQueryParam, paginator *pagination.LimitOffsetPaginator[medical.Appointment]) (*pagination.Result[medical.Appointment], error) {
+	zones := make(map[uuid.UUID]*time.Location)
+	switch actor.Role {
+	case auth.RolePatient:
+		loc, err := s.filterLocation(ctx, filters.DoctorID)
+		if err != nil {
+			return nil, err
+		}
+		filters.PatientID = actor.ID.String()
+		filters.Location = loc
+	case auth.RoleDoctor:
+		loc, err := s.doctorLocation(ctx, actor.ID)
+		if err != nil {
+			return nil, err
+		}
+		zones[actor.ID] = loc
+		filters.DoctorID = actor.ID.String()
+		filters.Location = loc
+		if filters.From == "" && filters.To == "" {
+			filters.From = s.now().In(loc).Format(time.DateOnly)
+		}
+	default:
+		return nil, auth.ErrForbidden
+	}
+
+	repo := s.appointments(s.db)
+	totalCount, err := repo.Count(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("count appointments: %w", err)
+	}
+
+	appointments, err := repo.GetAllPaginated(ctx, filters, paginator)
+	if err != nil {
+		return nil, fmt.Errorf("list appointments: %w", err)
+	}
+	for i := range appointments {
+		loc, ok := zones[appointments[i].DoctorID]
+		if !ok {
+			if loc, err = s.doctorLocation(ctx, appointments[i].DoctorID); err != nil {
+				return nil, err
+			}
+			zones[appointments[i].DoctorID] = loc
+		}
+		appointments[i].Localize(loc)
+	}
+
+	result, err := paginator.CreatePaginationResult(appointments, totalCount)
+	if err != nil {
+		return nil, fmt.Errorf("paginate appointments: %w", err)
+	}
+	return result, nil
+}
+
+// Get returns the appointment when it belongs to the actor
+func (s *AppointmentService) Get(ctx context.Context, actor auth.Principal, id uuid.UUID) (*medical.Appointment, error) {
+	appointment, err := s.appointments(s.db).GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !owns(actor, appointment) {
+		return nil, medical.ErrAppointmentNotFound
+	}
+	loc, err := s.doctorLocation(ctx, appointment.DoctorID)
+	if err != nil {
+		return nil, err
+	}
+	appointment.Localize(loc)
+	return appointment, nil
+}
+
+// Confirm lets the doctor accept a pending appointment that has not started yet
+func (s *AppointmentService) Confirm(ctx context.Context, actor auth.Principal, id uuid.UUID) (*medical.Appointment, error) {
+	if actor.Role != auth.RoleDoctor {
+		return nil, auth.ErrForbidden
+	}
+	appointment, err := s.Get(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !appointment.Status.CanTransitionTo(medical.AppointmentStatusConfirmed) || !appointment.StartTime.After(s.now()) {
+		return nil, medical.ErrAppointmentNotConfirmable
+	}
+	return s.transition(ctx, actor, appointment, medical.AppointmentStatusConfirmed)
+}
+
+// Cancel releases the appointment's slot. Patients and doctors each have their own cut-off before the start.
+func (s *AppointmentService) Cancel(ctx context.Context, actor auth.Principal, id uuid.UUID) (*medical.Appointment, error) {
+	var status medical.AppointmentStatus
+	var cutoff time.Duration
+	switch actor.Role {
+	case auth.RolePatient:
+		status, cutoff = medical.AppointmentStatusCancelledByPatient, s.policy.PatientCancelCutoff
+	case auth.RoleDoctor:
+		status, cutoff = medical.AppointmentStatusCancelledByDoctor, s.policy.DoctorCancelCutoff
+	default:
+		return nil, auth.ErrForbidden
+	}
+
+	appointment, err := s.Get(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !appointment.Status.CanTransitionTo(status) {
+		return nil, medical.ErrAppointmentNotCancellable
+	}
+	if !s.now().Before(appointment.StartTime.Add(-cutoff)) {
+		return nil, medical.ErrCancellationCutoffPassed
+	}
+	return s.transition(ctx, actor, appointment, status)
+}
+
+// Complete lets the doctor close an appointment that has started
+func (s *AppointmentService) Complete(ctx context.Context, actor auth.Principal, id uuid.UUID) (*medical.Appointment, error) {
+	return s.finish(ctx, actor, id, medical.AppointmentStatusCompleted)
+}
+
+// MarkNoShow lets the doctor record that the patient did not come to an appointment that has started
+func (s *AppointmentService) MarkNoShow(ctx context.Context, actor auth.Principal, id uuid.UUID) (*medical.Appointment, error) {
+	return s.finish(ctx, actor, id, medical.AppointmentStatusNoShow)
+}
+
+func (s *AppointmentService) finish(ctx context.Context, actor auth.Principal, id uuid.UUID, status medical.AppointmentStatus) (*medical.Appointment, error) {
+	if actor.Role != auth.RoleDoctor {
+		return nil, auth.ErrForbidden
+	}
+	appointment, err := s.Get(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !appointment.Status.CanTransitionTo(status) || appointment.StartTime.After(s.now()) {
+		return nil, medical.ErrAppointmentNotFinishable
+	}
+	return s.transition(ctx, actor, appointment, status)
+}
+
+// Reschedule moves an active appointment to another of the doctor's slots. The old slot is released and the new one
+// taken in a single update, so the appointment never holds both or neither. The status is kept.
+func (s *AppointmentService) Reschedule(ctx context.Context, actor auth.Principal, id uuid.UUID, startTime, endTime time.Time) (*medical.Appointment, error) {
+	appointment, err := s.Get(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !appointment.Status.IsActive() {
+		return nil, medical.ErrAppointmentNotReschedulable
+	}
+	if !s.now().Before(appointment.StartTime.Add(-s.policy.RescheduleCutoff)) {
+		return nil, medical.ErrRescheduleCutoffPassed
+	}
+
+	var moved medical.Appointment
+	err = s.tx.Do(ctx, func(tx *sql.Tx) error {
+		moved = *appointment
+		// Get found the doctor's time zone
+		if err := s.checkSlot(ctx, tx, moved.DoctorID, appointment.LocalStartTime.Location(), startTime, endTime); err != nil {
+			return err
+		}
+		repo := s.appointments(tx)
+		if err := repo.UpdateTimeRange(ctx, &moved, startTime, endTime); err != nil {
+			return err
+		}
+		return repo.RecordTransition(ctx, actor, appointment.Status, &moved)
+	})
+	if err != nil {
+		if errors.Is(err, medical.ErrInvalidTransition) {
+			return nil, medical.ErrAppointmentNotReschedulable
+		}
+		return nil, err
+	}
+	// Get found the doctor's time zone
+	moved.Localize(appointment.LocalStartTime.Location())
+	return &moved, nil
+}
+
+// History returns the appointment's booking, status changes and reschedules, oldest first
+func (s *AppointmentService) History(ctx context.Context, actor auth.Principal, id uuid.UUID) ([]medical.AppointmentTransition, error) {
+	appointment, err := s.Get(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	transitions, err := s.appointments(s.db).GetTransitions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for i := range transitions {
+		transitions[i].Localize(appointment.LocalStartTime.Location())
+	}
+	return transitions, nil
+}
+
+// transition changes the status only while it is still the one read, so a concurrent change fails
+// with ErrInvalidTransition instead of being overwritten
+func (s *AppointmentService) transition(ctx context.Context, actor auth.Principal, appointment *medical.Appointment, status medical.AppointmentStatus) (*medical.Appointment, error) {
+	var updated medical.Appointment
+	err := s.tx.Do(ctx, func(tx *sql.Tx) error {
+		updated = *appointment
+		repo := s.appointments(tx)
+		if err := repo.UpdateStatus(ctx, &updated, status); err != nil {
+			return err
+		}
+		return repo.RecordTransition(ctx, actor, appointment.Status, &updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Get found the doctor's time zone
+	updated.Localize(appointment.LocalStartTime.Location())
+	return &updated, nil
+}
+
+// checkSlot returns ErrSlotUnavailable unless [start, end) is exactly one of the doctor's slots on
+// its days in loc, leaving out the doctor's time off and public holidays. Whether another appointment
+// holds the slot is left to the repository.
+func (s *AppointmentService) checkSlot(ctx context.Context, q database.Querier, doctorID uuid.UUID, loc *time.Location, start, end time.Time) error {
+	window := scheduling.DateRange{From: scheduling.DateOf(start.In(loc)), To: scheduling.DateOf(end.In(loc))}.In(loc)
+
+	schedules, err := s.schedules(q).GetByDoctorID(ctx, doctorID)
+	if err != nil {
+		return fmt.Errorf("fetch doctor schedules: %w", err)
+	}
+	timeOffs, err := s.timeOffs(q).GetByDoctorBetween(ctx, doctorID, window.Start, window.End)
+	if err != nil {
+		return fmt.Errorf("fetch doctor time off: %w", err)
+	}
+	holidays, err := s.holidays(q).GetBetween(ctx, window.Start, window.End)
+	if err != nil {
+		return fmt.Errorf("fetch holidays: %w", err)
+	}
+
+	for _, slot := range scheduling.GenerateSlots(schedules, window, absences(timeOffs, holidays, loc)) {
+		if slot.StartTime.Equal(start) && slot.EndTime.Equal(end) {
+			return nil
+		}
+	}
+	return medical.ErrSlotUnavailable
+}
+
+// doctorLocation loads the time zone of the doctor
+func (s *AppointmentService) doctorLocation(ctx context.Context, doctorID uuid.UUID) (*time.Location, error) {
+	doctor, err := s.doctors(s.db).GetByID(ctx, doctorID)
+	if err != nil {
+		return nil, err
+	}
+	return doctor.Location()
+}
+
+// filterLocation returns the time zone a patient's dates are read in: that of the doctor filtered on,
+// else medical.DefaultTimeZone. An unknown doctor matches no appointments in any zone.
+func (s *AppointmentService) filterLocation(ctx context.Context, doctorID string) (*time.Location, error) {
+	if id, err := uuid.Parse(doctorID); err == nil {
+		loc, err := s.doctorLocation(ctx, id)
+		if !errors.Is(err, medical.ErrDoctorNotFound) {
+			return loc, err
+		}
+	}
+	loc, err := time.LoadLocation(medical.DefaultTimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("load default time zone: %w", err)
+	}
+	return loc, nil
+}
+
+// owns reports whether the appointment was booked by the patient or with the doctor acting
+func owns(actor auth.Principal, appointment *medical.Appointment) bool {
+	switch actor.Role {
+	case auth.RolePatient:
+		return appointment.PatientID == actor.ID
+	case auth.RoleDoctor:
+		return appointment.DoctorID == actor.ID
+	default:
+		return false
+	}
+}
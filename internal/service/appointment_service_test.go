@@ -0,0 +1,481 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+	medicalRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+)
+
+var testPolicy = AppointmentPolicy{
+	PatientCancelCutoff: 24 * time.Hour,
+	DoctorCancelCutoff:  2 * time.Hour,
+	RescheduleCutoff:    12 * time.Hour,
+}
+
+// newTestAppointmentService serves appointments of repo; every doctor works in Asia/Tehran
+// from 08:00 to 20:00 every day in 30 minute slots, without time off or holidays
+func newTestAppointmentService(repo *MockAppointmentRepository, now time.Time) *AppointmentService {
+	doctors := new(MockDoctorRepository)
+	doctors.On("GetByID", mock.Anything, mock.Anything).Return(&medical.Doctor{TimeZone: "Asia/Tehran"}, nil).Maybe()
+
+	var weekly []medical.Schedule
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		weekly = append(weekly, medical.Schedule{DayOfWeek: day, StartTime: 8 * 60, EndTime: 20 * 60, SlotDuration: 30})
+	}
+	schedules := new(MockScheduleRepository)
+	schedules.On("GetByDoctorID", mock.Anything, mock.Anything).Return(weekly, nil).Maybe()
+
+	s := NewAppointmentService(nil, fakeTransactor{}, testPolicy)
+	s.appointments = func(database.Querier) medicalRepo.AppointmentRepository { return repo }
+	s.doctors = func(database.Querier) medicalRepo.DoctorRepository { return doctors }
+	s.schedules = func(database.Querier) medicalRepo.ScheduleRepository { return schedules }
+	withAbsences(s, nil, nil)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+// withAbsences gives every doctor of s the time off and holidays
+func withAbsences(s *AppointmentService, timeOffs []medical.TimeOff, holidays []medical.Holiday) {
+	timeOffRepo := new(MockTimeOffRepository)
+	timeOffRepo.On("GetByDoctorBetween", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(timeOffs, nil).Maybe()
+	holidayRepo := new(MockHolidayRepository)
+	holidayRepo.On("GetBetween", mock.Anything, mock.Anything, mock.Anything).Return(holidays, nil).Maybe()
+
+	s.timeOffs = func(database.Querier) medicalRepo.TimeOffRepository { return timeOffRepo }
+	s.holidays = func(database.Querier) medicalRepo.HolidayRepository { return holidayRepo }
+}
+
+func mustLoadTehran(t *testing.T) *time.Location {
+	t.Helper()
+	tehran, err := time.LoadLocation("Asia/Tehran")
+	require.NoError(t, err)
+	return tehran
+}
+
+func TestAppointmentService_Cancel(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	patient := auth.Principal{ID: uuid.New(), Role: auth.RolePatient}
+	doctor := auth.Principal{ID: uuid.New(), Role: auth.RoleDoctor}
+	appointmentID := uuid.New()
+
+	tests := []struct {
+		name       string
+		actor      auth.Principal
+		startsIn   time.Duration
+		status     medical.AppointmentStatus
+		wantStatus medical.AppointmentStatus
+		wantErr    error
+	}{
+		{
+			name:       "patient cancels before the cut-off",
+			actor:      patient,
+			startsIn:   25 * time.Hour,
+			status:     medical.AppointmentStatusConfirmed,
+			wantStatus: medical.AppointmentStatusCancelledByPatient,
+		},
+		{
+			name:     "patient cancels exactly at the cut-off",
+			actor:    patient,
+			startsIn: 24 * time.Hour,
+			status:   medical.AppointmentStatusConfirmed,
+			wantErr:  medical.ErrCancellationCutoffPassed,
+		},
+		{
+			name:       "doctor has a shorter cut-off",
+			actor:      doctor,
+			startsIn:   3 * time.Hour,
+			status:     medical.AppointmentStatusPending,
+			wantStatus: medical.AppointmentStatusCancelledByDoctor,
+		},
+		{
+			name:     "doctor cancels within the cut-off",
+			actor:    doctor,
+			startsIn: time.Hour,
+			status:   medical.AppointmentStatusPending,
+			wantErr:  medical.ErrCancellationCutoffPassed,
+		},
+		{
+			name:     "already cancelled",
+			actor:    patient,
+			startsIn: 48 * time.Hour,
+			status:   medical.AppointmentStatusCancelledByDoctor,
+			wantErr:  medical.ErrAppointmentNotCancellable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appointment := &medical.Appointment{
+				ID:        appointmentID,
+				DoctorID:  doctor.ID,
+				PatientID: patient.ID,
+				StartTime: now.Add(tt.startsIn),
+				Status:    tt.status,
+			}
+			repo := new(MockAppointmentRepository)
+			repo.On("GetByID", mock.Anything, appointmentID).Return(appointment, nil)
+			if tt.wantErr == nil {
+				repo.On("UpdateStatus", mock.Anything, appointment, tt.wantStatus).Return(nil)
+				repo.On("RecordTransition", mock.Anything, tt.actor, tt.status, appointment).Return(nil)
+			}
+
+			_, err := newTestAppointmentService(repo, now).Cancel(context.Background(), tt.actor, appointmentID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAppointmentService_Get_HidesOthersAppointments(t *testing.T) {
+	appointmentID := uuid.New()
+	appointment := &medical.Appointment{ID: appointmentID, DoctorID: uuid.New(), PatientID: uuid.New()}
+
+	for _, actor := range []auth.Principal{
+		{ID: uuid.New(), Role: auth.RolePatient},
+		{ID: appointment.PatientID, Role: auth.RoleDoctor},
+		{ID: appointment.DoctorID, Role: auth.RoleAdmin},
+	} {
+		repo := new(MockAppointmentRepository)
+		repo.On("GetByID", mock.Anything, appointmentID).Return(appointment, nil)
+
+		_, err := newTestAppointmentService(repo, time.Now()).Get(context.Background(), actor, appointmentID)
+
+		assert.ErrorIs(t, err, medical.ErrAppointmentNotFound, "role %s", actor.Role)
+	}
+}
+
+func TestAppointmentService_Get_LocalTimes(t *testing.T) {
+	patient := auth.Principal{ID: uuid.New(), Role: auth.RolePatient}
+	appointmentID := uuid.New()
+	start := time.Date(2025, 3, 10, 5, 30, 0, 0, time.UTC)
+	repo := new(MockAppointmentRepository)
+	repo.On("GetByID", mock.Anything, appointmentID).
+		Return(&medical.Appointment{ID: appointmentID, PatientID: patient.ID, StartTime: start, EndTime: start.Add(30 * time.Minute)}, nil)
+
+	appointment, err := newTestAppointmentService(repo, time.Now()).Get(context.Background(), patient, appointmentID)
+
+	require.NoError(t, err)
+	assert.Equal(t, start, appointment.StartTime)
+	assert.Equal(t, "Asia/Tehran", appointment.TimeZone)
+	assert.Equal(t, "2025-03-10T09:00:00+03:30", appointment.LocalStartTime.Format(time.RFC3339))
+	assert.Equal(t, "2025-03-10T09:30:00+03:30", appointment.LocalEndTime.Format(time.RFC3339))
+}
+
+func TestAppointmentService_Reschedule(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	patient := auth.Principal{ID: uuid.New(), Role: auth.RolePatient}
+	appointmentID := uuid.New()
+	newStart := now.Add(72 * time.Hour)
+	newEnd := newStart.Add(30 * time.Minute)
+
+	tests := []struct {
+		name      string
+		startsIn  time.Duration
+		status    medical.AppointmentStatus
+		repoErr   error
+		callsRepo bool
+		wantErr   error
+	}{
+		{
+			name:      "moved before the cut-off",
+			startsIn:  13 * time.Hour,
+			status:    medical.AppointmentStatusConfirmed,
+			callsRepo: true,
+		},
+		{
+			name:     "within the cut-off",
+			startsIn: 11 * time.Hour,
+			status:   medical.AppointmentStatusConfirmed,
+			wantErr:  medical.ErrRescheduleCutoffPassed,
+		},
+		{
+			name:     "completed appointment",
+			startsIn: 48 * time.Hour,
+			status:   medical.AppointmentStatusCompleted,
+			wantErr:  medical.ErrAppointmentNotReschedulable,
+		},
+		{
+			name:      "cancelled by a concurrent request",
+			startsIn:  48 * time.Hour,
+			status:    medical.AppointmentStatusPending,
+			repoErr:   medical.ErrInvalidTransition,
+			callsRepo: true,
+			wantErr:   medical.ErrAppointmentNotReschedulable,
+		},
+		{
+			name:      "new slot taken",
+			startsIn:  48 * time.Hour,
+			status:    medical.AppointmentStatusPending,
+			repoErr:   medical.ErrSlotTaken,
+			callsRepo: true,
+			wantErr:   medical.ErrSlotTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appointment := &medical.Appointment{ID: appointmentID, PatientID: patient.ID, StartTime: now.Add(tt.startsIn), Status: tt.status}
+			repo := new(MockAppointmentRepository)
+			repo.On("GetByID", mock.Anything, appointmentID).Return(appointment, nil)
+			if tt.callsRepo {
+				repo.On("UpdateTimeRange", mock.Anything, appointment, newStart, newEnd).Return(tt.repoErr)
+			}
+			if tt.callsRepo && tt.repoErr == nil {
+				repo.On("RecordTransition", mock.Anything, patient, tt.status, appointment).Return(nil)
+			}
+
+			_, err := newTestAppointmentService(repo, now).Reschedule(context.Background(), patient, appointmentID, newStart, newEnd)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAppointmentService_Book(t *testing.T) {
+	patient := auth.Principal{ID: uuid.New(), Role: auth.RolePatient}
+	appointmentID := uuid.New()
+	// 09:00 in Tehran
+	start := time.Date(2025, 3, 10, 5, 30, 0, 0, time.UTC)
+	end := start.Add(30 * time.Minute)
+
+	t.Run("booking is recorded in the history", func(t *testing.T) {
+		repo := new(MockAppointmentRepository)
+		repo.On("Create", mock.Anything, mock.MatchedBy(func(a *medical.Appointment) bool {
+			return a.PatientID == patient.ID && a.Status == medical.AppointmentStatusPending
+		})).Run(func(args mock.Arguments) { args.Get(1).(*medical.Appointment).ID = appointmentID }).Return(nil)
+		repo.On("RecordTransition", mock.Anything, patient, medical.AppointmentStatus(""), mock.MatchedBy(func(a *medical.Appointment) bool {
+			return a.ID == appointmentID
+		})).Return(nil)
+
+		// The patient id and status sent by the client are overridden
+		appointment := &medical.Appointment{DoctorID: uuid.New(), PatientID: uuid.New(), StartTime: start, EndTime: end, Status: medical.AppointmentStatusCompleted}
+		require.NoError(t, newTestAppointmentService(repo, time.Now()).Book(context.Background(), patient, appointment))
+
+		assert.Equal(t, appointmentID, appointment.ID)
+		assert.Equal(t, "Asia/Tehran", appointment.TimeZone)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("slot taken", func(t *testing.T) {
+		repo := new(MockAppointmentRepository)
+		repo.On("Create", mock.Anything, mock.Anything).Return(medical.ErrSlotTaken)
+
+		err := newTestAppointmentService(repo, time.Now()).Book(context.Background(), patient, &medical.Appointment{DoctorID: uuid.New(), StartTime: start, EndTime: end})
+
+		assert.ErrorIs(t, err, medical.ErrSlotTaken)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("only patients", func(t *testing.T) {
+		err := newTestAppointmentService(new(MockAppointmentRepository), time.Now()).
+			Book(context.Background(), auth.Principal{ID: patient.ID, Role: auth.RoleDoctor}, &medical.Appointment{})
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+	})
+}
+
+func TestAppointmentService_Book_OnlyDoctorsSlots(t *testing.T) {
+	tehran := mustLoadTehran(t)
+	patient := auth.Principal{ID: uuid.New(), Role: auth.RolePatient}
+	at := func(hour, minute int) time.Time { return time.Date(2025, 3, 10, hour, minute, 0, 0, tehran) }
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		timeOffs []medical.TimeOff
+		holidays []medical.Holiday
+	}{
+		{
+			name:  "before the working hours",
+			start: at(3, 0),
+			end:   at(3, 30),
+		},
+		{
+			name:  "not aligned to the slots",
+			start: at(9, 10),
+			end:   at(9, 40),
+		},
+		{
+			name:  "longer than a slot",
+			start: at(9, 0),
+			end:   at(10, 0),
+		},
+		{
+			name:     "during the doctor's leave",
+			start:    at(9, 0),
+			end:      at(9, 30),
+			timeOffs: []medical.TimeOff{{StartTime: at(8, 0), EndTime: at(12, 0)}},
+		},
+		{
+			name:     "on a public holiday",
+			start:    at(9, 0),
+			end:      at(9, 30),
+			holidays: []medical.Holiday{{Date: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), Name: "Holiday"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockAppointmentRepository)
+			s := newTestAppointmentService(repo, time.Now())
+			withAbsences(s, tt.timeOffs, tt.holidays)
+
+			err := s.Book(context.Background(), patient, &medical.Appointment{DoctorID: uuid.New(), StartTime: tt.start, EndTime: tt.end})
+
+			assert.ErrorIs(t, err, medical.ErrSlotUnavailable)
+			repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAppointmentService_Reschedule_OnlyDoctorsSlots(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	patient := auth.Principal{ID: uuid.New(), Role: auth.RolePatient}
+	appointmentID := uuid.New()
+	appointment := &medical.Appointment{ID: appointmentID, PatientID: patient.ID, StartTime: now.Add(48 * time.Hour), Status: medical.AppointmentStatusConfirmed}
+	repo := new(MockAppointmentRepository)
+	repo.On("GetByID", mock.Anything, appointmentID).Return(appointment, nil)
+	s := newTestAppointmentService(repo, now)
+	withAbsences(s, nil, []medical.Holiday{{Date: time.Date(2025, 3, 13, 0, 0, 0, 0, time.UTC), Name: "Holiday"}})
+
+	// 12:30 in Tehran on the holiday
+	newStart := now.Add(72 * time.Hour)
+	_, err := s.Reschedule(context.Background(), patient, appointmentID, newStart, newStart.Add(30*time.Minute))
+
+	assert.ErrorIs(t, err, medical.ErrSlotUnavailable)
+	repo.AssertNotCalled(t, "UpdateTimeRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAppointmentService_List_OwnAppointmentsOnly(t *testing.T) {
+	tehran := mustLoadTehran(t)
+	doctor := auth.Principal{ID: uuid.New(), Role: auth.RoleDoctor}
+	params := pagination.LimitOffsetParams{Page: 1, Limit: 10, BaseURL: "/appointments"}
+	require.NoError(t, params.Validate())
+	paginator := pagination.NewLimitOffsetPaginator[medical.Appointment](params)
+
+	// A filter on another doctor is replaced by the signed-in doctor
+	requested := medicalFilter.AppointmentQueryParam{DoctorID: uuid.NewString(), Status: "confirmed", From: "2025-03-01"}
+	applied := medicalFilter.AppointmentQueryParam{DoctorID: doctor.ID.String(), Status: "confirmed", From: "2025-03-01", Location: tehran}
+	repo := new(MockAppointmentRepository)
+	repo.On("Count", mock.Anything, applied).Return(1, nil)
+	repo.On("GetAllPaginated", mock.Anything, applied, paginator).Return([]medical.Appointment{{DoctorID: doctor.ID}}, nil)
+
+	result, err := newTestAppointmentService(repo, time.Now()).List(context.Background(), doctor, requested, paginator)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.TotalCount)
+	assert.Equal(t, "Asia/Tehran", result.Items[0].TimeZone)
+	repo.AssertExpectations(t)
+}
+
+func TestAppointmentService_List_DoctorUpcomingByDefault(t *testing.T) {
+	tehran := mustLoadTehran(t)
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	doctor := auth.Principal{ID: uuid.New(), Role: auth.RoleDoctor}
+	params := pagination.LimitOffsetParams{Page: 1, Limit: 10, BaseURL: "/appointments"}
+	require.NoError(t, params.Validate())
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		filters medicalFilter.AppointmentQueryParam
+		applied medicalFilter.AppointmentQueryParam
+	}{
+		{
+			name:    "no dates starts from today",
+			now:     now,
+			filters: medicalFilter.AppointmentQueryParam{},
+			applied: medicalFilter.AppointmentQueryParam{DoctorID: doctor.ID.String(), From: "2025-03-10", Location: tehran},
+		},
+		{
+			name:    "today is the doctor's, not UTC's",
+			now:     time.Date(2025, 3, 10, 21, 0, 0, 0, time.UTC),
+			filters: medicalFilter.AppointmentQueryParam{},
+			applied: medicalFilter.AppointmentQueryParam{DoctorID: doctor.ID.String(), From: "2025-03-11", Location: tehran},
+		},
+		{
+			name:    "an end date alone is kept",
+			now:     now,
+			filters: medicalFilter.AppointmentQueryParam{To: "2025-03-01"},
+			applied: medicalFilter.AppointmentQueryParam{DoctorID: doctor.ID.String(), To: "2025-03-01", Location: tehran},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paginator := pagination.NewLimitOffsetPaginator[medical.Appointment](params)
+			repo := new(MockAppointmentRepository)
+			repo.On("Count", mock.Anything, tt.applied).Return(0, nil)
+			repo.On("GetAllPaginated", mock.Anything, tt.applied, paginator).Return(nil, nil)
+
+			_, err := newTestAppointmentService(repo, tt.now).List(context.Background(), doctor, tt.filters, paginator)
+
+			require.NoError(t, err)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAppointmentService_List_PatientDaysInDoctorsZone(t *testing.T) {
+	tehran := mustLoadTehran(t)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	patient := auth.Principal{ID: uuid.New(), Role: auth.RolePatient}
+	berlinDoctor := uuid.New()
+	unknownDoctor := uuid.New()
+	params := pagination.LimitOffsetParams{Page: 1, Limit: 10, BaseURL: "/appointments"}
+	require.NoError(t, params.Validate())
+
+	tests := []struct {
+		name     string
+		doctorID string
+		location *time.Location
+	}{
+		{name: "no doctor filter reads days in the default zone", location: tehran},
+		{name: "doctor filter reads days in the doctor's zone", doctorID: berlinDoctor.String(), location: berlin},
+		{name: "unknown doctor reads days in the default zone", doctorID: unknownDoctor.String(), location: tehran},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paginator := pagination.NewLimitOffsetPaginator[medical.Appointment](params)
+			applied := medicalFilter.AppointmentQueryParam{PatientID: patient.ID.String(), DoctorID: tt.doctorID, From: "2025-03-10", To: "2025-03-10", Location: tt.location}
+			repo := new(MockAppointmentRepository)
+			repo.On("Count", mock.Anything, applied).Return(0, nil)
+			repo.On("GetAllPaginated", mock.Anything, applied, paginator).Return(nil, nil)
+			doctors := new(MockDoctorRepository)
+			doctors.On("GetByID", mock.Anything, berlinDoctor).Return(&medical.Doctor{TimeZone: "Europe/Berlin"}, nil).Maybe()
+			doctors.On("GetByID", mock.Anything, unknownDoctor).Return(nil, medical.ErrDoctorNotFound).Maybe()
+			s := newTestAppointmentService(repo, time.Now())
+			s.doctors = func(database.Querier) medicalRepo.DoctorRepository { return doctors }
+
+			_, err := s.List(context.Background(), patient, medicalFilter.AppointmentQueryParam{DoctorID: tt.doctorID, From: "2025-03-10", To: "2025-03-10"}, paginator)
+
+			require.NoError(t, err)
+			repo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/audit"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	auditRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/audit"
+	medicalRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+)
+
+// CatalogService lets admins change specialties and doctors. Every change is written to the audit log
+// in the same transaction, so a change is never kept without its entry.
+type CatalogService struct {
+	tx          Transactor
+	specialties func(database.Querier) medicalRepo.SpecialtyRepository
+	doctors     func(database.Querier) medicalRepo.DoctorRepository
+	audits      func(database.Querier) auditRepo.AuditRepository
+}
+
+func NewCatalogService(tx Transactor) *CatalogService {
+	return &CatalogService{
+		tx:          tx,
+		specialties: medicalRepo.NewSpecialtyRepository,
+		doctors:     medicalRepo.NewDoctorRepository,
+		audits:      auditRepo.NewAuditRepository,
+	}
+}
+
+// CreateSpecialty inserts the specialty and fills in the database generated fields
+func (s *CatalogService) CreateSpecialty(ctx context.Context, actor auth.Principal, specialty *medical.Specialty) error {
+	if actor.Role != auth.RoleAdmin {
+		return auth.ErrForbidden
+	}
+
+	var created medical.Specialty
+	err := s.tx.Do(ctx, func(tx *sql.Tx) error {
+		created = *specialty
+		if err := s.specialties(tx).Create(ctx, &created); err != nil {
+			return err
+		}
+		return s.record(ctx, tx, actor, audit.ActionCreate, audit.EntitySpecialty, created.ID, nil, &created)
+	})
+	if err != nil {
+		return err
+	}
+	*specialty = created
+	return nil
+}
+
+// UpdateSpecialty renames the specialty with the same id and refreshes the remaining fields
+func (s *CatalogService) UpdateSpecialty(ctx context.Context, actor auth.Principal, specialty *medical.Specialty) error {
+	if actor.Role != auth.RoleAdmin {
+		return auth.ErrForbidden
+	}
+
+	var updated medical.Specialty
+	err := s.tx.Do(ctx, func(tx *sql.Tx) error {
+		repo := s.specialties(tx)
+		before, err := repo.GetByIDForUpdate(ctx, specialty.ID)
+		if err != nil {
+			return err
+		}
+
+		updated = *specialty
+		if err := repo.Update(ctx, &updated); err != nil {
+			return err
+		}
+		return s.record(ctx, tx, actor, audit.ActionUpdate, audit.EntitySpecialty, updated.ID, before, &updated)
+	})
+	if err != nil {
+		return err
+	}
+	*specialty = updated
+	return nil
+}
+
+// DeleteSpecialty removes a specialty that no doctor belongs to anymore
+func (s *CatalogService) DeleteSpecialty(ctx context.Context, actor auth.Principal, id uuid.UUID) error {
+	if actor.Role != auth.RoleAdmin {
+		return auth.ErrForbidden
+	}
+
+	return s.tx.Do(ctx, func(tx *sql.Tx) error {
+		before, err := s.specialties(tx).Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.record(ctx, tx, actor, audit.ActionDelete, audit.EntitySpecialty, id, before, nil)
+	})
+}
+
+// CreateDoctor inserts the doctor and fills in the database generated fields
+func (s *CatalogService) CreateDoctor(ctx context.Context, actor auth.Principal, doctor *medical.Doctor) error {
+	if actor.Role != auth.RoleAdmin {
+		return auth.ErrForbidden
+	}
+
+	var created medical.Doctor
+	err := s.tx.Do(ctx, func(tx *sql.Tx) error {
+		created = *doctor
+		if err := s.doctors(tx).Create(ctx, &created); err != nil {
+			return err
+		}
+		return s.record(ctx, tx, actor, audit.ActionCreate, audit.EntityDoctor, created.ID, nil, &created)
+	})
+	if err != nil {
+		return err
+	}
+	*doctor = created
+	return nil
+}
+
+// UpdateDoctor overwrites the editable fields of the doctor with the same id
+func (s *CatalogService) UpdateDoctor(ctx context.Context, actor auth.Principal, doctor *medical.Doctor) error {
+	if actor.Role != auth.RoleAdmin {
+		return auth.ErrForbidden
+	}
+
+	var updated medical.Doctor
+	err := s.tx.Do(ctx, func(tx *sql.Tx) error {
+		repo := s.doctors(tx)
+		before, err := repo.GetByIDForUpdate(ctx, doctor.ID)
+		if err != nil {
+			return err
+		}
+
+		updated = *doctor
+		if err := repo.Update(ctx, &updated); err != nil {
+			return err
+		}
+		return s.record(ctx, tx, actor, audit.ActionUpdate, audit.EntityDoctor, updated.ID, before, &updated)
+	})
+	if err != nil {
+		return err
+	}
+	*doctor = updated
+	return nil
+}
+
+// DeleteDoctor removes a doctor without appointments together with their schedules and time off
+func (s *CatalogService) DeleteDoctor(ctx context.Context, actor auth.Principal, id uuid.UUID) error {
+	if actor.Role != auth.RoleAdmin {
+		return auth.ErrForbidden
+	}
+
+	return s.tx.Do(ctx, func(tx *sql.Tx) error {
+		before, err := s.doctors(tx).Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.record(ctx, tx, actor, audit.ActionDelete, audit.EntityDoctor, id, before, nil)
+	})
+}
+
+// record writes the change to the audit log; a nil before or after is stored as NULL
+func (s *CatalogService) record(ctx context.Context, tx database.Querier, actor auth.Principal, action audit.Action, entityType string, entityID uuid.UUID, before, after any) error {
+	entry := audit.Entry{
+		ActorID:    actor.ID,
+		ActorRole:  actor.Role,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+
+	var err error
+	if entry.Before, err = auditDocument(before); err != nil {
+		return err
+	}
+	if entry.After, err = auditDocument(after); err != nil {
+		return err
+	}
+	return s.audits(tx).Record(ctx, &entry)
+}
+
+// auditDocument encodes the entity, leaving a nil entity empty
+func auditDocument(entity any) (json.RawMessage, error) {
+	if entity == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, fmt.Errorf("encode audit entry: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/audit"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	auditRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/audit"
+	medicalRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+)
+
+// catalogMocks groups the repositories the catalog service works with
+type catalogMocks struct {
+	specialties *MockSpecialtyRepository
+	doctors     *MockDoctorRepository
+	audits      *MockAuditRepository
+}
+
+func newTestCatalogService() (*CatalogService, catalogMocks) {
+	m := catalogMocks{
+		specialties: new(MockSpecialtyRepository),
+		doctors:     new(MockDoctorRepository),
+		audits:      new(MockAuditRepository),
+	}
+	s := NewCatalogService(fakeTransactor{})
+	s.specialties = func(database.Querier) medicalRepo.SpecialtyRepository { return m.specialties }
+	s.doctors = func(database.Querier) medicalRepo.DoctorRepository { return m.doctors }
+	s.audits = func(database.Querier) auditRepo.AuditRepository { return m.audits }
+	return s, m
+}
+
+// jsonField returns the raw value of key in an audit document
+func jsonField(document json.RawMessage, key string) string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(document, &fields); err != nil {
+		return ""
+	}
+	return string(fields[key])
+}
+
+func TestCatalogService_CreateSpecialty(t *testing.T) {
+	admin := auth.Principal{ID: uuid.New(), Role: auth.RoleAdmin}
+	specialtyID := uuid.New()
+
+	t.Run("creation is audited", func(t *testing.T) {
+		s, m := newTestCatalogService()
+		m.specialties.On("Create", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { args.Get(1).(*medical.Specialty).ID = specialtyID }).
+			Return(nil)
+		m.audits.On("Record", mock.Anything, mock.MatchedBy(func(e *audit.Entry) bool {
+			return e.ActorID == admin.ID && e.Action == audit.ActionCreate && e.EntityType == audit.EntitySpecialty &&
+				e.EntityID == specialtyID && e.Before == nil && jsonField(e.After, "name") == `"Cardiology"`
+		})).Return(nil)
+
+		specialty := &medical.Specialty{Name: "Cardiology"}
+		require.NoError(t, s.CreateSpecialty(context.Background(), admin, specialty))
+
+		assert.Equal(t, specialtyID, specialty.ID)
+		m.specialties.AssertExpectations(t)
+		m.audits.AssertExpectations(t)
+	})
+
+	t.Run("failed audit leaves the specialty untouched", func(t *testing.T) {
+		auditErr := errors.New("audit log unavailable")
+		s, m := newTestCatalogService()
+		m.specialties.On("Create", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { args.Get(1).(*medical.Specialty).ID = specialtyID }).
+			Return(nil)
+		m.audits.On("Record", mock.Anything, mock.Anything).Return(auditErr)
+
+		specialty := &medical.Specialty{Name: "Cardiology"}
+		err := s.CreateSpecialty(context.Background(), admin, specialty)
+
+		assert.ErrorIs(t, err, auditErr)
+		assert.Equal(t, uuid.Nil, specialty.ID)
+	})
+
+	t.Run("only admins", func(t *testing.T) {
+		s, m := newTestCatalogService()
+
+		err := s.CreateSpecialty(context.Background(), auth.Principal{ID: admin.ID, Role: auth.RoleDoctor}, &medical.Specialty{Name: "Cardiology"})
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+		m.specialties.AssertExpectations(t)
+	})
+}
+
+func TestCatalogService_UpdateDoctor(t *testing.T) {
+	admin := auth.Principal{ID: uuid.New(), Role: auth.RoleAdmin}
+	before := &medical.Doctor{ID: uuid.New(), Name: "Dr. Smith"}
+
+	t.Run("previous version is audited", func(t *testing.T) {
+		s, m := newTestCatalogService()
+		m.doctors.On("GetByIDForUpdate", mock.Anything, before.ID).Return(before, nil)
+		m.doctors.On("Update", mock.Anything, mock.Anything).Return(nil)
+		m.audits.On("Record", mock.Anything, mock.MatchedBy(func(e *audit.Entry) bool {
+			return e.Action == audit.ActionUpdate && e.EntityType == audit.EntityDoctor && e.EntityID == before.ID &&
+				jsonField(e.Before, "name") == `"Dr. Smith"` && jsonField(e.After, "name") == `"Dr. Jones"`
+		})).Return(nil)
+
+		doctor := &medical.Doctor{ID: before.ID, Name: "Dr. Jones"}
+		require.NoError(t, s.UpdateDoctor(context.Background(), admin, doctor))
+
+		m.doctors.AssertExpectations(t)
+		m.audits.AssertExpectations(t)
+	})
+
+	t.Run("unknown doctor", func(t *testing.T) {
+		s, m := newTestCatalogService()
+		m.doctors.On("GetByIDForUpdate", mock.Anything, before.ID).Return(nil, medical.ErrDoctorNotFound)
+
+		err := s.UpdateDoctor(context.Background(), admin, &medical.Doctor{ID: before.ID, Name: "Dr. Jones"})
+
+		assert.ErrorIs(t, err, medical.ErrDoctorNotFound)
+		m.doctors.AssertExpectations(t)
+		m.audits.AssertExpectations(t)
+	})
+}
+
+func TestCatalogService_DeleteSpecialty(t *testing.T) {
+	admin := auth.Principal{ID: uuid.New(), Role: auth.RoleAdmin}
+	specialty := &medical.Specialty{ID: uuid.New(), Name: "Cardiology"}
+
+	t.Run("deletion keeps the removed specialty", func(t *testing.T) {
+		s, m := newTestCatalogService()
+		m.specialties.On("Delete", mock.Anything, specialty.ID).Return(specialty, nil)
+		m.audits.On("Record", mock.Anything, mock.MatchedBy(func(e *audit.Entry) bool {
+			return e.Action == audit.ActionDelete && e.EntityID == specialty.ID &&
+				jsonField(e.Before, "name") == `"Cardiology"` && e.After == nil
+		})).Return(nil)
+
+		require.NoError(t, s.DeleteSpecialty(context.Background(), admin, specialty.ID))
+
+		m.specialties.AssertExpectations(t)
+		m.audits.AssertExpectations(t)
+	})
+
+	t.Run("specialty in use", func(t *testing.T) {
+		s, m := newTestCatalogService()
+		m.specialties.On("Delete", mock.Anything, specialty.ID).Return(nil, medical.ErrSpecialtyInUse)
+
+		err := s.DeleteSpecialty(context.Background(), admin, specialty.ID)
+
+		assert.ErrorIs(t, err, medical.ErrSpecialtyInUse)
+		m.audits.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+	medicalRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/scheduling"
+)
+
+// DoctorService serves the doctor catalog to patients and the profile to the doctor it belongs to
+type DoctorService struct {
+	doctors      medicalRepo.DoctorRepository
+	schedules    medicalRepo.ScheduleRepository
+	appointments medicalRepo.AppointmentRepository
+	timeOffs     medicalRepo.TimeOffRepository
+	holidays     medicalRepo.HolidayRepository
+	now          func() time.Time
+}
+
+func NewDoctorService(
+	doctors medicalRepo.DoctorRepository,
+	schedules medicalRepo.ScheduleRepository,
+	appointments medicalRepo.AppointmentRepository,
+	timeOffs medicalRepo.TimeOffRepository,
+	holidays medicalRepo.HolidayRepository,
+) *DoctorService {
+	return &DoctorService{
+		doctors:      doctors,
+		schedules:    schedules,
+		appointments: appointments,
+		timeOffs:     timeOffs,
+		holidays:     holidays,
+		now:          time.Now,
+	}
+}
+
+// List returns one page of the doctors matching filters together with the total count
+func (s *DoctorService) List(ctx context.Context, filters medicalFilter.DoctorQueryParam, paginator pagination.Paginator[medical.Doctor]) (*pagination.Result[medical.Doctor], error) {
+	totalCount, err := s.doctors.Count(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("count doctors: %w", err)
+	}
+
+	doctors, err := s.doctors.GetAllPaginated(ctx, filters, paginator)
+	if err != nil {
+		return nil, fmt.Errorf("list doctors: %w", err)
+	}
+
+	result, err := paginator.CreatePaginationResult(doctors, totalCount)
+	if err != nil {
+		return nil, fmt.Errorf("paginate doctors: %w", err)
+	}
+	return result, nil
+}
+
+// Get returns the doctor with its specialty
+func (s *DoctorService) Get(ctx context.Context, id uuid.UUID) (*medical.DoctorDetail, error) {
+	return s.doctors.GetDetailByID(ctx, id)
+}
+
+// FreeSlots expands the doctor's weekly schedule over days, read in the doctor's time zone, leaving out
+// slots that already started and those overlapping appointments, the doctor's time off or public holidays.
+func (s *DoctorService) FreeSlots(ctx context.Context, doctorID uuid.UUID, days scheduling.DateRange) (*medical.Availability, error) {
+	doctor, err := s.doctors.GetByID(ctx, doctorID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := doctor.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	window := days.In(loc)
+	if now := s.now().In(loc); window.Start.Before(now) {
+		window.Start = now
+	}
+
+	schedules, err := s.schedules.GetByDoctorID(ctx, doctorID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch doctor schedules: %w", err)
+	}
+
+	appointments, err := s.appointments.GetActiveByDoctorBetween(ctx, doctorID, window.Start, window.End)
+	if err != nil {
+		return nil, fmt.Errorf("fetch doctor appointments: %w", err)
+	}
+
+	timeOffs, err := s.timeOffs.GetByDoctorBetween(ctx, doctorID, window.Start, window.End)
+	if err != nil {
+		return nil, fmt.Errorf("fetch doctor time off: %w", err)
+	}
+
+	holidays, err := s.holidays.GetBetween(ctx, window.Start, window.End)
+	if err != nil {
+		return nil, fmt.Errorf("fetch holidays: %w", err)
+	}
+
+	busy := absences(timeOffs, holidays, loc)
+	for _, appointment := range appointments {
+		busy = append(busy, scheduling.Interval{Start: appointment.StartTime, End: appointment.EndTime})
+	}
+
+	return &medical.Availability{
+		TimeZone: loc.String(),
+		Slots:    scheduling.GenerateSlots(schedules, window, busy),
+	}, nil
+}
+
+// absences returns the intervals the doctor does not work outside the weekly schedule: the time off
+// and the whole days of public holidays in loc
+func absences(timeOffs []medical.TimeOff, holidays []medical.Holiday, loc *time.Location) []scheduling.Interval {
+	busy := make([]scheduling.Interval, 0, len(timeOffs)+len(holidays))
+	for _, timeOff := range timeOffs {
+		busy = append(busy, scheduling.Interval{Start: timeOff.StartTime, End: timeOff.EndTime})
+	}
+	for _, holiday := range holidays {
+		busy = append(busy, scheduling.DayInterval(holiday.Date, loc))
+	}
+	return busy
+}
+
+// Profile returns the signed-in doctor with its specialty
+func (s *DoctorService) Profile(ctx context.Context, actor auth.Principal) (*medical.DoctorDetail, error) {
+	if actor.Role != auth.RoleDoctor {
+		return nil, auth.ErrForbidden
+	}
+	return s.doctors.GetDetailByID(ctx, actor.ID)
+}
+
+// UpdateProfile changes the signed-in doctor's own profile fields
+func (s *DoctorService) UpdateProfile(ctx context.Context, actor auth.Principal, update medical.DoctorProfileUpdate) (*medical.Doctor, error) {
+	if actor.Role != auth.RoleDoctor {
+		return nil, auth.ErrForbidden
+	}
+	return s.doctors.UpdateProfile(ctx, actor.ID, update)
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+	"github.com/shayesteh1hs/DrAppointment/internal/scheduling"
+)
+
+func TestDoctorService_List(t *testing.T) {
+	filters := medicalFilter.DoctorQueryParam{Name: "Smith"}
+	doctors := []medical.Doctor{{ID: uuid.New()}, {ID: uuid.New()}}
+	newPaginator := func() *pagination.LimitOffsetPaginator[medical.Doctor] {
+		params := pagination.LimitOffsetParams{Page: 1, Limit: 10, BaseURL: "/doctors"}
+		require.NoError(t, params.Validate())
+		return pagination.NewLimitOffsetPaginator[medical.Doctor](params)
+	}
+
+	t.Run("page with total count", func(t *testing.T) {
+		repo := new(MockDoctorRepository)
+		paginator := newPaginator()
+		repo.On("Count", mock.Anything, filters).Return(12, nil)
+		repo.On("GetAllPaginated", mock.Anything, filters, paginator).Return(doctors, nil)
+
+		result, err := NewDoctorService(repo, nil, nil, nil, nil).List(context.Background(), filters, paginator)
+
+		require.NoError(t, err)
+		assert.Equal(t, 12, result.TotalCount)
+		assert.Len(t, result.Items, 2)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("count fails", func(t *testing.T) {
+		dbErr := errors.New("database error")
+		repo := new(MockDoctorRepository)
+		repo.On("Count", mock.Anything, filters).Return(0, dbErr)
+
+		_, err := NewDoctorService(repo, nil, nil, nil, nil).List(context.Background(), filters, newPaginator())
+
+		assert.ErrorIs(t, err, dbErr)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestDoctorService_FreeSlots(t *testing.T) {
+	doctorID := uuid.New()
+	now := time.Date(2025, 3, 10, 9, 10, 0, 0, time.UTC)
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	days := scheduling.DateRange{From: scheduling.DateOf(day), To: scheduling.DateOf(day)}
+	dayEnd := day.AddDate(0, 0, 1)
+	schedules := []medical.Schedule{{DoctorID: doctorID, DayOfWeek: now.Weekday(), StartTime: 9 * 60, EndTime: 11 * 60, SlotDuration: 30}}
+	tenOClock := day.Add(10 * time.Hour)
+
+	doctors := new(MockDoctorRepository)
+	scheduleRepo := new(MockScheduleRepository)
+	appointments := new(MockAppointmentRepository)
+	timeOffs := new(MockTimeOffRepository)
+	holidays := new(MockHolidayRepository)
+	doctors.On("GetByID", mock.Anything, doctorID).Return(&medical.Doctor{ID: doctorID}, nil)
+	scheduleRepo.On("GetByDoctorID", mock.Anything, doctorID).Return(schedules, nil)
+	// The window is queried from now on, not from midnight
+	appointments.On("GetActiveByDoctorBetween", mock.Anything, doctorID, now, dayEnd).
+		Return([]medical.Appointment{{StartTime: tenOClock, EndTime: tenOClock.Add(30 * time.Minute)}}, nil)
+	timeOffs.On("GetByDoctorBetween", mock.Anything, doctorID, now, dayEnd).Return(nil, nil)
+	holidays.On("GetBetween", mock.Anything, now, dayEnd).Return(nil, nil)
+
+	s := NewDoctorService(doctors, scheduleRepo, appointments, timeOffs, holidays)
+	s.now = func() time.Time { return now }
+	availability, err := s.FreeSlots(context.Background(), doctorID, days)
+
+	require.NoError(t, err)
+	// A doctor without a time zone works in UTC
+	assert.Equal(t, "UTC", availability.TimeZone)
+	// 9:00 already started and 10:00 is booked
+	require.Len(t, availability.Slots, 2)
+	assert.Equal(t, day.Add(9*time.Hour+30*time.Minute), availability.Slots[0].StartTime)
+	assert.Equal(t, day.Add(10*time.Hour+30*time.Minute), availability.Slots[1].StartTime)
+}
+
+func TestDoctorService_FreeSlots_DoctorTimeZone(t *testing.T) {
+	tehran, err := time.LoadLocation("Asia/Tehran")
+	require.NoError(t, err)
+
+	doctorID := uuid.New()
+	now := time.Date(2025, 3, 9, 12, 0, 0, 0, time.UTC)
+	// The patient asks for March 10; the doctor's March 10 starts at 20:30 UTC the day before
+	march10 := scheduling.Date{Year: 2025, Month: time.March, Day: 10}
+	days := scheduling.DateRange{From: march10, To: march10}
+	dayStart := time.Date(2025, 3, 10, 0, 0, 0, 0, tehran)
+	dayEnd := time.Date(2025, 3, 11, 0, 0, 0, 0, tehran)
+	schedules := []medical.Schedule{{DoctorID: doctorID, DayOfWeek: time.Monday, StartTime: 9 * 60, EndTime: 10 * 60, SlotDuration: 30}}
+
+	doctors := new(MockDoctorRepository)
+	scheduleRepo := new(MockScheduleRepository)
+	appointments := new(MockAppointmentRepository)
+	timeOffs := new(MockTimeOffRepository)
+	holidays := new(MockHolidayRepository)
+	doctors.On("GetByID", mock.Anything, doctorID).Return(&medical.Doctor{ID: doctorID, TimeZone: "Asia/Tehran"}, nil)
+	scheduleRepo.On("GetByDoctorID", mock.Anything, doctorID).Return(schedules, nil)
+	appointments.On("GetActiveByDoctorBetween", mock.Anything, doctorID, dayStart, dayEnd).Return(nil, nil)
+	timeOffs.On("GetByDoctorBetween", mock.Anything, doctorID, dayStart, dayEnd).Return(nil, nil)
+	holidays.On("GetBetween", mock.Anything, dayStart, dayEnd).Return(nil, nil)
+
+	s := NewDoctorService(doctors, scheduleRepo, appointments, timeOffs, holidays)
+	s.now = func() time.Time { return now }
+	availability, err := s.FreeSlots(context.Background(), doctorID, days)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tehran", availability.TimeZone)
+	require.Len(t, availability.Slots, 2)
+	first := availability.Slots[0]
+	assert.Equal(t, time.Date(2025, 3, 10, 5, 30, 0, 0, time.UTC), first.StartTime)
+	assert.Equal(t, time.UTC, first.StartTime.Location())
+	assert.Equal(t, "2025-03-10T09:00:00+03:30", first.LocalStartTime.Format(time.RFC3339))
+	assert.Equal(t, "2025-03-10T09:30:00+03:30", first.LocalEndTime.Format(time.RFC3339))
+}
+
+func TestDoctorService_FreeSlots_UnknownDoctor(t *testing.T) {
+	doctorID := uuid.New()
+	doctors := new(MockDoctorRepository)
+	doctors.On("GetByID", mock.Anything, doctorID).Return(nil, medical.ErrDoctorNotFound)
+
+	today := scheduling.DateOf(time.Now())
+	_, err := NewDoctorService(doctors, nil, nil, nil, nil).FreeSlots(context.Background(), doctorID, scheduling.DateRange{From: today, To: today})
+
+	assert.ErrorIs(t, err, medical.ErrDoctorNotFound)
+}
+
+func TestDoctorService_UpdateProfile_OnlyOwnProfile(t *testing.T) {
+	description := "Cardiologist"
+	update := medical.DoctorProfileUpdate{Description: &description}
+	doctor := auth.Principal{ID: uuid.New(), Role: auth.RoleDoctor}
+
+	repo := new(MockDoctorRepository)
+	repo.On("UpdateProfile", mock.Anything, doctor.ID, update).Return(&medical.Doctor{ID: doctor.ID, Description: description}, nil)
+	s := NewDoctorService(repo, nil, nil, nil, nil)
+
+	updated, err := s.UpdateProfile(context.Background(), doctor, update)
+	require.NoError(t, err)
+	assert.Equal(t, description, updated.Description)
+
+	_, err = s.UpdateProfile(context.Background(), auth.Principal{ID: doctor.ID, Role: auth.RolePatient}, update)
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/audit"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	"github.com/shayesteh1hs/DrAppointment/internal/pagination"
+)
+
+// fakeTransactor runs fn once without a transaction; the repositories it hands out are mocks
+type fakeTransactor struct{}
+
+func (fakeTransactor) Do(_ context.Context, fn func(tx *sql.Tx) error) error {
+	return fn(nil)
+}
+
+type MockAppointmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAppointmentRepository) Create(ctx context.Context, appointment *medical.Appointment) error {
+	args := m.Called(ctx, appointment)
+	return args.Error(0)
+}
+
+func (m *MockAppointmentRepository) GetAllPaginated(ctx context.Context, filters medicalFilter.AppointmentQueryParam, paginator *pagination.LimitOffsetPaginator[medical.Appointment]) ([]medical.Appointment, error) {
+	args := m.Called(ctx, filters, paginator)
+	var out []medical.Appointment
+	if v := args.Get(0); v != nil {
+		out = v.([]medical.Appointment)
+	}
+	return out, args.Error(1)
+}
+
+func (m *MockAppointmentRepository) Count(ctx context.Context, filters medicalFilter.AppointmentQueryParam) (int, error) {
+	args := m.Called(ctx, filters)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAppointmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*medical.Appointment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*medical.Appointment), args.Error(1)
+}
+
+func (m *MockAppointmentRepository) UpdateStatus(ctx context.Context, appointment *medical.Appointment, to medical.AppointmentStatus) error {
+	args := m.Called(ctx, appointment, to)
+	return args.Error(0)
+}
+
+func (m *MockAppointmentRepository) UpdateTimeRange(ctx context.Context, appointment *medical.Appointment, startTime, endTime time.Time) error {
+	args := m.Called(ctx, appointment, startTime, endTime)
+	return args.Error(0)
+}
+
+func (m *MockAppointmentRepository) RecordTransition(ctx context.Context, actor auth.Principal, from medical.AppointmentStatus, appointment *medical.Appointment) error {
+	args := m.Called(ctx, actor, from, appointment)
+	return args.Error(0)
+}
+
+func (m *MockAppointmentRepository) GetTransitions(ctx context.Context, appointmentID uuid.UUID) ([]medical.AppointmentTransition, error) {
+	args := m.Called(ctx, appointmentID)
+	var out []medical.AppointmentTransition
+	if v := args.Get(0); v != nil {
+		out = v.([]medical.AppointmentTransition)
+	}
+	return out, args.Error(1)
+}
+
+func (m *MockAppointmentRepository) GetActiveByDoctorBetween(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]medical.Appointment, error) {
+	args := m.Called(ctx, doctorID, from, to)
+	var out []medical.Appointment
+	if v := args.Get(0); v != nil {
+		out = v.([]medical.Appointment)
+	}
+	return out, args.Error(1)
+}
+
+type MockDoctorRepository struct {
+	mock.Mock
+}
+
+func (m *MockDoctorRepository) GetAllPaginated(ctx context.Context, filters medicalFilter.DoctorQueryParam, paginator pagination.Paginator[medical.Doctor]) ([]medical.Doctor, error) {
+	args := m.Called(ctx, filters, paginator)
+	var out []medical.Doctor
+	if v := args.Get(0); v != nil {
+		out = v.([]medical.Doctor)
+	}
+	return out, args.Error(1)
+}
+
+func (m *MockDoctorRepository) Count(ctx context.Context, filters medicalFilter.DoctorQueryParam) (int, error) {
+	args := m.Called(ctx, filters)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockDoctorRepository) GetByID(ctx context.Context, id uuid.UUID) (*medical.Doctor, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*medical.Doctor), args.Error(1)
+}
+
+func (m *MockDoctorRepository) GetDetailByID(ctx context.Context, id uuid.UUID) (*medical.DoctorDetail, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*medical.DoctorDetail), args.Error(1)
+}
+
+func (m *MockDoctorRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*medical.Doctor, error) {
+	args := m.Called(ctx, phoneNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*medical.Doctor), args.Error(1)
+}
+
+func (m *MockDoctorRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*medical.Doctor, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*medical.Doctor), args.Error(1)
+}
+
+func (m *MockDoctorRepository) Create(ctx context.Context, doctor *medical.Doctor) error {
+	args := m.Called(ctx, doctor)
+	return args.Error(0)
+}
+
+func (m *MockDoctorRepository) Update(ctx context.Context, doctor *medical.Doctor) error {
+	args := m.Called(ctx, doctor)
+	return args.Error(0)
+}
+
+func (m *MockDoctorRepository) Delete(ctx context.Context, id uuid.UUID) (*medical.Doctor, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*medical.Doctor), args.Error(1)
+}
+
+func (m *MockDoctorRepository) UpdateProfile(ctx context.Context, id uuid.UUID, update medical.DoctorProfileUpdate) (*medical.Doctor, error) {
+	args := m.Called(ctx, id, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*medical.Doctor), args.Error(1)
+}
+
+type MockScheduleRepository struct {
+	mock.Mock
+}
+
+func (m *MockScheduleRepository) GetByDoctorID(ctx context.Context, doctorID uuid.UUID) ([]medical.Schedule, error) {
+	args := m.Called(ctx, doctorID)
+	var out []medical.Schedule
+	if v := args.Get(0); v != nil {
+		out = v.([]medical.Schedule)
+	}
+	return out, args.Error(1)
+}
+
+func (m *MockScheduleRepository) DeleteByDoctorID(ctx context.Context, doctorID uuid.UUID) error {
+	args := m.Called(ctx, doctorID)
+	return args.Error(0)
+}
+
+func (m *MockScheduleRepository) Create(ctx context.Context, doctorID uuid.UUID, schedules []medical.Schedule) ([]medical.Schedule, error) {
+	args := m.Called(ctx, doctorID, schedules)
+	var out []medical.Schedule
+	if v := args.Get(0); v != nil {
+		out = v.([]medical.Schedule)
+	}
+	return out, args.Error(1)
+}
+
+type MockTimeOffRepository struct {
+	mock.Mock
+}
+
+func (m *MockTimeOffRepository) GetByDoctorBetween(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]medical.TimeOff, error) {
+	args := m.Called(ctx, doctorID, from, to)
+	var out []medical.TimeOff
+	if v := args.Get(0); v != nil {
+		out = v.([]medical.TimeOff)
+	}
+	return out, args.Error(1)
+}
+
+func (m *MockTimeOffRepository) GetByDoctorEndingAfter(ctx context.Context, doctorID uuid.UUID, after time.Time) ([]medical.TimeOff, error) {
+	args := m.Called(ctx, doctorID, after)
+	var out []medical.TimeOff
+	if v := args.Get(0); v != nil {
+		out = v.([]medical.TimeOff)
+	}
+	return out, args.Error(1)
+}
+
+func (m *MockTimeOffRepository) Create(ctx context.Context, timeOff *medical.TimeOff) error {
+	args := m.Called(ctx, timeOff)
+	return args.Error(0)
+}
+
+func (m *MockTimeOffRepository) Delete(ctx context.Context, doctorID, id uuid.UUID) error {
+	args := m.Called(ctx, doctorID, id)
+	return args.Error(0)
+}
+
+type MockHolidayRepository struct {
+	mock.Mock
+}
+
+func (m *MockHolidayRepository) GetBetween(ctx context.Context, from, to time.Time) ([]medical.Holiday, error) {
+	args := m.Called(ctx, from, to)
+	var out []medical.Holiday
+	if v := args.Get(0); v != nil {
+		out = v.([]medical.Holiday)
+	}
+	return out, args.Error(1)
+}
+
+func (m *MockHolidayRepository) Upsert(ctx context.Context, holidays []medical.Holiday) (int64, error) {
+	args := m.Called(ctx, holidays)
+	return int64(args.Int(0)), args.Error(1)
+}
+
+type MockSpecialtyRepository struct {
+	mock.Mock
+}
+
+func (m *MockSpecialtyRepository) GetAllWithDoctorCount(ctx context.Context, filters medicalFilter.SpecialtyQueryParam) ([]medical.SpecialtySummary, error) {
+	args := m.Called(ctx, filters)
+	var out []medical.SpecialtySummary
+	if v := args.Get(0); v != nil {
+		out = v.([]medical.SpecialtySummary)
+	}
+	return out, args.Error(1)
+}
+
+func (m *MockSpecialtyRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*medical.Specialty, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*medical.Specialty), args.Error(1)
+}
+
+func (m *MockSpecialtyRepository) Create(ctx context.Context, specialty *medical.Specialty) error {
+	args := m.Called(ctx, specialty)
+	return args.Error(0)
+}
+
+func (m *MockSpecialtyRepository) Update(ctx context.Context, specialty *medical.Specialty) error {
+	args := m.Called(ctx, specialty)
+	return args.Error(0)
+}
+
+func (m *MockSpecialtyRepository) Delete(ctx context.Context, id uuid.UUID) (*medical.Specialty, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*medical.Specialty), args.Error(1)
+}
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Record(ctx context.Context, entry *audit.Entry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
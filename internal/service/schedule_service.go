@@ -0,0 +1,81 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"slices"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+)
+
+// ScheduleService manages the weekly working hours of the signed-in doctor
+type ScheduleService struct {
+	db        database.Querier
+	tx        Transactor
+	doctors   func(database.Querier) medicalRepo.DoctorRepository
+	schedules func(database.Querier) medicalRepo.ScheduleRepository
+}
+
+func NewScheduleService(db database.Querier, tx Transactor) *ScheduleService {
+	return &ScheduleService{
+		db:        db,
+		tx:        tx,
+		doctors:   medicalRepo.NewDoctorRepository,
+		schedules: medicalRepo.NewScheduleRepository,
+	}
+}
+
+// Get returns the doctor's weekly schedule, ordered by day and start time
+func (s *ScheduleService) Get(ctx context.Context, actor auth.Principal) ([]medical.Schedule, error) {
+	if actor.Role != auth.RoleDoctor {
+		return nil, auth.ErrForbidden
+	}
+
+	schedules, err := s.schedules(s.db).GetByDoctorID(ctx, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	if schedules == nil {
+		schedules = []medical.Schedule{}
+	}
+	return schedules, nil
+}
+
+// Replace swaps the doctor's whole weekly schedule for schedules in one transaction.
+// Booked appointments are kept even when they fall outside the new schedule.
+func (s *ScheduleService) Replace(ctx context.Context, actor auth.Principal, schedules []medical.Schedule) ([]medical.Schedule, error) {
+	if actor.Role != auth.RoleDoctor {
+		return nil, auth.ErrForbidden
+	}
+
+	var saved []medical.Schedule
+	err := s.tx.Do(ctx, func(tx *sql.Tx) error {
+		// Locking the doctor serializes concurrent replacements, which would otherwise both insert their rows
+		if _, err := s.doctors(tx).GetByIDForUpdate(ctx, actor.ID); err != nil {
+			return err
+		}
+
+		repo := s.schedules(tx)
+		if err := repo.DeleteByDoctorID(ctx, actor.ID); err != nil {
+			return err
+		}
+		var err error
+		saved, err = repo.Create(ctx, actor.ID, schedules)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if saved == nil {
+		saved = []medical.Schedule{}
+	}
+	slices.SortFunc(saved, func(a, b medical.Schedule) int {
+		return cmp.Or(cmp.Compare(a.DayOfWeek, b.DayOfWeek), cmp.Compare(a.StartTime, b.StartTime))
+	})
+	return saved, nil
+}
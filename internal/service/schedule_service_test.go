@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+)
+
+func newTestScheduleService(doctors *MockDoctorRepository, schedules *MockScheduleRepository) *ScheduleService {
+	s := NewScheduleService(nil, fakeTransactor{})
+	s.doctors = func(database.Querier) medicalRepo.DoctorRepository { return doctors }
+	s.schedules = func(database.Querier) medicalRepo.ScheduleRepository { return schedules }
+	return s
+}
+
+func TestScheduleService_Replace(t *testing.T) {
+	doctor := auth.Principal{ID: uuid.New(), Role: auth.RoleDoctor}
+	afternoon := medical.Schedule{DayOfWeek: time.Saturday, StartTime: 16 * 60, EndTime: 19 * 60, SlotDuration: 20}
+	morning := medical.Schedule{DayOfWeek: time.Saturday, StartTime: 9 * 60, EndTime: 13 * 60, SlotDuration: 20}
+	sunday := medical.Schedule{DayOfWeek: time.Sunday, StartTime: 9 * 60, EndTime: 13 * 60, SlotDuration: 20}
+
+	t.Run("doctor is locked and the result sorted", func(t *testing.T) {
+		doctors := new(MockDoctorRepository)
+		schedules := new(MockScheduleRepository)
+		input := []medical.Schedule{afternoon, morning, sunday}
+		doctors.On("GetByIDForUpdate", mock.Anything, doctor.ID).Return(&medical.Doctor{ID: doctor.ID}, nil)
+		schedules.On("DeleteByDoctorID", mock.Anything, doctor.ID).Return(nil)
+		schedules.On("Create", mock.Anything, doctor.ID, input).Return(input, nil)
+
+		saved, err := newTestScheduleService(doctors, schedules).Replace(context.Background(), doctor, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, []medical.Schedule{sunday, morning, afternoon}, saved)
+		doctors.AssertExpectations(t)
+		schedules.AssertExpectations(t)
+	})
+
+	t.Run("empty schedule clears working hours", func(t *testing.T) {
+		doctors := new(MockDoctorRepository)
+		schedules := new(MockScheduleRepository)
+		doctors.On("GetByIDForUpdate", mock.Anything, doctor.ID).Return(&medical.Doctor{ID: doctor.ID}, nil)
+		schedules.On("DeleteByDoctorID", mock.Anything, doctor.ID).Return(nil)
+		schedules.On("Create", mock.Anything, doctor.ID, []medical.Schedule{}).Return(nil, nil)
+
+		saved, err := newTestScheduleService(doctors, schedules).Replace(context.Background(), doctor, []medical.Schedule{})
+
+		require.NoError(t, err)
+		assert.NotNil(t, saved)
+		assert.Empty(t, saved)
+	})
+
+	t.Run("deleted doctor", func(t *testing.T) {
+		doctors := new(MockDoctorRepository)
+		schedules := new(MockScheduleRepository)
+		doctors.On("GetByIDForUpdate", mock.Anything, doctor.ID).Return(nil, medical.ErrDoctorNotFound)
+
+		_, err := newTestScheduleService(doctors, schedules).Replace(context.Background(), doctor, []medical.Schedule{morning})
+
+		assert.ErrorIs(t, err, medical.ErrDoctorNotFound)
+		schedules.AssertExpectations(t)
+	})
+
+	t.Run("only doctors", func(t *testing.T) {
+		_, err := newTestScheduleService(nil, nil).Replace(context.Background(), auth.Principal{ID: doctor.ID, Role: auth.RolePatient}, nil)
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+	})
+}
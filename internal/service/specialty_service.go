@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+	medicalRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+)
+
+// SpecialtyService serves the specialty catalog to patients
+type SpecialtyService struct {
+	specialties medicalRepo.SpecialtyRepository
+}
+
+func NewSpecialtyService(specialties medicalRepo.SpecialtyRepository) *SpecialtyService {
+	return &SpecialtyService{
+		specialties: specialties,
+	}
+}
+
+// List returns the specialties matching filters alphabetically, each with its number of doctors
+func (s *SpecialtyService) List(ctx context.Context, filters medicalFilter.SpecialtyQueryParam) ([]medical.SpecialtySummary, error) {
+	specialties, err := s.specialties.GetAllWithDoctorCount(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("list specialties: %w", err)
+	}
+	if specialties == nil {
+		specialties = []medical.SpecialtySummary{}
+	}
+	return specialties, nil
+}
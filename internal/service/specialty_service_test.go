@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	medicalFilter "github.com/shayesteh1hs/DrAppointment/internal/filter/medical"
+)
+
+func TestSpecialtyService_List_EmptyCatalog(t *testing.T) {
+	repo := new(MockSpecialtyRepository)
+	filters := medicalFilter.SpecialtyQueryParam{HasDoctors: true}
+	repo.On("GetAllWithDoctorCount", mock.Anything, filters).Return(nil, nil)
+
+	specialties, err := NewSpecialtyService(repo).List(context.Background(), filters)
+
+	require.NoError(t, err)
+	// An empty catalog is served as an empty list, not null
+	assert.NotNil(t, specialties)
+	assert.Empty(t, specialties)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+	medicalRepo "github.com/shayesteh1hs/DrAppointment/internal/repository/medical"
+)
+
+// TimeOffService manages the periods in which the signed-in doctor does not accept appointments.
+// Appointments already booked in a new period are kept; the doctor cancels them separately.
+type TimeOffService struct {
+	timeOffs medicalRepo.TimeOffRepository
+	now      func() time.Time
+}
+
+func NewTimeOffService(timeOffs medicalRepo.TimeOffRepository) *TimeOffService {
+	return &TimeOffService{
+		timeOffs: timeOffs,
+		now:      time.Now,
+	}
+}
+
+// List returns the doctor's time off that has not ended yet, earliest first
+func (s *TimeOffService) List(ctx context.Context, actor auth.Principal) ([]medical.TimeOff, error) {
+	if actor.Role != auth.RoleDoctor {
+		return nil, auth.ErrForbidden
+	}
+
+	timeOffs, err := s.timeOffs.GetByDoctorEndingAfter(ctx, actor.ID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if timeOffs == nil {
+		timeOffs = []medical.TimeOff{}
+	}
+	return timeOffs, nil
+}
+
+// Create adds a time off period for the doctor and fills in the database generated fields
+func (s *TimeOffService) Create(ctx context.Context, actor auth.Principal, timeOff *medical.TimeOff) error {
+	if actor.Role != auth.RoleDoctor {
+		return auth.ErrForbidden
+	}
+
+	timeOff.DoctorID = actor.ID
+	return s.timeOffs.Create(ctx, timeOff)
+}
+
+// Delete removes one of the doctor's time off periods, making its slots bookable again
+func (s *TimeOffService) Delete(ctx context.Context, actor auth.Principal, id uuid.UUID) error {
+	if actor.Role != auth.RoleDoctor {
+		return auth.ErrForbidden
+	}
+	return s.timeOffs.Delete(ctx, actor.ID, id)
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/auth"
+	"github.com/shayesteh1hs/DrAppointment/internal/domain/medical"
+)
+
+func TestTimeOffService_List(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	doctor := auth.Principal{ID: uuid.New(), Role: auth.RoleDoctor}
+
+	repo := new(MockTimeOffRepository)
+	repo.On("GetByDoctorEndingAfter", mock.Anything, doctor.ID, now).Return(nil, nil)
+	s := NewTimeOffService(repo)
+	s.now = func() time.Time { return now }
+
+	timeOffs, err := s.List(context.Background(), doctor)
+
+	require.NoError(t, err)
+	assert.NotNil(t, timeOffs)
+	assert.Empty(t, timeOffs)
+	repo.AssertExpectations(t)
+}
+
+func TestTimeOffService_Create_ForSignedInDoctor(t *testing.T) {
+	doctor := auth.Principal{ID: uuid.New(), Role: auth.RoleDoctor}
+	start := time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	repo := new(MockTimeOffRepository)
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(t *medical.TimeOff) bool {
+		return t.DoctorID == doctor.ID && t.StartTime.Equal(start)
+	})).Return(nil)
+	s := NewTimeOffService(repo)
+
+	// The doctor id sent by the client is overridden
+	timeOff := &medical.TimeOff{DoctorID: uuid.New(), StartTime: start, EndTime: start.AddDate(0, 0, 2)}
+	require.NoError(t, s.Create(context.Background(), doctor, timeOff))
+
+	err := s.Create(context.Background(), auth.Principal{ID: doctor.ID, Role: auth.RolePatient}, timeOff)
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	repo.AssertExpectations(t)
+}
+
+func TestTimeOffService_Delete(t *testing.T) {
+	doctor := auth.Principal{ID: uuid.New(), Role: auth.RoleDoctor}
+	id := uuid.New()
+
+	repo := new(MockTimeOffRepository)
+	repo.On("Delete", mock.Anything, doctor.ID, id).Return(medical.ErrTimeOffNotFound)
+
+	err := NewTimeOffService(repo).Delete(context.Background(), doctor, id)
+
+	assert.ErrorIs(t, err, medical.ErrTimeOffNotFound)
+	repo.AssertExpectations(t)
+}
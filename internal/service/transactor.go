@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/shayesteh1hs/DrAppointment/internal/database"
+)
+
+// Transactor runs fn in a transaction that is committed only when fn succeeds. It is satisfied by
+// *database.UnitOfWork, which may run fn again after a serialization failure, so fn must only change
+// the database and leave its results to the caller once Do returns.
+type Transactor interface {
+	Do(ctx context.Context, fn func(tx *sql.Tx) error) error
+}
+
+var _ Transactor = (*database.UnitOfWork)(nil)
@@ -0,0 +1,72 @@
+package validation
+
+// problemMessagesFa translates the English problem titles and messages of the API to Persian.
+// Messages built from an error at runtime, e.g. with its values, have no entry and are shown in English.
+var problemMessagesFa = map[string]string{
+	// Titles, the status texts of the statuses the API reports
+	"Bad Request":           "درخواست نامعتبر",
+	"Unauthorized":          "احراز هویت نشده",
+	"Forbidden":             "دسترسی غیرمجاز",
+	"Not Found":             "یافت نشد",
+	"Conflict":              "تعارض",
+	"Unprocessable Entity":  "درخواست قابل پردازش نیست",
+	"Too Many Requests":     "درخواست‌های بیش از حد",
+	"Internal Server Error": "خطای داخلی سرور",
+
+	// Request errors
+	"Invalid doctor id":                                      "شناسه پزشک نامعتبر است",
+	"Invalid specialty id":                                   "شناسه تخصص نامعتبر است",
+	"Invalid appointment id":                                 "شناسه نوبت نامعتبر است",
+	"Invalid time off id":                                    "شناسه مرخصی نامعتبر است",
+	"Invalid filter parameters":                              "پارامترهای فیلتر نامعتبر است",
+	"Invalid pagination parameters":                          "پارامترهای صفحه‌بندی نامعتبر است",
+	"Invalid slot parameters":                                "پارامترهای زمان‌های آزاد نامعتبر است",
+	"Invalid appointment request":                            "درخواست نوبت نامعتبر است",
+	"Invalid reschedule request":                             "درخواست تغییر زمان نوبت نامعتبر است",
+	"Invalid doctor request":                                 "درخواست پزشک نامعتبر است",
+	"Invalid patient request":                                "درخواست بیمار نامعتبر است",
+	"Invalid specialty request":                              "درخواست تخصص نامعتبر است",
+	"Invalid schedule request":                               "درخواست برنامه کاری نامعتبر است",
+	"Invalid time off request":                               "درخواست مرخصی نامعتبر است",
+	"Invalid profile update request":                         "درخواست ویرایش پروفایل نامعتبر است",
+	"Invalid login code request":                             "درخواست کد ورود نامعتبر است",
+	"Invalid login code verification request":                "درخواست تأیید کد ورود نامعتبر است",
+	"Invalid refresh request":                                "درخواست تمدید نشست نامعتبر است",
+	"Specialty does not exist":                               "تخصص وجود ندارد",
+	"No route matches the request":                           "مسیری با این درخواست مطابقت ندارد",
+	"Validation failed":                                      "اعتبارسنجی ناموفق بود",
+	"Internal server error":                                  "خطای داخلی سرور",
+	"end_time must be after start_time":                      "end_time باید بعد از start_time باشد",
+	"start_time must be in the future":                       "start_time باید در آینده باشد",
+	"end_time must be in the future":                         "end_time باید در آینده باشد",
+	"slot_duration_minutes is longer than the working hours": "slot_duration_minutes از ساعات کاری طولانی‌تر است",
+	"working hours of a day overlap":                         "ساعات کاری یک روز با هم همپوشانی دارند",
+
+	// Domain errors
+	"Doctor not found":                                                 "پزشک یافت نشد",
+	"Specialty not found":                                              "تخصص یافت نشد",
+	"Patient not found":                                                "بیمار یافت نشد",
+	"Appointment not found":                                            "نوبت یافت نشد",
+	"Time off not found":                                               "مرخصی یافت نشد",
+	"The requested resource was not found":                             "منبع درخواست‌شده یافت نشد",
+	"The requested time slot is already booked":                        "این زمان قبلاً رزرو شده است",
+	"The doctor does not see patients at the requested time":           "پزشک در زمان درخواست‌شده پذیرش ندارد",
+	"Appointment can no longer be cancelled":                           "دیگر امکان لغو این نوبت وجود ندارد",
+	"Only pending appointments that have not started can be confirmed": "فقط نوبت‌های در انتظاری که شروع نشده‌اند قابل تأیید هستند",
+	"Only active appointments that have started can be marked as completed or no-show":     "فقط نوبت‌های فعالی که شروع شده‌اند را می‌توان انجام‌شده یا عدم حضور ثبت کرد",
+	"Only pending or confirmed appointments can be rescheduled":                            "فقط زمان نوبت‌های در انتظار یا تأییدشده قابل تغییر است",
+	"The appointment's current status does not allow this change":                          "وضعیت فعلی نوبت اجازه این تغییر را نمی‌دهد",
+	"The appointment starts too soon to be cancelled":                                      "زمان شروع نوبت برای لغو بسیار نزدیک است",
+	"The appointment starts too soon to be rescheduled":                                    "زمان شروع نوبت برای تغییر زمان بسیار نزدیک است",
+	"The specialty still has doctors; move them to another specialty or delete them first": "این تخصص هنوز پزشک دارد؛ ابتدا آن‌ها را به تخصص دیگری منتقل یا حذف کنید",
+	"The doctor has appointments on record and can not be deleted":                         "این پزشک نوبت ثبت‌شده دارد و قابل حذف نیست",
+	"Another specialty already has this name":                                              "تخصص دیگری با این نام وجود دارد",
+	"The phone number is already used by another doctor":                                   "این شماره تلفن متعلق به پزشک دیگری است",
+	"The login code is incorrect":                                                          "کد ورود نادرست است",
+	"The login code has expired, request a new one":                                        "کد ورود منقضی شده است، کد جدیدی درخواست کنید",
+	"Too many wrong login codes, request a new one":                                        "تعداد کدهای نادرست بیش از حد است، کد جدیدی درخواست کنید",
+	"A login code was sent recently, wait before requesting another":                       "کد ورود به‌تازگی ارسال شده است، پیش از درخواست دوباره صبر کنید",
+	"The token is invalid or has expired":                                                  "توکن نامعتبر یا منقضی شده است",
+	"A bearer token is required":                                                           "توکن احراز هویت لازم است",
+	"You are not allowed to access this resource":                                          "اجازه دسترسی به این منبع را ندارید",
+}
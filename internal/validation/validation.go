@@ -0,0 +1,198 @@
+// Package validation makes validators name fields after their request tags and describes
+// their failures, and the problem messages of the API, in the client's language
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/fa"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	faTranslations "github.com/go-playground/validator/v10/translations/fa"
+)
+
+// DefaultLanguage is used when the client accepts none of the supported languages
+const DefaultLanguage = "en"
+
+// invalidValueKey names the message of tags without a translation of their own
+const invalidValueKey = "invalid_value"
+
+var invalidValueMessages = map[string]string{
+	"en": "{0} is invalid",
+	"fa": "{0} نامعتبر است",
+}
+
+// Translator describes failures in the client's language: the field errors of the validators
+// registered on it and the English problem messages of the API. A validator's messages are only
+// known to the translator it was registered on.
+type Translator struct {
+	uni *ut.UniversalTranslator
+}
+
+// NewTranslator returns a translator of the problem messages without any validator registered on it.
+// The bundled messages are static, so failing to add them is a programming error.
+func NewTranslator() *Translator {
+	uni := ut.New(en.New(), en.New(), fa.New())
+	for language, messages := range map[string]map[string]string{"en": nil, "fa": problemMessagesFa} {
+		trans, _ := uni.GetTranslator(language)
+		if err := trans.Add(invalidValueKey, invalidValueMessages[language], false); err != nil {
+			panic(fmt.Errorf("add %s messages: %w", language, err))
+		}
+		for message, translation := range messages {
+			if err := trans.Add(message, translation, false); err != nil {
+				panic(fmt.Errorf("add %s messages: %w", language, err))
+			}
+		}
+	}
+	return &Translator{uni: uni}
+}
+
+// Validator is a validator together with the translator of its messages
+type Validator struct {
+	*validator.Validate
+	translator *Translator
+}
+
+// Errors are the field errors of a Validator with the translator that describes them
+type Errors struct {
+	validator.ValidationErrors
+	Translator *Translator
+}
+
+func (e *Errors) Unwrap() error {
+	return e.ValidationErrors
+}
+
+// New returns a validator set up with Register
+func New() *Validator {
+	v := validator.New()
+	translator, err := Register(v)
+	if err != nil {
+		panic(err)
+	}
+	return &Validator{Validate: v, translator: translator}
+}
+
+// Struct validates s; field errors are returned as *Errors so they are described by the translator
+// of this validator
+func (v *Validator) Struct(s any) error {
+	err := v.Validate.Struct(s)
+	var fieldErrors validator.ValidationErrors
+	if errors.As(err, &fieldErrors) {
+		return &Errors{ValidationErrors: fieldErrors, Translator: v.translator}
+	}
+	return err
+}
+
+// RegisterBinding sets up the validator gin uses for the binding tags of requests and returns
+// the translator its errors are described with
+func RegisterBinding() (*Translator, error) {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil, fmt.Errorf("unexpected binding validator %T", binding.Validator.Engine())
+	}
+	return Register(v)
+}
+
+// Register makes v report fields by their json or form name and returns a new translator with the
+// en and fa messages of its built-in tags
+func Register(v *validator.Validate) (*Translator, error) {
+	v.RegisterTagNameFunc(TagName)
+
+	translator := NewTranslator()
+	for language, register := range map[string]func(*validator.Validate, ut.Translator) error{
+		"en": enTranslations.RegisterDefaultTranslations,
+		"fa": faTranslations.RegisterDefaultTranslations,
+	} {
+		trans, _ := translator.uni.GetTranslator(language)
+		if err := register(v, trans); err != nil {
+			return nil, fmt.Errorf("register %s translations: %w", language, err)
+		}
+	}
+	return translator, nil
+}
+
+// TagName is the name a client knows the field by: its json name, else its form name.
+// Fields without either keep their Go name.
+func TagName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return ""
+}
+
+// Message describes fieldError in the first of languages that is supported. Tags without a
+// translation, and errors of validators not registered on t, are described as invalid values.
+func (t *Translator) Message(fieldError validator.FieldError, languages []string) string {
+	trans, _ := t.uni.FindTranslator(languages...)
+	if message := fieldError.Translate(trans); message != fieldError.Error() {
+		return message
+	}
+	message, err := trans.T(invalidValueKey, fieldError.Field())
+	if err != nil {
+		return fieldError.Error()
+	}
+	return message
+}
+
+// Text translates an English problem message to the first of languages that is supported.
+// Messages without a translation, e.g. those built from errors, are kept in English.
+func (t *Translator) Text(message string, languages []string) string {
+	trans, _ := t.uni.FindTranslator(languages...)
+	if translated, err := trans.T(message); err == nil {
+		return translated
+	}
+	return message
+}
+
+// Languages lists the primary language subtags of an Accept-Language header, most preferred
+// first. Languages with q=0 are left out.
+func Languages(acceptLanguage string) []string {
+	type weighted struct {
+		language string
+		q        float64
+	}
+
+	var ranges []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if language == "" || language == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		ranges = append(ranges, weighted{language: language, q: q})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+
+	languages := make([]string, len(ranges))
+	for i, r := range ranges {
+		languages[i] = r.language
+	}
+	return languages
+}
@@ -0,0 +1,125 @@
+package validation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguages(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       []string
+	}{
+		{
+			name:           "empty header",
+			acceptLanguage: "",
+			expected:       []string{},
+		},
+		{
+			name:           "single language with region",
+			acceptLanguage: "fa-IR",
+			expected:       []string{"fa"},
+		},
+		{
+			name:           "ordered by quality",
+			acceptLanguage: "en;q=0.5, fa;q=0.9, de",
+			expected:       []string{"de", "fa", "en"},
+		},
+		{
+			name:           "equal quality keeps header order",
+			acceptLanguage: "EN-us,fa",
+			expected:       []string{"en", "fa"},
+		},
+		{
+			name:           "wildcard, refused and malformed ranges are skipped",
+			acceptLanguage: "*, fa;q=0, en;q=abc, de;q=0.1",
+			expected:       []string{"de"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Languages(tt.acceptLanguage))
+		})
+	}
+}
+
+func TestTagName(t *testing.T) {
+	type request struct {
+		PhoneNumber string `json:"phone_number,omitempty" form:"phone"`
+		Limit       int    `form:"limit,default=10"`
+		Secret      string `json:"-" form:"secret"`
+		Internal    string `json:"-"`
+		Plain       string
+	}
+
+	tests := []struct {
+		field    string
+		expected string
+	}{
+		{field: "PhoneNumber", expected: "phone_number"},
+		{field: "Limit", expected: "limit"},
+		{field: "Secret", expected: "secret"},
+		{field: "Internal", expected: ""},
+		{field: "Plain", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			field, ok := reflect.TypeOf(request{}).FieldByName(tt.field)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expected, TagName(field))
+		})
+	}
+}
+
+func TestRegisterBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	translator, err := RegisterBinding()
+	require.NoError(t, err)
+
+	type query struct {
+		Limit int `form:"limit" binding:"min=1"`
+	}
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/doctors?limit=0", nil)
+
+	var fieldErrors validator.ValidationErrors
+	require.True(t, errors.As(c.ShouldBindQuery(&query{}), &fieldErrors))
+	require.Len(t, fieldErrors, 1)
+	assert.Equal(t, "limit", fieldErrors[0].Field())
+	assert.Equal(t, "limit must be 1 or greater", translator.Message(fieldErrors[0], nil))
+}
+
+func TestNew(t *testing.T) {
+	type request struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	// Each validator has a translator of its own, so several can be set up side by side
+	first, second := New(), New()
+	for _, v := range []*Validator{first, second} {
+		var fieldErrors *Errors
+		require.True(t, errors.As(v.Struct(request{}), &fieldErrors))
+		assert.Equal(t, "فیلد name اجباری میباشد", fieldErrors.Translator.Message(fieldErrors.ValidationErrors[0], []string{"fa"}))
+	}
+	assert.NoError(t, first.Struct(request{Name: "Sara"}))
+}
+
+func TestTranslator_Text(t *testing.T) {
+	translator := NewTranslator()
+
+	assert.Equal(t, "پزشک یافت نشد", translator.Text("Doctor not found", []string{"fa"}))
+	assert.Equal(t, "Doctor not found", translator.Text("Doctor not found", []string{"en", "fa"}))
+	assert.Equal(t, "Doctor not found", translator.Text("Doctor not found", nil))
+	assert.Equal(t, "invalid calendar", translator.Text("invalid calendar", []string{"fa"}))
+}